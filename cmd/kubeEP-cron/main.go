@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	awsCustomAuth "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/auth/aws_custom"
 	gcpCustomAuth "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/auth/gcp_custom"
 	log "github.com/sirupsen/logrus"
 )
@@ -18,6 +19,7 @@ func main() {
 	}
 
 	gcpCustomAuth.RegisterK8SGCPCustomAuthProvider()
+	awsCustomAuth.RegisterK8SAWSCustomAuthProvider()
 
 	runService(configData)
 }
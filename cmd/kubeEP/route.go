@@ -28,6 +28,46 @@ func buildRoute(handlers *handler.Handlers, router fiber.Router) {
 		},
 	)
 
+	router.Route(
+		"/aws", func(router fiber.Router) {
+			router.Route(
+				"/register", func(router fiber.Router) {
+					router.Post("/datacenter", handlers.AwsHandler.RegisterDatacenter)
+					router.Post("/clusters", handlers.AwsHandler.RegisterClusterWithDatacenter)
+				},
+			)
+			router.Get("/clusters", handlers.AwsHandler.GetClustersByDatacenterID)
+		},
+	)
+
+	router.Route(
+		"/azure", func(router fiber.Router) {
+			router.Route(
+				"/register", func(router fiber.Router) {
+					router.Post("/datacenter", handlers.AzureHandler.RegisterDatacenter)
+					router.Post("/clusters", handlers.AzureHandler.RegisterClusterWithDatacenter)
+				},
+			)
+			router.Get("/clusters", handlers.AzureHandler.GetClustersByDatacenterID)
+		},
+	)
+
+	router.Route(
+		"/kubeconfig", func(router fiber.Router) {
+			router.Route(
+				"/register", func(router fiber.Router) {
+					router.Post("/datacenter", handlers.KubeconfigHandler.RegisterDatacenter)
+					router.Post("/clusters", handlers.KubeconfigHandler.RegisterClusterWithDatacenter)
+				},
+			)
+			router.Get("/clusters", handlers.KubeconfigHandler.GetClustersByDatacenterID)
+			router.Put(
+				"/clusters/:cluster_id/capacity-backend",
+				handlers.KubeconfigHandler.UpdateCapacityBackend,
+			)
+		},
+	)
+
 	router.Route(
 		"/cluster", func(router fiber.Router) {
 			router.Get("/list", handlers.ClusterHandler.GetAllRegisteredClusters)
@@ -53,8 +93,46 @@ func buildRoute(handlers *handler.Handlers, router fiber.Router) {
 				"/status/hpa/:scheduled_hpa_config_id",
 				handlers.EventHandler.ListHPAStatusByScheduledHPAConfig,
 			)
+			router.Get(
+				"/status/workload/:scheduled_workload_config_id",
+				handlers.EventHandler.ListWorkloadStatusByScheduledWorkloadConfig,
+			)
 			router.Get("/:event_id", handlers.EventHandler.GetDetailedEvent)
+			router.Post("/:event_id/plan", handlers.EventHandler.PlanEvent)
+			router.Post("/:event_id/execute", handlers.EventHandler.ScheduleEventNow)
+			router.Post("/:event_id/cancel", handlers.EventHandler.CancelEvent)
+			router.Post("/:event_id/retry", handlers.EventHandler.RetryEvent)
+			router.Post("/:event_id/clone", handlers.EventHandler.CloneEvent)
 			router.Delete("/:event_id", handlers.EventHandler.DeleteEvent)
 		},
 	)
+
+	router.Route(
+		"/recurring-event", func(router fiber.Router) {
+			router.Post("/register", handlers.EventHandler.RegisterRecurringEvent)
+			router.Get("/list", handlers.EventHandler.ListRecurringEventByCluster)
+			router.Get(
+				"/:recurring_event_id/occurrences",
+				handlers.EventHandler.ListRecurringEventOccurrences,
+			)
+			router.Put(
+				"/:recurring_event_id/occurrence",
+				handlers.EventHandler.UpdateRecurringEventOccurrence,
+			)
+			router.Delete("/:recurring_event_id", handlers.EventHandler.DeleteRecurringEvent)
+		},
+	)
+
+	router.Route(
+		"/event-template", func(router fiber.Router) {
+			router.Post("/register", handlers.EventHandler.RegisterEventTemplate)
+			router.Get("/list", handlers.EventHandler.ListEventTemplateByCluster)
+			router.Get("/:event_template_id", handlers.EventHandler.GetDetailedEventTemplate)
+			router.Post(
+				"/:event_template_id/instantiate",
+				handlers.EventHandler.InstantiateEventTemplate,
+			)
+			router.Delete("/:event_template_id", handlers.EventHandler.DeleteEventTemplate)
+		},
+	)
 }
@@ -0,0 +1,24 @@
+package constant
+
+type CapacityBackend = string
+
+const (
+	CapacityBackendNone              CapacityBackend = "none"
+	CapacityBackendClusterAutoscaler CapacityBackend = "cluster-autoscaler"
+	CapacityBackendKarpenter         CapacityBackend = "karpenter"
+)
+
+const (
+	ClusterAPIMachineDeploymentListPath = "/apis/cluster.x-k8s.io/v1beta1/machinedeployments"
+	ClusterAPIMachineDeploymentPath     = "/apis/cluster.x-k8s.io/v1beta1/namespaces/%s/machinedeployments/%s"
+	ClusterAPIDeploymentLabel           = "cluster.x-k8s.io/deployment-name"
+	ClusterAutoscalerMinSizeAnnotation  = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size"
+	ClusterAutoscalerMaxSizeAnnotation  = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
+const (
+	KarpenterNodePoolPath    = "/apis/karpenter.sh/v1/nodepools/%s"
+	KarpenterNodePoolLabel   = "karpenter.sh/nodepool"
+	KarpenterNodesLimit      = "nodes"
+	OriginalLimitsAnnotation = "kubeep.io/original-limits"
+)
@@ -0,0 +1,5 @@
+package errorConstant
+
+const (
+	ServicePrincipalInvalid = "service principal invalid"
+)
@@ -0,0 +1,7 @@
+package errorConstant
+
+const (
+	CapacityBackendUnknown         = "capacity backend %s unknown"
+	MachineDeploymentNotFound      = "machine deployment %s not found"
+	NodePoolSizeAnnotationsMissing = "node pool %s has no autoscaler size annotations"
+)
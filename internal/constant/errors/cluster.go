@@ -9,5 +9,9 @@ const (
 	HPAVersionUnknown     = "hpa version unknown"
 	TargetRefResolveError = "target ref resolve error"
 	DeploymentNotFound    = "deployment not found"
+	PodTemplateNotFound   = "pod template of %s %s not found"
+	WorkloadKindInvalid   = "workload kind %s is not supported"
 	NoExistingNode        = "no existing node found"
+	KubeconfigNotFound    = "kubeconfig not found"
+	KubeconfigInvalid     = "kubeconfig invalid"
 )
@@ -1,6 +1,12 @@
 package errorConstant
 
 const (
-	EventExist    = "event already exist"
-	EventNotExist = "event not exist"
+	EventExist              = "event already exist"
+	EventNotExist           = "event not exist"
+	EventStatusInvalid      = "event with status %s can't be %s"
+	EventAlreadyEnded       = "event already ended"
+	EventRestorePending     = "event is waiting to be restored"
+	EventLeaseLost          = "event lease is held by another worker"
+	PrimaryNodePoolRequired = "primary_node_pool is required for the PRIMARY_POOL node pool distribution"
+	EventConflict           = "event overlaps with other events on the same HPA or node pool, set merge_conflicts to take the max replicas of the shared HPAs"
 )
@@ -0,0 +1,7 @@
+package errorConstant
+
+const (
+	EventTemplateNotExist            = "event template not exist"
+	EventTemplateNameExist           = "event template %s already exists in the cluster"
+	EventTemplateRampStepUnsupported = "event templates do not support ramp steps"
+)
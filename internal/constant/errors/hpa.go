@@ -0,0 +1,10 @@
+package errorConstant
+
+const (
+	HPABehaviorUnsupported          = "hpa %s with api version %s does not support behavior"
+	HPAMetricNotFound               = "hpa %s has no %s resource metric"
+	ScaledObjectOverrideUnsupported = "scaled object %s does not support behavior and metric target overrides"
+	HPARampUpStepInvalid            = "ramp up steps of %s must run between execute config time and start time"
+	HPARampDownStepInvalid          = "ramp down steps of %s must run after end time"
+	HPARampStepReplicasInvalid      = "ramp steps of %s must have min replicas below max replicas and max replicas up to %d"
+)
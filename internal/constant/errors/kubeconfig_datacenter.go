@@ -0,0 +1,5 @@
+package errorConstant
+
+const (
+	KubeconfigCredentialsInvalid = "kubeconfig credentials invalid"
+)
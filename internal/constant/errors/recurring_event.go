@@ -0,0 +1,12 @@
+package errorConstant
+
+const (
+	CronExpressionInvalid                = "cron expression %s is invalid"
+	TimezoneInvalid                      = "timezone %s is invalid"
+	RecurringEventNotExist               = "recurring event not exist"
+	RecurringEventOffsetInvalid          = "watching offset must not be larger than execute config offset"
+	RecurringEventRampStepUnsupported    = "recurring events do not support ramp steps"
+	RecurringEventOccurrenceInvalid      = "%s is not an occurrence of the recurring event"
+	RecurringEventOccurrenceMaterialized = "occurrence at %s is already created as event %s"
+	RecurringEventOccurrenceConflict     = "occurrence overlaps with events %s, set merge_conflicts to take the max replicas of the shared HPAs"
+)
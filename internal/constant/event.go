@@ -0,0 +1,8 @@
+package constant
+
+import "time"
+
+const (
+	EventLeaseDuration      = 2 * time.Minute
+	EventLeaseRenewInterval = 30 * time.Second
+)
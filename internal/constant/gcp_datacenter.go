@@ -0,0 +1,9 @@
+package constant
+
+import "time"
+
+const (
+	GCPOperationTimeout         = 30 * time.Minute
+	GCPOperationPollInterval    = time.Second
+	GCPOperationMaxPollInterval = 30 * time.Second
+)
@@ -4,12 +4,40 @@ const K8sHPAUpdateFieldManager = "kubeEP"
 
 const AppsV1 = "apps/v1"
 
-const Deployment = "Deployment"
+const (
+	Deployment  = "Deployment"
+	StatefulSet = "StatefulSet"
+	ReplicaSet  = "ReplicaSet"
+)
 
 const NameAndNamespaceKeyFormat = "%s|%s"
 
 const (
-	GCPNodePoolLabel = "cloud.google.com/gke-nodepool"
+	GCPNodePoolLabel  = "cloud.google.com/gke-nodepool"
+	EKSNodeGroupLabel = "eks.amazonaws.com/nodegroup"
+	AKSAgentPoolLabel = "agentpool"
+)
+
+const (
+	GCPMachineFamilyLabel    = "cloud.google.com/machine-family"
+	GCPPreemptibleLabel      = "cloud.google.com/gke-preemptible"
+	GKEDefaultMaxPodsPerNode = 110
+)
+
+const (
+	GKEGPUTaintKey   = "nvidia.com/gpu"
+	GKEGPUTaintValue = "present"
+)
+
+// Taints managed by the node controllers and cluster autoscaler, not part of the node pool spec
+var (
+	NodeControllerTaintPrefixes = []string{"node.kubernetes.io/", "node.cloudprovider.kubernetes.io/"}
+	ClusterAutoscalerTaintKeys  = []string{"ToBeDeletedByClusterAutoscaler", "DeletionCandidateOfClusterAutoscaler"}
+)
+
+const (
+	KubeconfigDefaultNodePoolLabel = "node.kubernetes.io/instance-type"
+	KubeconfigDefaultNodePool      = "default"
 )
 
 var (
@@ -8,3 +8,5 @@ const (
 	AutoscalingV2Beta1 HPAVersion = "autoscaling/v2beta1"
 	AutoscalingV2      HPAVersion = "autoscaling/v2"
 )
+
+const HorizontalPodAutoscaler = "HorizontalPodAutoscaler"
@@ -0,0 +1,14 @@
+package constant
+
+const (
+	KedaV1Alpha1 = "keda.sh/v1alpha1"
+	ScaledObject = "ScaledObject"
+)
+
+// KedaScaledObjectLabel is set by KEDA on every HPA it manages for a ScaledObject
+const KedaScaledObjectLabel = "scaledobject.keda.sh/name"
+
+const (
+	KedaHPANameFormat          = "keda-hpa-%s"
+	KedaDefaultMaxReplicaCount = int32(100)
+)
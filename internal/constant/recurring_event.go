@@ -0,0 +1,12 @@
+package constant
+
+import "time"
+
+// RecurringEventMaterializeAhead is how far before their execute config time occurrences become events
+const RecurringEventMaterializeAhead = 24 * time.Hour
+
+const (
+	RecurringEventDefaultOccurrenceCount = 10
+	RecurringEventMaxOccurrenceCount     = 100
+	RecurringEventNameTimeFormat         = "2006-01-02 15:04"
+)
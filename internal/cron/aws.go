@@ -0,0 +1,577 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+	"sync"
+	"time"
+)
+
+func (c *cron) getAllAWSClient(
+	ctx context.Context,
+	clusterData *UCEntity.ClusterData,
+) (kubernetes.Interface, *AWSClients, error) {
+	datacenter := clusterData.Datacenter.Datacenter
+	if datacenter != model.AWS {
+		return nil, nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+	datacenterName := clusterData.Datacenter.Name
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: clusterData.Datacenter.Credentials,
+		Name:        datacenterName,
+	}
+	awsConfig, err := c.awsDatacenterUC.GetAWSConfig(ctx, datacenterData)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.awsClusterUC.RegisterAWSCredentials(datacenterName, awsConfig)
+	kubernetesClient, err := c.awsClusterUC.GetKubernetesClusterClient(
+		datacenterName,
+		clusterData,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kubernetesClient, &AWSClients{
+		clusterClient: c.awsClusterUC.GetEKSClusterClient(awsConfig),
+	}, nil
+}
+
+func (c *cron) waitAWSNodeGroupUpdate(
+	ctx context.Context,
+	clusterClient repository.EKSClient,
+	clusterName, nodeGroupName string,
+	update *types.Update,
+) error {
+	for {
+		updateData, err := c.awsClusterUC.GetNodeGroupUpdate(
+			ctx,
+			clusterClient,
+			clusterName,
+			nodeGroupName,
+			aws.ToString(update.Id),
+		)
+		if err != nil {
+			return err
+		}
+		update = updateData.UpdateData
+		switch update.Status {
+		case types.UpdateStatusSuccessful:
+			return nil
+		case types.UpdateStatusFailed, types.UpdateStatusCancelled:
+			var errMessages []string
+			for _, updateError := range update.Errors {
+				errMessages = append(errMessages, aws.ToString(updateError.ErrorMessage))
+			}
+			return fmt.Errorf(
+				"node group %s update %s : %s",
+				nodeGroupName,
+				update.Status,
+				strings.Join(errMessages, ", "),
+			)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func (c *cron) execAWSEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
+	log.Infof("[EventCronJob] Executing event %s", e.Name)
+	db, ctx, releaseLease := c.holdEventLease(db, e, ctx)
+	defer releaseLease()
+
+	if !e.CalculateNodePool {
+		log.Infof("[EventCronJob] Event %s, skipping node pool calculation", e.Name)
+	}
+
+	clusterID := e.Cluster.ID
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Get Clients
+	kubernetesClient, awsClients, err := c.getAllAWSClient(ctx, clusterData)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	err = c.clusterUC.RefreshLatestHPAAPIVersion(db, kubernetesClient, clusterData)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	eksClient := awsClients.clusterClient
+
+	// Calculate Event Plan
+	log.Infof("[EventCronJob] Event : %s, Calculating event plan", e.Name)
+	plan, err := c.awsEventUC.CalculateAWSEventPlan(
+		ctx,
+		db,
+		kubernetesClient,
+		eksClient,
+		clusterData,
+		e,
+	)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	//Give error message to missing hpa
+	for _, modifiedHPA := range plan.MissingHPAs {
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigStatusMessage(
+			db,
+			modifiedHPA.ID,
+			model.HPAUpdateFailed,
+			"hpa not found",
+		)
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Event : %s, Error Update HPA %s Namespace %s : %s",
+				e.Name,
+				modifiedHPA.Name,
+				modifiedHPA.Namespace,
+				err.Error(),
+			)
+		}
+	}
+
+	if len(plan.SelectedHPAs) == 0 && len(plan.SelectedWorkloads) == 0 {
+		c.handleExecEventError(db, e, "no hpa or workload exist")
+		return
+	}
+
+	var existingModifiedHPAs []*UCEntity.EventModifiedHPAConfigData
+	for _, hpaPlan := range plan.SelectedHPAs {
+		modifiedHPA := hpaPlan.ModifiedHPAConfig
+		if modifiedHPA.OriginalMaxReplicas == nil {
+			currentMaxReplicas := hpaPlan.CurrentMaxReplicas
+			modifiedHPA.OriginalMinReplicas = hpaPlan.CurrentMinReplicas
+			modifiedHPA.OriginalMaxReplicas = &currentMaxReplicas
+			modifiedHPA.OriginalBehavior = hpaPlan.CurrentBehavior
+			modifiedHPA.OriginalMetrics = hpaPlan.CurrentMetrics
+		}
+		existingModifiedHPAs = append(existingModifiedHPAs, modifiedHPA)
+	}
+
+	// Reuse node groups registered by a previous failed attempt
+	existingUpdatedNodePools, err := c.updatedNodePoolUC.GetAllUpdatedNodePoolByEvent(db, e.ID)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+	existingUpdatedNodePoolMap := map[string]*UCEntity.UpdatedNodePoolData{}
+	for _, existingUpdatedNodePool := range existingUpdatedNodePools {
+		existingUpdatedNodePoolMap[existingUpdatedNodePool.NodePoolName] = existingUpdatedNodePool
+	}
+
+	var newUpdatedNodePools []*model.UpdatedNodePool
+	for _, nodePoolPlan := range plan.NodePools {
+		nodeGroup := nodePoolPlan.NodeGroupObject
+		if _, ok := existingUpdatedNodePoolMap[nodePoolPlan.NodePoolName]; ok {
+			continue
+		}
+		updatedNodePool := &model.UpdatedNodePool{
+			NodePoolName:         nodePoolPlan.NodePoolName,
+			DistributionStrategy: e.NodePoolDistribution,
+		}
+		if nodeGroup.ScalingConfig != nil {
+			updatedNodePool.MaxNode = aws.ToInt32(nodeGroup.ScalingConfig.MaxSize)
+			updatedNodePool.OriginalMinNode = aws.ToInt32(nodeGroup.ScalingConfig.MinSize)
+			updatedNodePool.OriginalMaxNode = aws.ToInt32(nodeGroup.ScalingConfig.MaxSize)
+			updatedNodePool.OriginalAutoscalingEnabled = true
+		}
+		updatedNodePool.EventID.SetUUID(e.ID)
+
+		newUpdatedNodePools = append(newUpdatedNodePools, updatedNodePool)
+	}
+
+	if len(newUpdatedNodePools) != 0 {
+		if err := db.Create(&newUpdatedNodePools).Error; err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+	}
+
+	for _, newUpdatedNodePool := range newUpdatedNodePools {
+		existingUpdatedNodePoolMap[newUpdatedNodePool.NodePoolName] = &UCEntity.UpdatedNodePoolData{
+			ID:           newUpdatedNodePool.ID.GetUUID(),
+			NodePoolName: newUpdatedNodePool.NodePoolName,
+			MaxNode:      newUpdatedNodePool.MaxNode,
+		}
+	}
+
+	if e.CalculateNodePool {
+		// Update the Node Groups
+		log.Infof("[EventCronJob] Event : %s, Updating node groups based on event plan", e.Name)
+		errGroup, ctxEg := errgroup.WithContext(ctx)
+		var updateNodePoolLock sync.Mutex
+		for _, nodePoolPlan := range plan.NodePools {
+			updatedNodePool := existingUpdatedNodePoolMap[nodePoolPlan.NodePoolName]
+			newMaxNode := nodePoolPlan.NewMaxNode
+			if updatedNodePool.AutoscalingModified {
+				// Keep the max node size decided by the previous attempt
+				newMaxNode = updatedNodePool.MaxNode
+				if nodePoolPlan.CurrentMaxNode == newMaxNode {
+					log.Infof(
+						"[EventCronJob] Event : %s, EKS node group %s already updated with max node size %d, skipping",
+						e.Name,
+						nodePoolPlan.NodePoolName,
+						newMaxNode,
+					)
+					continue
+				}
+			}
+			errGroup.Go(
+				func(
+					nodePoolPlan *UCEntity.AWSNodePoolPlan,
+					updatedNodePoolID uuid.UUID,
+					newMaxNode int32,
+				) func() error {
+					return func() error {
+						updateNodePoolLock.Lock()
+						defer updateNodePoolLock.Unlock()
+						err := c.updatedNodePoolUC.UpdateUpdatedNodePoolMaxNode(
+							db,
+							updatedNodePoolID,
+							newMaxNode,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							return err
+						}
+
+						log.Infof(
+							"[EventCronJob] Event : %s, Updating EKS node group %s with new max node size %d (before : %d)",
+							e.Name,
+							nodePoolPlan.NodePoolName,
+							newMaxNode,
+							nodePoolPlan.CurrentMaxNode,
+						)
+
+						updateData, err := c.awsClusterUC.UpdateNodeGroupScalingConfig(
+							ctx,
+							eksClient,
+							plan.ClusterName,
+							nodePoolPlan.NodePoolName,
+							&types.NodegroupScalingConfig{MaxSize: aws.Int32(newMaxNode)},
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							return err
+						}
+						return c.waitAWSNodeGroupUpdate(
+							ctx,
+							eksClient,
+							plan.ClusterName,
+							nodePoolPlan.NodePoolName,
+							updateData.UpdateData,
+						)
+					}
+				}(nodePoolPlan, updatedNodePool.ID, newMaxNode),
+			)
+		}
+
+		if err := errGroup.Wait(); err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+	}
+
+	// Snapshot Original HPA Spec
+	log.Infof("[EventCronJob] Event : %s, Saving original HPA configuration", e.Name)
+	for _, existingModifiedHPA := range existingModifiedHPAs {
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigOriginalSpec(
+			db,
+			existingModifiedHPA.ID,
+			existingModifiedHPA.OriginalMinReplicas,
+			*existingModifiedHPA.OriginalMaxReplicas,
+			existingModifiedHPA.OriginalBehavior,
+			existingModifiedHPA.OriginalMetrics,
+		)
+		if err != nil {
+			c.handleExecEventError(
+				db, e, fmt.Sprintf(
+					"Error Update HPA %s Namespace %s : %s", existingModifiedHPA.Name,
+					existingModifiedHPA.Namespace,
+					err.Error(),
+				),
+			)
+			return
+		}
+	}
+
+	// Update K8s HPA
+	log.Infof("[EventCronJob] Event : %s, Updating K8s HPA with new configuration", e.Name)
+	conflicts, err := c.clusterUC.UpdateHPAK8sObjectBatch(
+		ctx,
+		kubernetesClient,
+		clusterID,
+		plan.SelectedHPAs,
+		e.ForceHPAApply,
+	)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	for _, existingModifiedHPA := range existingModifiedHPAs {
+		// HPAs owned by another field manager are left untouched and skipped on restore
+		status := model.HPAUpdateSuccess
+		message := ""
+		if conflictErr, ok := conflicts[fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			util.GetHPAObjectName(existingModifiedHPA.Kind, existingModifiedHPA.Name),
+			existingModifiedHPA.Namespace,
+		)]; ok {
+			status = model.HPAUpdateFailed
+			message = conflictErr.Error()
+		}
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigStatusMessage(
+			db,
+			existingModifiedHPA.ID,
+			status,
+			message,
+		)
+		if err != nil {
+			c.handleExecEventError(
+				db, e, fmt.Sprintf(
+					"Error Update HPA %s Namespace %s : %s", existingModifiedHPA.Name,
+					existingModifiedHPA.Namespace,
+					err.Error(),
+				),
+			)
+			return
+		}
+	}
+
+	// Scale Workloads
+	log.Infof("[EventCronJob] Event : %s, Scaling workloads with new replicas", e.Name)
+	err = c.scaleWorkloads(kubernetesClient, db, e, &plan.EventPlan, ctx)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	e.Status = model.EventPrescaled
+
+	err = c.eventUC.UpdateLeasedEventStatus(db, e, c.owner)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+	}
+
+	log.Infof("[EventCronJob] Event : %s, Done executing update and calculation", e.Name)
+}
+
+func (c *cron) restoreAWSEvent(
+	e *UCEntity.Event,
+	db *gorm.DB,
+	ctx context.Context,
+	restoredStatus model.EventStatus,
+) {
+	log.Infof("[EventCronJob] Restoring event %s", e.Name)
+	db, ctx, releaseLease := c.holdEventLease(db, e, ctx)
+	defer releaseLease()
+
+	clusterID := e.Cluster.ID
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Get Clients
+	kubernetesClient, awsClients, err := c.getAllAWSClient(ctx, clusterData)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	err = c.clusterUC.RefreshLatestHPAAPIVersion(db, kubernetesClient, clusterData)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Restore K8s HPA
+	log.Infof("[EventCronJob] Event : %s, Restoring K8s HPA original configuration", e.Name)
+	failedHPAs, err := c.restoreHPA(kubernetesClient, db, e, clusterData, ctx)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Restore Workloads
+	log.Infof("[EventCronJob] Event : %s, Restoring workload original replicas", e.Name)
+	failedWorkloads, err := c.restoreWorkloads(kubernetesClient, db, e, ctx)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Restore EKS Node Groups
+	log.Infof("[EventCronJob] Event : %s, Restoring EKS node group original scaling config", e.Name)
+	failedNodePools, err := c.restoreAWSNodeGroup(
+		awsClients.clusterClient,
+		db,
+		e,
+		clusterData,
+		ctx,
+	)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	var errMessages []string
+	if len(failedHPAs) != 0 {
+		errMessages = append(
+			errMessages,
+			fmt.Sprintf("failed to restore hpa : %s", strings.Join(failedHPAs, ", ")),
+		)
+	}
+	if len(failedWorkloads) != 0 {
+		errMessages = append(
+			errMessages,
+			fmt.Sprintf("failed to restore workload : %s", strings.Join(failedWorkloads, ", ")),
+		)
+	}
+	if len(failedNodePools) != 0 {
+		errMessages = append(
+			errMessages,
+			fmt.Sprintf("failed to restore node pool : %s", strings.Join(failedNodePools, ", ")),
+		)
+	}
+	if len(errMessages) != 0 {
+		c.handleRestoreEventError(db, e, strings.Join(errMessages, "\n"))
+		return
+	}
+
+	e.Status = restoredStatus
+
+	err = c.eventUC.UpdateLeasedEventStatus(db, e, c.owner)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+	}
+
+	log.Infof("[EventCronJob] Event : %s, Done restoring original configuration", e.Name)
+}
+
+func (c *cron) restoreAWSNodeGroup(
+	clusterClient repository.EKSClient,
+	db *gorm.DB,
+	event *UCEntity.Event,
+	clusterData *UCEntity.ClusterData,
+	ctx context.Context,
+) ([]string, error) {
+	updatedNodePools, err := c.updatedNodePoolUC.GetAllUpdatedNodePoolByEvent(db, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse AWS Cluster Name
+	clusterMetadata := strings.SplitN(clusterData.Name, "_", 3)
+	name := clusterMetadata[2]
+
+	nodeGroups, err := c.awsClusterUC.GetAllNodeGroupsInEKSCluster(ctx, clusterClient, name)
+	if err != nil {
+		return nil, err
+	}
+	nodeGroupMap := map[string]*types.Nodegroup{}
+	for _, nodeGroup := range nodeGroups {
+		nodeGroupMap[aws.ToString(nodeGroup.NodeGroupObject.NodegroupName)] = nodeGroup.NodeGroupObject
+	}
+
+	var failedNodePools []string
+	for _, updatedNodePool := range updatedNodePools {
+		if updatedNodePool.RestoreStatus == model.NodePoolUpdateSuccess {
+			continue
+		}
+
+		restoreStatus := model.NodePoolUpdateSuccess
+		restoreMessage := ""
+		if updatedNodePool.AutoscalingModified {
+			log.Infof(
+				"[EventCronJob] Restoring event : %s, Updating EKS node group %s with original max node size %d (before : %d)",
+				event.Name,
+				updatedNodePool.NodePoolName,
+				updatedNodePool.OriginalMaxNode,
+				updatedNodePool.MaxNode,
+			)
+			scalingConfig := &types.NodegroupScalingConfig{
+				MinSize: aws.Int32(updatedNodePool.OriginalMinNode),
+				MaxSize: aws.Int32(updatedNodePool.OriginalMaxNode),
+			}
+			// EKS rejects a max size below the desired size, shrink the desired size along with it
+			nodeGroup, ok := nodeGroupMap[updatedNodePool.NodePoolName]
+			if ok && nodeGroup.ScalingConfig != nil &&
+				aws.ToInt32(nodeGroup.ScalingConfig.DesiredSize) > updatedNodePool.OriginalMaxNode {
+				scalingConfig.DesiredSize = aws.Int32(updatedNodePool.OriginalMaxNode)
+			}
+			updateData, err := c.awsClusterUC.UpdateNodeGroupScalingConfig(
+				ctx,
+				clusterClient,
+				name,
+				updatedNodePool.NodePoolName,
+				scalingConfig,
+			)
+			if err == nil {
+				err = c.waitAWSNodeGroupUpdate(
+					ctx,
+					clusterClient,
+					name,
+					updatedNodePool.NodePoolName,
+					updateData.UpdateData,
+				)
+			}
+			if err != nil {
+				restoreStatus = model.NodePoolUpdateFailed
+				restoreMessage = err.Error()
+				failedNodePools = append(failedNodePools, updatedNodePool.NodePoolName)
+				log.Errorf(
+					"[EventCronJob] Restoring event : %s, Node group %s, Error : %s",
+					event.Name,
+					updatedNodePool.NodePoolName,
+					restoreMessage,
+				)
+			}
+		} else {
+			restoreStatus = model.NodePoolUpdateSkipped
+			restoreMessage = "node pool was not modified"
+		}
+
+		err := c.updatedNodePoolUC.UpdateUpdatedNodePoolRestoreStatusMessage(
+			db,
+			updatedNodePool.ID,
+			restoreStatus,
+			restoreMessage,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return failedNodePools, nil
+}
@@ -0,0 +1,652 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEKSClient serves node groups from memory and records the scaling config updates
+type fakeEKSClient struct {
+	lock                  sync.Mutex
+	nodeGroups            map[string]*types.Nodegroup
+	updateErr             error
+	updateStatus          types.UpdateStatus
+	updateNodegroupConfig []*eks.UpdateNodegroupConfigInput
+}
+
+func (f *fakeEKSClient) ListClusters(
+	context.Context,
+	*eks.ListClustersInput,
+	...func(*eks.Options),
+) (*eks.ListClustersOutput, error) {
+	return &eks.ListClustersOutput{}, nil
+}
+
+func (f *fakeEKSClient) DescribeCluster(
+	context.Context,
+	*eks.DescribeClusterInput,
+	...func(*eks.Options),
+) (*eks.DescribeClusterOutput, error) {
+	return &eks.DescribeClusterOutput{}, nil
+}
+
+func (f *fakeEKSClient) ListNodegroups(
+	context.Context,
+	*eks.ListNodegroupsInput,
+	...func(*eks.Options),
+) (*eks.ListNodegroupsOutput, error) {
+	output := &eks.ListNodegroupsOutput{}
+	for name := range f.nodeGroups {
+		output.Nodegroups = append(output.Nodegroups, name)
+	}
+	return output, nil
+}
+
+func (f *fakeEKSClient) DescribeNodegroup(
+	_ context.Context,
+	params *eks.DescribeNodegroupInput,
+	_ ...func(*eks.Options),
+) (*eks.DescribeNodegroupOutput, error) {
+	nodeGroup, ok := f.nodeGroups[aws.ToString(params.NodegroupName)]
+	if !ok {
+		return nil, fmt.Errorf("node group %s not found", aws.ToString(params.NodegroupName))
+	}
+	return &eks.DescribeNodegroupOutput{Nodegroup: nodeGroup}, nil
+}
+
+func (f *fakeEKSClient) UpdateNodegroupConfig(
+	_ context.Context,
+	params *eks.UpdateNodegroupConfigInput,
+	_ ...func(*eks.Options),
+) (*eks.UpdateNodegroupConfigOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.updateNodegroupConfig = append(f.updateNodegroupConfig, params)
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	return &eks.UpdateNodegroupConfigOutput{
+		Update: &types.Update{
+			Id:     aws.String(fmt.Sprintf("update-%d", len(f.updateNodegroupConfig))),
+			Status: types.UpdateStatusInProgress,
+		},
+	}, nil
+}
+
+func (f *fakeEKSClient) DescribeUpdate(
+	_ context.Context,
+	params *eks.DescribeUpdateInput,
+	_ ...func(*eks.Options),
+) (*eks.DescribeUpdateOutput, error) {
+	update := &types.Update{Id: params.UpdateId, Status: types.UpdateStatusSuccessful}
+	if f.updateStatus != "" {
+		update.Status = f.updateStatus
+		update.Errors = []types.ErrorDetail{{ErrorMessage: aws.String("rejected by eks")}}
+	}
+	return &eks.DescribeUpdateOutput{Update: update}, nil
+}
+
+type fakeEventUC struct {
+	useCase.Event
+	lock     sync.Mutex
+	statuses []model.EventStatus
+	messages []string
+}
+
+func (f *fakeEventUC) RenewEventLease(*gorm.DB, uuid.UUID, string, time.Time) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeEventUC) ReleaseEventLease(*gorm.DB, uuid.UUID, string, time.Time) error {
+	return nil
+}
+
+func (f *fakeEventUC) UpdateLeasedEventStatus(_ *gorm.DB, eventData *UCEntity.Event, _ string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.statuses = append(f.statuses, eventData.Status)
+	f.messages = append(f.messages, eventData.Message)
+	return nil
+}
+
+type fakeClusterUC struct {
+	useCase.Cluster
+	clusterData *UCEntity.ClusterData
+	updatedHPAs []*UCEntity.HPAPlan
+}
+
+func (f *fakeClusterUC) GetClusterAndDatacenterDataByClusterID(
+	*gorm.DB,
+	uuid.UUID,
+) (*UCEntity.ClusterData, error) {
+	return f.clusterData, nil
+}
+
+func (f *fakeClusterUC) RefreshLatestHPAAPIVersion(
+	*gorm.DB,
+	kubernetes.Interface,
+	*UCEntity.ClusterData,
+) error {
+	return nil
+}
+
+func (f *fakeClusterUC) UpdateHPAK8sObjectBatch(
+	_ context.Context,
+	_ kubernetes.Interface,
+	_ uuid.UUID,
+	hpaPlans []*UCEntity.HPAPlan,
+	_ bool,
+) (map[string]error, error) {
+	f.updatedHPAs = append(f.updatedHPAs, hpaPlans...)
+	return nil, nil
+}
+
+func (f *fakeClusterUC) GetAllK8sHPAObjectInCluster(
+	context.Context,
+	kubernetes.Interface,
+	uuid.UUID,
+	constant.HPAVersion,
+) ([]UCEntity.K8sHPAObjectData, error) {
+	return nil, nil
+}
+
+type fakeAWSDatacenterUC struct {
+	useCase.AWSDatacenter
+}
+
+func (f *fakeAWSDatacenterUC) GetAWSConfig(context.Context, UCEntity.DatacenterData) (aws.Config, error) {
+	return aws.Config{}, nil
+}
+
+// fakeAWSClusterUC keeps the real node group calls and hands out the fake EKS client
+type fakeAWSClusterUC struct {
+	useCase.AWSCluster
+	eksClient repository.EKSClient
+}
+
+func (f *fakeAWSClusterUC) RegisterAWSCredentials(string, aws.Config) {}
+
+func (f *fakeAWSClusterUC) GetEKSClusterClient(aws.Config) repository.EKSClient {
+	return f.eksClient
+}
+
+func (f *fakeAWSClusterUC) GetKubernetesClusterClient(
+	string,
+	*UCEntity.ClusterData,
+) (*kubernetes.Clientset, error) {
+	return nil, nil
+}
+
+type fakeAWSEventUC struct {
+	useCase.AWSEvent
+	plan *UCEntity.AWSEventPlan
+	err  error
+}
+
+func (f *fakeAWSEventUC) CalculateAWSEventPlan(
+	context.Context,
+	*gorm.DB,
+	kubernetes.Interface,
+	repository.EKSClient,
+	*UCEntity.ClusterData,
+	*UCEntity.Event,
+) (*UCEntity.AWSEventPlan, error) {
+	return f.plan, f.err
+}
+
+type fakeScheduledHPAConfigUC struct {
+	useCase.ScheduledHPAConfig
+	lock     sync.Mutex
+	statuses map[uuid.UUID]model.HPAUpdateStatus
+}
+
+func (f *fakeScheduledHPAConfigUC) UpdateScheduledHPAConfigStatusMessage(
+	_ *gorm.DB,
+	id uuid.UUID,
+	status model.HPAUpdateStatus,
+	_ string,
+) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.statuses[id] = status
+	return nil
+}
+
+func (f *fakeScheduledHPAConfigUC) UpdateScheduledHPAConfigOriginalSpec(
+	*gorm.DB,
+	uuid.UUID,
+	*int32,
+	int32,
+	json.RawMessage,
+	json.RawMessage,
+) error {
+	return nil
+}
+
+func (f *fakeScheduledHPAConfigUC) SkipPendingScheduledHPASteps(*gorm.DB, uuid.UUID, string) error {
+	return nil
+}
+
+func (f *fakeScheduledHPAConfigUC) ListScheduledHPAConfigByEventID(
+	*gorm.DB,
+	uuid.UUID,
+) ([]*UCEntity.EventModifiedHPAConfigData, error) {
+	return nil, nil
+}
+
+type fakeScheduledWorkloadConfigUC struct {
+	useCase.ScheduledWorkloadConfig
+}
+
+func (f *fakeScheduledWorkloadConfigUC) ListScheduledWorkloadConfigByEventID(
+	*gorm.DB,
+	uuid.UUID,
+) ([]*UCEntity.EventModifiedWorkloadConfigData, error) {
+	return nil, nil
+}
+
+type fakeStatisticUC struct {
+	useCase.Statistic
+	lock             sync.Mutex
+	updatedNodePools []*UCEntity.UpdatedNodePoolData
+	maxNodes         map[uuid.UUID]int32
+	restoreStatuses  map[uuid.UUID]model.NodePoolUpdateStatus
+}
+
+func (f *fakeStatisticUC) GetAllUpdatedNodePoolByEvent(
+	*gorm.DB,
+	uuid.UUID,
+) ([]*UCEntity.UpdatedNodePoolData, error) {
+	return f.updatedNodePools, nil
+}
+
+func (f *fakeStatisticUC) UpdateUpdatedNodePoolMaxNode(_ *gorm.DB, id uuid.UUID, maxNode int32) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.maxNodes[id] = maxNode
+	return nil
+}
+
+func (f *fakeStatisticUC) UpdateUpdatedNodePoolRestoreStatusMessage(
+	_ *gorm.DB,
+	id uuid.UUID,
+	status model.NodePoolUpdateStatus,
+	_ string,
+) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.restoreStatuses[id] = status
+	return nil
+}
+
+type awsCronFixture struct {
+	cron        *cron
+	eks         *fakeEKSClient
+	event       *fakeEventUC
+	cluster     *fakeClusterUC
+	awsEvent    *fakeAWSEventUC
+	hpaConfig   *fakeScheduledHPAConfigUC
+	statistic   *fakeStatisticUC
+	eventData   *UCEntity.Event
+	clusterName string
+}
+
+func newAWSCronFixture(t *testing.T) *awsCronFixture {
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true},
+	)
+	if err != nil {
+		t.Fatalf("open dry run db : %s", err.Error())
+	}
+	resources := &config.KubeEPResources{ValidatorInst: validator.New()}
+	useCases := useCase.BuildUseCases(resources, repository.BuildRepositories(resources))
+
+	clusterData := &UCEntity.ClusterData{
+		ID:   uuid.New(),
+		Name: "eks_ap-southeast-1_production",
+		Datacenter: UCEntity.DatacenterDetailedData{
+			Datacenter: model.AWS,
+			Name:       "aws",
+		},
+	}
+	f := &awsCronFixture{
+		eks:       &fakeEKSClient{nodeGroups: map[string]*types.Nodegroup{}},
+		event:     &fakeEventUC{},
+		cluster:   &fakeClusterUC{clusterData: clusterData},
+		awsEvent:  &fakeAWSEventUC{},
+		hpaConfig: &fakeScheduledHPAConfigUC{statuses: map[uuid.UUID]model.HPAUpdateStatus{}},
+		statistic: &fakeStatisticUC{
+			maxNodes:        map[uuid.UUID]int32{},
+			restoreStatuses: map[uuid.UUID]model.NodePoolUpdateStatus{},
+		},
+		eventData: &UCEntity.Event{
+			ID:                uuid.New(),
+			Name:              "sale",
+			CalculateNodePool: true,
+			Cluster:           *clusterData,
+		},
+		clusterName: "production",
+	}
+	f.cron = &cron{
+		eventUC:                   f.event,
+		clusterUC:                 f.cluster,
+		scheduledHPAConfigUC:      f.hpaConfig,
+		scheduledWorkloadConfigUC: &fakeScheduledWorkloadConfigUC{},
+		updatedNodePoolUC:         f.statistic,
+		awsClusterUC:              &fakeAWSClusterUC{AWSCluster: useCases.AwsCluster, eksClient: f.eks},
+		awsDatacenterUC:           &fakeAWSDatacenterUC{},
+		awsEventUC:                f.awsEvent,
+		owner:                     "test",
+		tx:                        db,
+	}
+	return f
+}
+
+func (f *awsCronFixture) lastStatus(t *testing.T) (model.EventStatus, string) {
+	if len(f.event.statuses) == 0 {
+		t.Fatalf("event status was never updated")
+	}
+	last := len(f.event.statuses) - 1
+	return f.event.statuses[last], f.event.messages[last]
+}
+
+func newAWSEventPlan(clusterName string, nodePools ...*UCEntity.AWSNodePoolPlan) *UCEntity.AWSEventPlan {
+	maxReplicas := int32(20)
+	return &UCEntity.AWSEventPlan{
+		EventPlan: UCEntity.EventPlan{
+			SelectedHPAs: []*UCEntity.HPAPlan{
+				{
+					ModifiedHPAConfig: &UCEntity.EventModifiedHPAConfigData{
+						ID:          uuid.New(),
+						Name:        "web",
+						Namespace:   "default",
+						MaxReplicas: maxReplicas,
+					},
+					CurrentMaxReplicas: 5,
+				},
+			},
+		},
+		ClusterName: clusterName,
+		NodePools:   nodePools,
+	}
+}
+
+func newAWSNodePoolPlan(name string, currentMaxNode, newMaxNode int32) *UCEntity.AWSNodePoolPlan {
+	return &UCEntity.AWSNodePoolPlan{
+		NodePoolPlan: UCEntity.NodePoolPlan{
+			NodePoolName:   name,
+			CurrentMaxNode: currentMaxNode,
+			NewMaxNode:     newMaxNode,
+		},
+		NodeGroupObject: &types.Nodegroup{
+			NodegroupName: aws.String(name),
+			ScalingConfig: &types.NodegroupScalingConfig{
+				MinSize:     aws.Int32(1),
+				MaxSize:     aws.Int32(currentMaxNode),
+				DesiredSize: aws.Int32(currentMaxNode),
+			},
+		},
+	}
+}
+
+func TestExecAWSEvent(t *testing.T) {
+	f := newAWSCronFixture(t)
+	f.awsEvent.plan = newAWSEventPlan(f.clusterName, newAWSNodePoolPlan("workers", 3, 10))
+
+	f.cron.execAWSEvent(f.eventData, f.cron.tx, context.Background())
+
+	status, message := f.lastStatus(t)
+	if status != model.EventPrescaled {
+		t.Fatalf("expected status %s, got %s (%s)", model.EventPrescaled, status, message)
+	}
+	if len(f.eks.updateNodegroupConfig) != 1 {
+		t.Fatalf("expected 1 node group update, got %d", len(f.eks.updateNodegroupConfig))
+	}
+	update := f.eks.updateNodegroupConfig[0]
+	if aws.ToString(update.ClusterName) != f.clusterName ||
+		aws.ToString(update.NodegroupName) != "workers" {
+		t.Fatalf(
+			"unexpected node group update %s/%s",
+			aws.ToString(update.ClusterName),
+			aws.ToString(update.NodegroupName),
+		)
+	}
+	if maxSize := aws.ToInt32(update.ScalingConfig.MaxSize); maxSize != 10 {
+		t.Fatalf("expected max size 10, got %d", maxSize)
+	}
+	if len(f.cluster.updatedHPAs) != 1 {
+		t.Fatalf("expected 1 updated hpa, got %d", len(f.cluster.updatedHPAs))
+	}
+	hpaID := f.awsEvent.plan.SelectedHPAs[0].ModifiedHPAConfig.ID
+	if f.hpaConfig.statuses[hpaID] != model.HPAUpdateSuccess {
+		t.Fatalf("expected hpa status %s, got %s", model.HPAUpdateSuccess, f.hpaConfig.statuses[hpaID])
+	}
+}
+
+func TestExecAWSEventKeepsPreviousAttempt(t *testing.T) {
+	f := newAWSCronFixture(t)
+	f.awsEvent.plan = newAWSEventPlan(f.clusterName, newAWSNodePoolPlan("workers", 10, 12))
+	f.statistic.updatedNodePools = []*UCEntity.UpdatedNodePoolData{
+		{
+			ID:                  uuid.New(),
+			NodePoolName:        "workers",
+			MaxNode:             10,
+			OriginalMinNode:     1,
+			OriginalMaxNode:     3,
+			AutoscalingModified: true,
+		},
+	}
+	f.awsEvent.plan.SelectedHPAs[0].ModifiedHPAConfig.Status = model.HPAUpdateSuccess
+
+	f.cron.execAWSEvent(f.eventData, f.cron.tx, context.Background())
+
+	status, message := f.lastStatus(t)
+	if status != model.EventPrescaled {
+		t.Fatalf("expected status %s, got %s (%s)", model.EventPrescaled, status, message)
+	}
+	if len(f.eks.updateNodegroupConfig) != 0 {
+		t.Fatalf("expected no node group update, got %d", len(f.eks.updateNodegroupConfig))
+	}
+}
+
+func TestExecAWSEventError(t *testing.T) {
+	testCases := []struct {
+		name            string
+		planErr         error
+		emptyPlan       bool
+		updateErr       error
+		updateStatus    types.UpdateStatus
+		expectedMessage string
+		expectedUpdates int
+	}{
+		{
+			name:            "plan calculation fails",
+			planErr:         errors.New("cluster unreachable"),
+			expectedMessage: "cluster unreachable",
+		},
+		{
+			name:            "nothing to modify",
+			emptyPlan:       true,
+			expectedMessage: "no hpa or workload exist",
+		},
+		{
+			name:            "node group update rejected",
+			updateErr:       errors.New("throttled"),
+			expectedMessage: "throttled",
+			expectedUpdates: 1,
+		},
+		{
+			name:            "node group update failed",
+			updateStatus:    types.UpdateStatusFailed,
+			expectedMessage: "node group workers update Failed : rejected by eks",
+			expectedUpdates: 1,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				f := newAWSCronFixture(t)
+				f.awsEvent.plan = newAWSEventPlan(f.clusterName, newAWSNodePoolPlan("workers", 3, 10))
+				if testCase.emptyPlan {
+					f.awsEvent.plan.SelectedHPAs = nil
+				}
+				f.awsEvent.err = testCase.planErr
+				f.eks.updateErr = testCase.updateErr
+				f.eks.updateStatus = testCase.updateStatus
+
+				f.cron.execAWSEvent(f.eventData, f.cron.tx, context.Background())
+
+				status, message := f.lastStatus(t)
+				if status != model.EventFailed {
+					t.Fatalf("expected status %s, got %s", model.EventFailed, status)
+				}
+				if message != testCase.expectedMessage {
+					t.Fatalf("expected message %q, got %q", testCase.expectedMessage, message)
+				}
+				if len(f.eks.updateNodegroupConfig) != testCase.expectedUpdates {
+					t.Fatalf(
+						"expected %d node group updates, got %d",
+						testCase.expectedUpdates,
+						len(f.eks.updateNodegroupConfig),
+					)
+				}
+				if len(f.cluster.updatedHPAs) != 0 {
+					t.Fatalf("expected no hpa update, got %d", len(f.cluster.updatedHPAs))
+				}
+			},
+		)
+	}
+}
+
+func TestRestoreAWSEvent(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		desiredSize           int32
+		updateErr             error
+		restoredStatus        model.EventStatus
+		expectedStatus        model.EventStatus
+		expectedDesiredSize   *int32
+		expectedRestoreStatus model.NodePoolUpdateStatus
+	}{
+		{
+			name:                  "restore after the event",
+			desiredSize:           2,
+			restoredStatus:        model.EventSuccess,
+			expectedStatus:        model.EventSuccess,
+			expectedRestoreStatus: model.NodePoolUpdateSuccess,
+		},
+		{
+			name:                  "shrink desired size above the original max size",
+			desiredSize:           8,
+			restoredStatus:        model.EventCancelled,
+			expectedStatus:        model.EventCancelled,
+			expectedDesiredSize:   aws.Int32(3),
+			expectedRestoreStatus: model.NodePoolUpdateSuccess,
+		},
+		{
+			name:                  "node group update rejected",
+			desiredSize:           2,
+			updateErr:             errors.New("throttled"),
+			restoredStatus:        model.EventSuccess,
+			expectedStatus:        model.EventRestoreFailed,
+			expectedRestoreStatus: model.NodePoolUpdateFailed,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				f := newAWSCronFixture(t)
+				f.eks.updateErr = testCase.updateErr
+				f.eks.nodeGroups["workers"] = &types.Nodegroup{
+					NodegroupName: aws.String("workers"),
+					ScalingConfig: &types.NodegroupScalingConfig{
+						MinSize:     aws.Int32(1),
+						MaxSize:     aws.Int32(10),
+						DesiredSize: aws.Int32(testCase.desiredSize),
+					},
+				}
+				modifiedNodePoolID := uuid.New()
+				untouchedNodePoolID := uuid.New()
+				f.statistic.updatedNodePools = []*UCEntity.UpdatedNodePoolData{
+					{
+						ID:                  modifiedNodePoolID,
+						NodePoolName:        "workers",
+						MaxNode:             10,
+						OriginalMinNode:     1,
+						OriginalMaxNode:     3,
+						AutoscalingModified: true,
+					},
+					{
+						ID:           untouchedNodePoolID,
+						NodePoolName: "system",
+						MaxNode:      2,
+					},
+				}
+
+				f.cron.restoreAWSEvent(f.eventData, f.cron.tx, context.Background(), testCase.restoredStatus)
+
+				status, message := f.lastStatus(t)
+				if status != testCase.expectedStatus {
+					t.Fatalf("expected status %s, got %s (%s)", testCase.expectedStatus, status, message)
+				}
+				if testCase.expectedStatus == model.EventRestoreFailed &&
+					!strings.Contains(message, "workers") {
+					t.Fatalf("expected the failed node group in %q", message)
+				}
+				if len(f.eks.updateNodegroupConfig) != 1 {
+					t.Fatalf("expected 1 node group update, got %d", len(f.eks.updateNodegroupConfig))
+				}
+				scalingConfig := f.eks.updateNodegroupConfig[0].ScalingConfig
+				if aws.ToInt32(scalingConfig.MinSize) != 1 || aws.ToInt32(scalingConfig.MaxSize) != 3 {
+					t.Fatalf(
+						"expected original scaling config 1-3, got %d-%d",
+						aws.ToInt32(scalingConfig.MinSize),
+						aws.ToInt32(scalingConfig.MaxSize),
+					)
+				}
+				if aws.ToInt32(scalingConfig.DesiredSize) != aws.ToInt32(testCase.expectedDesiredSize) {
+					t.Fatalf(
+						"expected desired size %d, got %d",
+						aws.ToInt32(testCase.expectedDesiredSize),
+						aws.ToInt32(scalingConfig.DesiredSize),
+					)
+				}
+				if f.statistic.restoreStatuses[modifiedNodePoolID] != testCase.expectedRestoreStatus {
+					t.Fatalf(
+						"expected restore status %s, got %s",
+						testCase.expectedRestoreStatus,
+						f.statistic.restoreStatuses[modifiedNodePoolID],
+					)
+				}
+				if f.statistic.restoreStatuses[untouchedNodePoolID] != model.NodePoolUpdateSkipped {
+					t.Fatalf(
+						"expected untouched node group to be %s, got %s",
+						model.NodePoolUpdateSkipped,
+						f.statistic.restoreStatuses[untouchedNodePoolID],
+					)
+				}
+			},
+		)
+	}
+}
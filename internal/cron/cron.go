@@ -6,13 +6,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
-	v1Apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/api/autoscaling/v2beta2"
 	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,13 +29,26 @@ type Cron interface {
 }
 
 type cron struct {
-	eventUC              useCase.Event
-	clusterUC            useCase.Cluster
-	gcpClusterUC         useCase.GCPCluster
-	gcpDatacenterUC      useCase.GCPDatacenter
-	scheduledHPAConfigUC useCase.ScheduledHPAConfig
-	updatedNodePoolUC    useCase.Statistic
-	tx                   *gorm.DB
+	eventUC                   useCase.Event
+	clusterUC                 useCase.Cluster
+	gcpClusterUC              useCase.GCPCluster
+	gcpDatacenterUC           useCase.GCPDatacenter
+	scheduledHPAConfigUC      useCase.ScheduledHPAConfig
+	scheduledWorkloadConfigUC useCase.ScheduledWorkloadConfig
+	updatedNodePoolUC         useCase.Statistic
+	gcpEventUC                useCase.GCPEvent
+	awsClusterUC              useCase.AWSCluster
+	awsDatacenterUC           useCase.AWSDatacenter
+	awsEventUC                useCase.AWSEvent
+	azureClusterUC            useCase.AzureCluster
+	azureDatacenterUC         useCase.AzureDatacenter
+	azureEventUC              useCase.AzureEvent
+	kubeconfigClusterUC       useCase.KubeconfigCluster
+	kubeconfigDatacenterUC    useCase.KubeconfigDatacenter
+	kubeconfigEventUC         useCase.KubeconfigEvent
+	recurringEventUC          useCase.RecurringEvent
+	owner                     string
+	tx                        *gorm.DB
 }
 
 func newCron(
@@ -43,24 +57,126 @@ func newCron(
 	gcpClusterUC useCase.GCPCluster,
 	gcpDatacenterUC useCase.GCPDatacenter,
 	scheduledHPAConfigUC useCase.ScheduledHPAConfig,
+	scheduledWorkloadConfigUC useCase.ScheduledWorkloadConfig,
 	updatedNodePoolUC useCase.Statistic,
+	gcpEventUC useCase.GCPEvent,
+	awsClusterUC useCase.AWSCluster,
+	awsDatacenterUC useCase.AWSDatacenter,
+	awsEventUC useCase.AWSEvent,
+	azureClusterUC useCase.AzureCluster,
+	azureDatacenterUC useCase.AzureDatacenter,
+	azureEventUC useCase.AzureEvent,
+	kubeconfigClusterUC useCase.KubeconfigCluster,
+	kubeconfigDatacenterUC useCase.KubeconfigDatacenter,
+	kubeconfigEventUC useCase.KubeconfigEvent,
+	recurringEventUC useCase.RecurringEvent,
+	owner string,
 	tx *gorm.DB,
 ) Cron {
 	return &cron{
-		eventUC:              eventUC,
-		tx:                   tx,
-		clusterUC:            clusterUC,
-		gcpClusterUC:         gcpClusterUC,
-		gcpDatacenterUC:      gcpDatacenterUC,
-		scheduledHPAConfigUC: scheduledHPAConfigUC,
-		updatedNodePoolUC:    updatedNodePoolUC,
+		eventUC:                   eventUC,
+		tx:                        tx,
+		clusterUC:                 clusterUC,
+		gcpClusterUC:              gcpClusterUC,
+		gcpDatacenterUC:           gcpDatacenterUC,
+		scheduledHPAConfigUC:      scheduledHPAConfigUC,
+		scheduledWorkloadConfigUC: scheduledWorkloadConfigUC,
+		updatedNodePoolUC:         updatedNodePoolUC,
+		gcpEventUC:                gcpEventUC,
+		awsClusterUC:              awsClusterUC,
+		awsDatacenterUC:           awsDatacenterUC,
+		awsEventUC:                awsEventUC,
+		azureClusterUC:            azureClusterUC,
+		azureDatacenterUC:         azureDatacenterUC,
+		azureEventUC:              azureEventUC,
+		kubeconfigClusterUC:       kubeconfigClusterUC,
+		kubeconfigDatacenterUC:    kubeconfigDatacenterUC,
+		kubeconfigEventUC:         kubeconfigEventUC,
+		recurringEventUC:          recurringEventUC,
+		owner:                     owner,
+	}
+}
+
+func (c *cron) claimEvent(
+	db *gorm.DB,
+	e *UCEntity.Event,
+	status model.EventStatus,
+	now time.Time,
+) bool {
+	claimed, err := c.eventUC.ClaimEvent(db, e, status, c.owner, now)
+	return c.checkEventClaim(e, claimed, err)
+}
+
+// claimEventRestore claims the event for restoring, the restored status survives a takeover
+func (c *cron) claimEventRestore(
+	db *gorm.DB,
+	e *UCEntity.Event,
+	restoredStatus model.EventStatus,
+	now time.Time,
+) bool {
+	claimed, err := c.eventUC.ClaimEventRestore(db, e, restoredStatus, c.owner, now)
+	return c.checkEventClaim(e, claimed, err)
+}
+
+func (c *cron) checkEventClaim(e *UCEntity.Event, claimed bool, err error) bool {
+	if err != nil {
+		log.Errorf("[EventCronJob] Event : %s, Error claiming event : %s", e.Name, err.Error())
+		return false
+	}
+	if !claimed {
+		log.Infof("[EventCronJob] Event : %s, Already claimed by another worker", e.Name)
+	}
+	return claimed
+}
+
+// holdEventLease keeps renewing the event lease, the returned db and context are cancelled when the lease is lost
+// so the worker aborts instead of racing the worker taking over the event
+func (c *cron) holdEventLease(
+	db *gorm.DB,
+	e *UCEntity.Event,
+	ctx context.Context,
+) (*gorm.DB, context.Context, func()) {
+	leaseCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		renewTicker := time.NewTicker(constant.EventLeaseRenewInterval)
+		defer renewTicker.Stop()
+		for {
+			select {
+			case now := <-renewTicker.C:
+				renewed, err := c.eventUC.RenewEventLease(db, e.ID, c.owner, now)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Event : %s, Error renewing lease : %s",
+						e.Name,
+						err.Error(),
+					)
+					continue
+				}
+				if !renewed {
+					log.Errorf("[EventCronJob] Event : %s, Lease lost, aborting", e.Name)
+					cancel()
+					return
+				}
+			case <-leaseCtx.Done():
+				return
+			}
+		}
+	}()
+	return db.WithContext(leaseCtx), leaseCtx, func() {
+		cancel()
+		// Release as already expired and without the worker context,
+		// so a shutdown hands the event over to the next expired lease takeover immediately
+		err := c.eventUC.ReleaseEventLease(c.tx, e.ID, c.owner, time.Now())
+		if err != nil {
+			log.Errorf("[EventCronJob] Event : %s, Error releasing lease : %s", e.Name, err.Error())
+		}
 	}
 }
 
 func (c *cron) handleExecEventError(db *gorm.DB, e *UCEntity.Event, errMsg string) {
 	e.Status = model.EventFailed
 	e.Message = errMsg
-	err := c.eventUC.UpdateEvent(db, e)
+	err := c.eventUC.UpdateLeasedEventStatus(db, e, c.owner)
 	if err != nil {
 		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
 	}
@@ -69,17 +185,382 @@ func (c *cron) handleExecEventError(db *gorm.DB, e *UCEntity.Event, errMsg strin
 
 func (c *cron) handleWatchEvent(db *gorm.DB, e *UCEntity.Event, errMsg string) {
 	e.Message = errMsg
-	err := c.eventUC.UpdateEvent(db, e)
+	err := c.eventUC.UpdateLeasedEventStatus(db, e, c.owner)
 	if err != nil {
 		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
 	}
 	log.Errorf("[EventCronJob] Watching event : %s, Error : %s", e.Name, errMsg)
 }
 
+func (c *cron) handleRestoreEventError(db *gorm.DB, e *UCEntity.Event, errMsg string) {
+	e.Status = model.EventRestoreFailed
+	e.Message = errMsg
+	err := c.eventUC.UpdateLeasedEventStatus(db, e, c.owner)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+	}
+	log.Errorf("[EventCronJob] Restoring event : %s, Error : %s", e.Name, errMsg)
+}
+
+func (c *cron) getHPAObjectMap(existingK8sHPA []UCEntity.K8sHPAObjectData) map[string]interface{} {
+	existingK8sHPAMap := map[string]interface{}{}
+	for _, data := range existingK8sHPA {
+		switch h := data.HPAObject.(type) {
+		case v1.HorizontalPodAutoscaler:
+			existingK8sHPAMap[fmt.Sprintf(
+				constant.NameNSKeyFormat,
+				h.Name,
+				h.Namespace,
+			)] = h.DeepCopy()
+		case v2beta1.HorizontalPodAutoscaler:
+			existingK8sHPAMap[fmt.Sprintf(
+				constant.NameNSKeyFormat,
+				h.Name,
+				h.Namespace,
+			)] = h.DeepCopy()
+		case v2beta2.HorizontalPodAutoscaler:
+			existingK8sHPAMap[fmt.Sprintf(
+				constant.NameNSKeyFormat,
+				h.Name,
+				h.Namespace,
+			)] = h.DeepCopy()
+		case v2.HorizontalPodAutoscaler:
+			existingK8sHPAMap[fmt.Sprintf(
+				constant.NameNSKeyFormat,
+				h.Name,
+				h.Namespace,
+			)] = h.DeepCopy()
+		case UCEntity.ScaledObject:
+			existingK8sHPAMap[fmt.Sprintf(
+				constant.NameNSKeyFormat,
+				util.GetHPAObjectName(constant.ScaledObject, h.Name),
+				h.Namespace,
+			)] = &h
+		}
+	}
+	return existingK8sHPAMap
+}
+
+func (c *cron) setHPAReplicas(hpa interface{}, minReplicas *int32, maxReplicas int32) {
+	switch h := hpa.(type) {
+	case *v1.HorizontalPodAutoscaler:
+		h.Spec.MinReplicas = minReplicas
+		h.Spec.MaxReplicas = maxReplicas
+	case *v2beta1.HorizontalPodAutoscaler:
+		h.Spec.MinReplicas = minReplicas
+		h.Spec.MaxReplicas = maxReplicas
+	case *v2beta2.HorizontalPodAutoscaler:
+		h.Spec.MinReplicas = minReplicas
+		h.Spec.MaxReplicas = maxReplicas
+	case *v2.HorizontalPodAutoscaler:
+		h.Spec.MinReplicas = minReplicas
+		h.Spec.MaxReplicas = maxReplicas
+	case *UCEntity.ScaledObject:
+		h.MinReplicaCount = minReplicas
+		h.MaxReplicaCount = maxReplicas
+	}
+}
+
+func (c *cron) restoreHPA(
+	client kubernetes.Interface,
+	db *gorm.DB,
+	event *UCEntity.Event,
+	clusterData *UCEntity.ClusterData,
+	ctx context.Context,
+) ([]string, error) {
+	err := c.scheduledHPAConfigUC.SkipPendingScheduledHPASteps(db, event.ID, "event was restored")
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledHPAConfigs, err := c.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(db, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingK8sHPA, err := c.clusterUC.GetAllK8sHPAObjectInCluster(
+		ctx,
+		client,
+		clusterData.ID,
+		clusterData.LatestHPAAPIVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	existingK8sHPAMap := c.getHPAObjectMap(existingK8sHPA)
+
+	var failedHPAs []string
+	for _, scheduledHPAConfig := range scheduledHPAConfigs {
+		if scheduledHPAConfig.RestoreStatus == model.HPAUpdateSuccess {
+			continue
+		}
+
+		key := fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			util.GetHPAObjectName(scheduledHPAConfig.Kind, scheduledHPAConfig.Name),
+			scheduledHPAConfig.Namespace,
+		)
+
+		restoreStatus := model.HPAUpdateSuccess
+		restoreMessage := ""
+		hpa, ok := existingK8sHPAMap[key]
+		switch {
+		case scheduledHPAConfig.Status != model.HPAUpdateSuccess || scheduledHPAConfig.OriginalMaxReplicas == nil:
+			restoreStatus = model.HPAUpdateSkipped
+			restoreMessage = "hpa was not modified"
+		case !ok:
+			restoreStatus = model.HPAUpdateFailed
+			restoreMessage = "hpa not found"
+		default:
+			c.setHPAReplicas(
+				hpa,
+				scheduledHPAConfig.OriginalMinReplicas,
+				*scheduledHPAConfig.OriginalMaxReplicas,
+			)
+			err := c.clusterUC.RestoreHPABehaviorAndMetrics(hpa, scheduledHPAConfig)
+			if err == nil {
+				err = c.clusterUC.UpdateHPAK8sObject(
+					ctx,
+					client,
+					clusterData.ID,
+					hpa,
+					scheduledHPAConfig,
+					event.ForceHPAApply,
+				)
+			}
+			if err == nil {
+				err = c.clusterUC.ReleaseHPAK8sObject(ctx, client, hpa)
+			}
+			if err != nil {
+				restoreStatus = model.HPAUpdateFailed
+				restoreMessage = err.Error()
+			}
+		}
+
+		if restoreStatus == model.HPAUpdateFailed {
+			failedHPAs = append(failedHPAs, key)
+			log.Errorf(
+				"[EventCronJob] Restoring event : %s, HPA %s Namespace %s, Error : %s",
+				event.Name,
+				scheduledHPAConfig.Name,
+				scheduledHPAConfig.Namespace,
+				restoreMessage,
+			)
+		}
+
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigRestoreStatusMessage(
+			db,
+			scheduledHPAConfig.ID,
+			restoreStatus,
+			restoreMessage,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return failedHPAs, nil
+}
+
+func (c *cron) getWorkloadKey(kind, name, namespace string) string {
+	return fmt.Sprintf(
+		constant.NameAndNamespaceKeyFormat,
+		fmt.Sprintf("%s/%s", kind, name),
+		namespace,
+	)
+}
+
+func (c *cron) scaleWorkloads(
+	client kubernetes.Interface,
+	db *gorm.DB,
+	event *UCEntity.Event,
+	plan *UCEntity.EventPlan,
+	ctx context.Context,
+) error {
+	//Give error message to missing workload
+	for _, modifiedWorkload := range plan.MissingWorkloads {
+		err := c.scheduledWorkloadConfigUC.UpdateScheduledWorkloadConfigStatusMessage(
+			db,
+			modifiedWorkload.ID,
+			model.WorkloadUpdateFailed,
+			"workload not found",
+		)
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Event : %s, Error Update %s %s Namespace %s : %s",
+				event.Name,
+				modifiedWorkload.Kind,
+				modifiedWorkload.Name,
+				modifiedWorkload.Namespace,
+				err.Error(),
+			)
+		}
+	}
+
+	for _, workloadPlan := range plan.SelectedWorkloads {
+		modifiedWorkload := workloadPlan.ModifiedWorkloadConfig
+		if modifiedWorkload.Status == model.WorkloadUpdateSuccess {
+			continue
+		}
+
+		// Keep the replicas snapshotted by a previous attempt
+		if modifiedWorkload.OriginalReplicas == nil {
+			err := c.scheduledWorkloadConfigUC.UpdateScheduledWorkloadConfigOriginalReplicas(
+				db,
+				modifiedWorkload.ID,
+				workloadPlan.CurrentReplicas,
+			)
+			if err != nil {
+				return fmt.Errorf(
+					"Error Update %s %s Namespace %s : %s",
+					modifiedWorkload.Kind,
+					modifiedWorkload.Name,
+					modifiedWorkload.Namespace,
+					err.Error(),
+				)
+			}
+		}
+
+		log.Infof(
+			"[EventCronJob] Event : %s, Scaling %s %s namespace %s to %d replicas (before : %d)",
+			event.Name,
+			modifiedWorkload.Kind,
+			modifiedWorkload.Name,
+			modifiedWorkload.Namespace,
+			modifiedWorkload.Replicas,
+			workloadPlan.CurrentReplicas,
+		)
+
+		status := model.WorkloadUpdateSuccess
+		message := ""
+		err := c.clusterUC.ScaleWorkload(
+			ctx,
+			client,
+			modifiedWorkload.Kind,
+			modifiedWorkload.Name,
+			modifiedWorkload.Namespace,
+			modifiedWorkload.Replicas,
+		)
+		if err != nil {
+			status = model.WorkloadUpdateFailed
+			message = err.Error()
+			log.Errorf(
+				"[EventCronJob] Event : %s, %s %s Namespace %s, Error : %s",
+				event.Name,
+				modifiedWorkload.Kind,
+				modifiedWorkload.Name,
+				modifiedWorkload.Namespace,
+				message,
+			)
+		}
+
+		err = c.scheduledWorkloadConfigUC.UpdateScheduledWorkloadConfigStatusMessage(
+			db,
+			modifiedWorkload.ID,
+			status,
+			message,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"Error Update %s %s Namespace %s : %s",
+				modifiedWorkload.Kind,
+				modifiedWorkload.Name,
+				modifiedWorkload.Namespace,
+				err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (c *cron) restoreWorkloads(
+	client kubernetes.Interface,
+	db *gorm.DB,
+	event *UCEntity.Event,
+	ctx context.Context,
+) ([]string, error) {
+	scheduledWorkloadConfigs, err := c.scheduledWorkloadConfigUC.ListScheduledWorkloadConfigByEventID(
+		db,
+		event.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var failedWorkloads []string
+	for _, scheduledWorkloadConfig := range scheduledWorkloadConfigs {
+		if scheduledWorkloadConfig.RestoreStatus == model.WorkloadUpdateSuccess {
+			continue
+		}
+
+		restoreStatus := model.WorkloadUpdateSuccess
+		restoreMessage := ""
+		if scheduledWorkloadConfig.Status != model.WorkloadUpdateSuccess ||
+			scheduledWorkloadConfig.OriginalReplicas == nil {
+			restoreStatus = model.WorkloadUpdateSkipped
+			restoreMessage = "workload was not modified"
+		} else {
+			log.Infof(
+				"[EventCronJob] Restoring event : %s, Scaling %s %s namespace %s to original %d replicas",
+				event.Name,
+				scheduledWorkloadConfig.Kind,
+				scheduledWorkloadConfig.Name,
+				scheduledWorkloadConfig.Namespace,
+				*scheduledWorkloadConfig.OriginalReplicas,
+			)
+			err := c.clusterUC.ScaleWorkload(
+				ctx,
+				client,
+				scheduledWorkloadConfig.Kind,
+				scheduledWorkloadConfig.Name,
+				scheduledWorkloadConfig.Namespace,
+				*scheduledWorkloadConfig.OriginalReplicas,
+			)
+			if err != nil {
+				restoreStatus = model.WorkloadUpdateFailed
+				restoreMessage = err.Error()
+			}
+		}
+
+		if restoreStatus == model.WorkloadUpdateFailed {
+			failedWorkloads = append(
+				failedWorkloads,
+				fmt.Sprintf(
+					constant.NameNSKeyFormat,
+					fmt.Sprintf("%s/%s", scheduledWorkloadConfig.Kind, scheduledWorkloadConfig.Name),
+					scheduledWorkloadConfig.Namespace,
+				),
+			)
+			log.Errorf(
+				"[EventCronJob] Restoring event : %s, %s %s Namespace %s, Error : %s",
+				event.Name,
+				scheduledWorkloadConfig.Kind,
+				scheduledWorkloadConfig.Name,
+				scheduledWorkloadConfig.Namespace,
+				restoreMessage,
+			)
+		}
+
+		err := c.scheduledWorkloadConfigUC.UpdateScheduledWorkloadConfigRestoreStatusMessage(
+			db,
+			scheduledWorkloadConfig.ID,
+			restoreStatus,
+			restoreMessage,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return failedWorkloads, nil
+}
+
 func (c *cron) watchNodePool(
 	client kubernetes.Interface,
 	db *gorm.DB,
-	provider model.DatacenterProvider,
+	getNodePoolName func(nodeLabels map[string]string) string,
 	event *UCEntity.Event,
 	now time.Time,
 	ctx context.Context,
@@ -96,13 +577,7 @@ func (c *cron) watchNodePool(
 	}
 	nodeCounts := map[string]int32{}
 	for _, node := range nodes.Items {
-		nodeLabels := node.Labels
-		var nodePoolName string
-		switch provider {
-		case model.GCP:
-			nodePoolName = nodeLabels[constant.GCPNodePoolLabel]
-			nodeCounts[nodePoolName] += 1
-		}
+		nodeCounts[getNodePoolName(node.Labels)] += 1
 	}
 
 	var nodePoolStatusObjects []model.NodePoolStatus
@@ -135,6 +610,7 @@ func (c *cron) watchHPA(
 	db *gorm.DB,
 	event *UCEntity.Event,
 	scheduledHPAConfigs []*UCEntity.EventModifiedHPAConfigData,
+	scheduledWorkloadConfigs []*UCEntity.EventModifiedWorkloadConfigData,
 	now time.Time,
 	ctx context.Context,
 ) {
@@ -152,10 +628,13 @@ func (c *cron) watchHPA(
 	for _, scheduledHPAConfig := range scheduledHPAConfigs {
 		key := fmt.Sprintf(
 			constant.NameAndNamespaceKeyFormat,
-			scheduledHPAConfig.Name,
+			util.GetHPAObjectName(scheduledHPAConfig.Kind, scheduledHPAConfig.Name),
 			scheduledHPAConfig.Namespace,
 		)
-		data := deploymentDataMap[key]
+		data, ok := deploymentDataMap[key]
+		if !ok {
+			continue
+		}
 		hpaStatus := model.HPAStatus{
 			CreatedAt:           now,
 			Replicas:            data.Replicas,
@@ -167,14 +646,49 @@ func (c *cron) watchHPA(
 		selectedHPAStatuses = append(selectedHPAStatuses, hpaStatus)
 	}
 
-	err = db.Create(&selectedHPAStatuses).Error
-	if err != nil {
-		log.Errorf(
-			"[EventCronJob] Watching event : %s, Watch hpa error : %s",
-			event.Name,
-			err.Error(),
-		)
-		return
+	var selectedWorkloadStatuses []model.WorkloadStatus
+	for _, scheduledWorkloadConfig := range scheduledWorkloadConfigs {
+		data, ok := deploymentDataMap[c.getWorkloadKey(
+			scheduledWorkloadConfig.Kind,
+			scheduledWorkloadConfig.Name,
+			scheduledWorkloadConfig.Namespace,
+		)]
+		if !ok {
+			continue
+		}
+		workloadStatus := model.WorkloadStatus{
+			CreatedAt:           now,
+			Replicas:            data.Replicas,
+			AvailableReplicas:   data.AvailableReplicas,
+			UnavailableReplicas: data.UnavailableReplicas,
+			ReadyReplicas:       data.ReadyReplicas,
+		}
+		workloadStatus.ScheduledWorkloadConfigID.SetUUID(scheduledWorkloadConfig.ID)
+		selectedWorkloadStatuses = append(selectedWorkloadStatuses, workloadStatus)
+	}
+
+	if len(selectedHPAStatuses) != 0 {
+		err = db.Create(&selectedHPAStatuses).Error
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Watching event : %s, Watch hpa error : %s",
+				event.Name,
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	if len(selectedWorkloadStatuses) != 0 {
+		err = db.Create(&selectedWorkloadStatuses).Error
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Watching event : %s, Watch workload error : %s",
+				event.Name,
+				err.Error(),
+			)
+			return
+		}
 	}
 
 	log.Infof(
@@ -186,13 +700,8 @@ func (c *cron) watchHPA(
 
 func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 	log.Infof("[EventCronJob] Watching event %s", e.Name)
-	e.Status = model.EventWatching
-
-	err := c.eventUC.UpdateEvent(db, e)
-	if err != nil {
-		log.Errorf("[EventCronJob] Error update event : %s", err.Error())
-		return
-	}
+	db, ctx, releaseLease := c.holdEventLease(db, e, ctx)
+	defer releaseLease()
 
 	clusterID := e.Cluster.ID
 	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
@@ -202,6 +711,7 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 	}
 	datacenter := clusterData.Datacenter.Datacenter
 	var kubernetesClient kubernetes.Interface
+	var getNodePoolName func(nodeLabels map[string]string) string
 
 	// Get Clients
 	switch datacenter {
@@ -211,6 +721,52 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 			c.handleWatchEvent(db, e, err.Error())
 			return
 		}
+		getNodePoolName = func(nodeLabels map[string]string) string {
+			return nodeLabels[constant.GCPNodePoolLabel]
+		}
+	case model.AWS:
+		kubernetesClient, _, err = c.getAllAWSClient(ctx, clusterData)
+		if err != nil {
+			c.handleWatchEvent(db, e, err.Error())
+			return
+		}
+		getNodePoolName = func(nodeLabels map[string]string) string {
+			return nodeLabels[constant.EKSNodeGroupLabel]
+		}
+	case model.Azure:
+		kubernetesClient, _, err = c.getAllAzureClient(clusterData, ctx)
+		if err != nil {
+			c.handleWatchEvent(db, e, err.Error())
+			return
+		}
+		getNodePoolName = func(nodeLabels map[string]string) string {
+			return nodeLabels[constant.AKSAgentPoolLabel]
+		}
+	case model.Kubeconfig:
+		var credentials *UCEntity.KubeconfigCredentials
+		kubernetesClient, credentials, err = c.getAllKubeconfigClient(clusterData)
+		if err != nil {
+			c.handleWatchEvent(db, e, err.Error())
+			return
+		}
+		nodePoolLabel := credentials.NodePoolLabel
+		capacityBackend, err := c.kubeconfigClusterUC.GetCapacityBackend(clusterData)
+		if err != nil {
+			c.handleWatchEvent(db, e, err.Error())
+			return
+		}
+		if capacityBackend != nil {
+			nodePoolLabel = capacityBackend.GetNodePoolLabel()
+		}
+		getNodePoolName = func(nodeLabels map[string]string) string {
+			return c.kubeconfigClusterUC.GetNodePoolName(nodeLabels, nodePoolLabel)
+		}
+	}
+
+	err = c.clusterUC.RefreshLatestHPAAPIVersion(db, kubernetesClient, clusterData)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
 	}
 
 	scheduledHPAConfigs, err := c.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(db, e.ID)
@@ -233,9 +789,10 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 	mapHPAScaleTargetRef := map[string]interface{}{}
 	for _, data := range allHPAK8sObject {
 		for _, scheduledHPAConfig := range scheduledHPAConfigs {
+			name := util.GetHPAObjectName(scheduledHPAConfig.Kind, scheduledHPAConfig.Name)
 			switch h := data.HPAObject.(type) {
 			case v1.HorizontalPodAutoscaler:
-				if scheduledHPAConfig.Name == h.Name && scheduledHPAConfig.Namespace == h.Namespace {
+				if name == h.Name && scheduledHPAConfig.Namespace == h.Namespace {
 					mapHPAScaleTargetRef[fmt.Sprintf(
 						constant.NameAndNamespaceKeyFormat,
 						h.Name,
@@ -244,7 +801,7 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 					break
 				}
 			case v2beta1.HorizontalPodAutoscaler:
-				if scheduledHPAConfig.Name == h.Name && scheduledHPAConfig.Namespace == h.Namespace {
+				if name == h.Name && scheduledHPAConfig.Namespace == h.Namespace {
 					mapHPAScaleTargetRef[fmt.Sprintf(
 						constant.NameAndNamespaceKeyFormat,
 						h.Name,
@@ -253,7 +810,7 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 					break
 				}
 			case v2beta2.HorizontalPodAutoscaler:
-				if scheduledHPAConfig.Name == h.Name && scheduledHPAConfig.Namespace == h.Namespace {
+				if name == h.Name && scheduledHPAConfig.Namespace == h.Namespace {
 					mapHPAScaleTargetRef[fmt.Sprintf(
 						constant.NameAndNamespaceKeyFormat,
 						h.Name,
@@ -261,10 +818,56 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 					)] = h.Spec.ScaleTargetRef
 					break
 				}
+			case v2.HorizontalPodAutoscaler:
+				if name == h.Name && scheduledHPAConfig.Namespace == h.Namespace {
+					mapHPAScaleTargetRef[fmt.Sprintf(
+						constant.NameAndNamespaceKeyFormat,
+						h.Name,
+						h.Namespace,
+					)] = h.Spec.ScaleTargetRef
+					break
+				}
+			case UCEntity.ScaledObject:
+				scaledObjectName := util.GetHPAObjectName(constant.ScaledObject, h.Name)
+				if name == scaledObjectName && scheduledHPAConfig.Namespace == h.Namespace {
+					mapHPAScaleTargetRef[fmt.Sprintf(
+						constant.NameAndNamespaceKeyFormat,
+						scaledObjectName,
+						h.Namespace,
+					)] = h.ScaleTargetRef
+					break
+				}
 			}
 		}
 	}
 
+	scheduledWorkloadConfigs, err := c.scheduledWorkloadConfigUC.ListScheduledWorkloadConfigByEventID(
+		db,
+		e.ID,
+	)
+	if err != nil {
+		c.handleWatchEvent(db, e, err.Error())
+		return
+	}
+
+	// Workloads scaled without an HPA are watched through their kind qualified name
+	var watchedWorkloadConfigs []*UCEntity.EventModifiedWorkloadConfigData
+	for _, scheduledWorkloadConfig := range scheduledWorkloadConfigs {
+		if scheduledWorkloadConfig.Status != model.WorkloadUpdateSuccess {
+			continue
+		}
+		mapHPAScaleTargetRef[c.getWorkloadKey(
+			scheduledWorkloadConfig.Kind,
+			scheduledWorkloadConfig.Name,
+			scheduledWorkloadConfig.Namespace,
+		)] = v1.CrossVersionObjectReference{
+			Kind:       scheduledWorkloadConfig.Kind,
+			Name:       scheduledWorkloadConfig.Name,
+			APIVersion: constant.AppsV1,
+		}
+		watchedWorkloadConfigs = append(watchedWorkloadConfigs, scheduledWorkloadConfig)
+	}
+
 	getAllDeploymentsFunc := func(ctx context.Context) (map[string]*DeploymentPodData, error) {
 		mapDeploymentsPodData := map[string]*DeploymentPodData{}
 		errGroup, ctxEg := errgroup.WithContext(ctx)
@@ -295,14 +898,10 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 						return err
 					}
 
-					switch resolveRes := res.(type) {
-					case *v1Apps.Deployment:
-						status := resolveRes.Status
-						data.Replicas = status.Replicas
-						data.UnavailableReplicas = status.UnavailableReplicas
-						data.ReadyReplicas = status.ReadyReplicas
-						data.AvailableReplicas = status.AvailableReplicas
-					}
+					data.Replicas = res.Replicas
+					data.UnavailableReplicas = res.UnavailableReplicas
+					data.ReadyReplicas = res.ReadyReplicas
+					data.AvailableReplicas = res.AvailableReplicas
 
 					return nil
 				}
@@ -344,8 +943,18 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 				return
 			}
 
-			go c.watchNodePool(kubernetesClient, db, datacenter, e, now, ctx, updatedNodePoolMap)
-			go c.watchHPA(getAllDeploymentsFunc, db, e, scheduledHPAConfigs, now, ctx)
+			// Applied in line so a slow step is not applied again by the next tick
+			c.applyHPASteps(e, db, ctx, now)
+			go c.watchNodePool(kubernetesClient, db, getNodePoolName, e, now, ctx, updatedNodePoolMap)
+			go c.watchHPA(
+				getAllDeploymentsFunc,
+				db,
+				e,
+				scheduledHPAConfigs,
+				watchedWorkloadConfigs,
+				now,
+				ctx,
+			)
 		case <-ctx.Done():
 			return
 		}
@@ -354,10 +963,11 @@ func (c *cron) watchEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
 }
 
 func (c *cron) Start() {
-	log.Infof("Starting event cron job")
+	log.Infof("Starting event cron job with worker id %s", c.owner)
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer cancel()
 	db := c.tx.WithContext(ctx)
+	c.recoverEvents(db, ctx, time.Now())
 	mainTicker := time.NewTicker(1 * time.Minute)
 	defer mainTicker.Stop()
 	for {
@@ -373,10 +983,18 @@ func (c *cron) Start() {
 				}
 				if len(pendingEvents) != 0 && err == nil {
 					for _, pendingEvent := range pendingEvents {
+						if !c.claimEvent(db, pendingEvent, model.EventExecuting, now) {
+							continue
+						}
 						switch pendingEvent.Cluster.Datacenter.Datacenter {
 						case model.GCP:
 							go c.execGCPEvent(pendingEvent, db, ctx)
-
+						case model.AWS:
+							go c.execAWSEvent(pendingEvent, db, ctx)
+						case model.Azure:
+							go c.execAzureEvent(pendingEvent, db, ctx)
+						case model.Kubeconfig:
+							go c.execKubeconfigEvent(pendingEvent, db, ctx)
 						}
 					}
 				}
@@ -392,19 +1010,189 @@ func (c *cron) Start() {
 				}
 				if len(prescaledEvents) != 0 && err == nil {
 					for _, prescaledEvent := range prescaledEvents {
+						if !c.claimEvent(db, prescaledEvent, model.EventWatching, now) {
+							continue
+						}
 						go c.watchEvent(prescaledEvent, db, ctx)
 					}
 				}
 			}()
 
 			go func() {
-				err := c.eventUC.FinishAllWatchedEvent(db, now)
+				watchedEvents, err := c.eventUC.GetAllFinishedWatchedEvent(db, now)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Error getting finished watched events : %s",
+						err.Error(),
+					)
+				}
+				if len(watchedEvents) != 0 && err == nil {
+					for _, watchedEvent := range watchedEvents {
+						if !c.claimEventRestore(db, watchedEvent, model.EventSuccess, now) {
+							continue
+						}
+						switch watchedEvent.Cluster.Datacenter.Datacenter {
+						case model.GCP:
+							go c.restoreGCPEvent(watchedEvent, db, ctx, model.EventSuccess)
+						case model.AWS:
+							go c.restoreAWSEvent(watchedEvent, db, ctx, model.EventSuccess)
+						case model.Azure:
+							go c.restoreAzureEvent(watchedEvent, db, ctx, model.EventSuccess)
+						case model.Kubeconfig:
+							go c.restoreKubeconfigEvent(watchedEvent, db, ctx, model.EventSuccess)
+						}
+					}
+				}
+			}()
+
+			go func() {
+				cancelledEvents, err := c.eventUC.GetAllCancelledUnrestoredEvent(db)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Error getting cancelled events : %s",
+						err.Error(),
+					)
+				}
+				if len(cancelledEvents) != 0 && err == nil {
+					for _, cancelledEvent := range cancelledEvents {
+						if !c.claimEventRestore(db, cancelledEvent, model.EventCancelled, now) {
+							continue
+						}
+						switch cancelledEvent.Cluster.Datacenter.Datacenter {
+						case model.GCP:
+							go c.restoreGCPEvent(cancelledEvent, db, ctx, model.EventCancelled)
+						case model.AWS:
+							go c.restoreAWSEvent(cancelledEvent, db, ctx, model.EventCancelled)
+						case model.Azure:
+							go c.restoreAzureEvent(cancelledEvent, db, ctx, model.EventCancelled)
+						case model.Kubeconfig:
+							go c.restoreKubeconfigEvent(cancelledEvent, db, ctx, model.EventCancelled)
+						}
+					}
+				}
+			}()
+
+			go func() {
+				steppedEvents, err := c.eventUC.GetAllEventWithDueHPAStep(db, now)
+				if err != nil {
+					log.Errorf(
+						"[EventCronJob] Error getting events with due ramp steps : %s",
+						err.Error(),
+					)
+				}
+				if len(steppedEvents) != 0 && err == nil {
+					for _, steppedEvent := range steppedEvents {
+						if !c.claimEvent(db, steppedEvent, model.EventPrescaled, now) {
+							continue
+						}
+						go c.applyPrescaledHPASteps(steppedEvent, db, ctx, now)
+					}
+				}
+			}()
+
+			go func() {
+				err := c.recurringEventUC.MaterializeRecurringEvents(db, now)
 				if err != nil {
-					log.Errorf("[EventCronJob] Error update watched events : %s", err.Error())
+					log.Errorf(
+						"[EventCronJob] Error materializing recurring events : %s",
+						err.Error(),
+					)
 				}
 			}()
+
+			go c.takeOverExpiredEvents(db, ctx, now)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
+
+func (c *cron) takeOverExpiredEvents(db *gorm.DB, ctx context.Context, now time.Time) {
+	for _, status := range []model.EventStatus{
+		model.EventExecuting,
+		model.EventWatching,
+		model.EventRestoring,
+	} {
+		expiredEvents, err := c.eventUC.GetAllExpiredLeaseEvent(db, status, now)
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Error getting expired %s events : %s",
+				status,
+				err.Error(),
+			)
+			continue
+		}
+		c.takeOverEvents(db, ctx, now, status, expiredEvents)
+	}
+}
+
+func (c *cron) recoverEvents(db *gorm.DB, ctx context.Context, now time.Time) {
+	log.Infof("[EventCronJob] Recovering interrupted events")
+	for _, status := range []model.EventStatus{
+		model.EventExecuting,
+		model.EventWatching,
+		model.EventRestoring,
+	} {
+		unleasedEvents, err := c.eventUC.GetAllUnleasedEvent(db, status)
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Error getting interrupted %s events : %s",
+				status,
+				err.Error(),
+			)
+			continue
+		}
+		c.takeOverEvents(db, ctx, now, status, unleasedEvents)
+	}
+	c.takeOverExpiredEvents(db, ctx, now)
+}
+
+func (c *cron) takeOverEvents(
+	db *gorm.DB,
+	ctx context.Context,
+	now time.Time,
+	status model.EventStatus,
+	events []*UCEntity.Event,
+) {
+	for _, e := range events {
+		// Finished watched events are restored by the regular restore claim
+		if status == model.EventWatching && now.After(e.EndTime) {
+			continue
+		}
+		if !c.claimEvent(db, e, status, now) {
+			continue
+		}
+		log.Infof("[EventCronJob] Event : %s, Taking over %s event", e.Name, status)
+		switch status {
+		case model.EventExecuting:
+			switch e.Cluster.Datacenter.Datacenter {
+			case model.GCP:
+				go c.execGCPEvent(e, db, ctx)
+			case model.AWS:
+				go c.execAWSEvent(e, db, ctx)
+			case model.Azure:
+				go c.execAzureEvent(e, db, ctx)
+			case model.Kubeconfig:
+				go c.execKubeconfigEvent(e, db, ctx)
+			}
+		case model.EventWatching:
+			go c.watchEvent(e, db, ctx)
+		case model.EventRestoring:
+			restoredStatus := e.RestoredStatus
+			// Events claimed for restoring before the restored status was kept
+			if restoredStatus == "" {
+				restoredStatus = model.EventSuccess
+			}
+			switch e.Cluster.Datacenter.Datacenter {
+			case model.GCP:
+				go c.restoreGCPEvent(e, db, ctx, restoredStatus)
+			case model.AWS:
+				go c.restoreAWSEvent(e, db, ctx, restoredStatus)
+			case model.Azure:
+				go c.restoreAzureEvent(e, db, ctx, restoredStatus)
+			case model.Kubeconfig:
+				go c.restoreKubeconfigEvent(e, db, ctx, restoredStatus)
+			}
+		}
+	}
+}
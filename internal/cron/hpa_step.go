@@ -0,0 +1,183 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+	"time"
+)
+
+func (c *cron) getKubernetesClient(
+	ctx context.Context,
+	clusterData *UCEntity.ClusterData,
+) (kubernetes.Interface, error) {
+	var kubernetesClient kubernetes.Interface
+	var err error
+	switch clusterData.Datacenter.Datacenter {
+	case model.GCP:
+		kubernetesClient, _, err = c.getAllGCPClient(ctx, clusterData)
+	case model.AWS:
+		kubernetesClient, _, err = c.getAllAWSClient(ctx, clusterData)
+	case model.Azure:
+		kubernetesClient, _, err = c.getAllAzureClient(clusterData, ctx)
+	case model.Kubeconfig:
+		kubernetesClient, _, err = c.getAllKubeconfigClient(clusterData)
+	default:
+		err = errors.New(errorConstant.DatacenterTypeNotFound)
+	}
+	return kubernetesClient, err
+}
+
+func (c *cron) getDueHPASteps(scheduledHPAConfig *UCEntity.EventModifiedHPAConfigData, now time.Time) []UCEntity.HPAStepData {
+	var dueSteps []UCEntity.HPAStepData
+	for _, step := range append(scheduledHPAConfig.RampUpSteps, scheduledHPAConfig.RampDownSteps...) {
+		if step.Status == model.HPAUpdatePending && !step.ExecuteAt.After(now) {
+			dueSteps = append(dueSteps, step)
+		}
+	}
+	return dueSteps
+}
+
+// applyPrescaledHPASteps applies the due ramp steps of a claimed prescaled event while holding its lease,
+// watched events apply them from their watcher
+func (c *cron) applyPrescaledHPASteps(e *UCEntity.Event, db *gorm.DB, ctx context.Context, now time.Time) {
+	db, ctx, releaseLease := c.holdEventLease(db, e, ctx)
+	defer releaseLease()
+	c.applyHPASteps(e, db, ctx, now)
+}
+
+// applyHPASteps applies the latest due ramp step of every HPA, earlier due steps are superseded by it.
+// The caller must hold the event lease.
+func (c *cron) applyHPASteps(e *UCEntity.Event, db *gorm.DB, ctx context.Context, now time.Time) {
+	scheduledHPAConfigs, err := c.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(db, e.ID)
+	if err != nil {
+		log.Errorf("[EventCronJob] Event : %s, Applying ramp steps error : %s", e.Name, err.Error())
+		return
+	}
+
+	hasDueStep := false
+	for _, scheduledHPAConfig := range scheduledHPAConfigs {
+		if len(c.getDueHPASteps(scheduledHPAConfig, now)) > 0 {
+			hasDueStep = true
+			break
+		}
+	}
+	if !hasDueStep {
+		return
+	}
+
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, e.Cluster.ID)
+	if err != nil {
+		log.Errorf("[EventCronJob] Event : %s, Applying ramp steps error : %s", e.Name, err.Error())
+		return
+	}
+
+	kubernetesClient, err := c.getKubernetesClient(ctx, clusterData)
+	if err != nil {
+		log.Errorf("[EventCronJob] Event : %s, Applying ramp steps error : %s", e.Name, err.Error())
+		return
+	}
+
+	err = c.clusterUC.RefreshLatestHPAAPIVersion(db, kubernetesClient, clusterData)
+	if err != nil {
+		log.Errorf("[EventCronJob] Event : %s, Applying ramp steps error : %s", e.Name, err.Error())
+		return
+	}
+
+	existingK8sHPA, err := c.clusterUC.GetAllK8sHPAObjectInCluster(
+		ctx,
+		kubernetesClient,
+		clusterData.ID,
+		clusterData.LatestHPAAPIVersion,
+	)
+	if err != nil {
+		log.Errorf("[EventCronJob] Event : %s, Applying ramp steps error : %s", e.Name, err.Error())
+		return
+	}
+	existingK8sHPAMap := c.getHPAObjectMap(existingK8sHPA)
+
+	for _, scheduledHPAConfig := range scheduledHPAConfigs {
+		dueSteps := c.getDueHPASteps(scheduledHPAConfig, now)
+		if len(dueSteps) == 0 {
+			continue
+		}
+
+		for _, step := range dueSteps[:len(dueSteps)-1] {
+			err := c.scheduledHPAConfigUC.UpdateScheduledHPAStepStatusMessage(
+				db,
+				step.ID,
+				model.HPAUpdateSkipped,
+				"superseded by a later step",
+			)
+			if err != nil {
+				log.Errorf("[EventCronJob] Event : %s, Applying ramp steps error : %s", e.Name, err.Error())
+				return
+			}
+		}
+
+		step := dueSteps[len(dueSteps)-1]
+		key := fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			util.GetHPAObjectName(scheduledHPAConfig.Kind, scheduledHPAConfig.Name),
+			scheduledHPAConfig.Namespace,
+		)
+
+		status := model.HPAUpdateSuccess
+		message := ""
+		hpa, ok := existingK8sHPAMap[key]
+		switch {
+		case scheduledHPAConfig.Status != model.HPAUpdateSuccess:
+			status = model.HPAUpdateSkipped
+			message = "hpa was not modified"
+		case !ok:
+			status = model.HPAUpdateFailed
+			message = "hpa not found"
+		default:
+			c.setHPAReplicas(hpa, step.MinReplicas, step.MaxReplicas)
+			err := c.clusterUC.UpdateHPAK8sObject(
+				ctx,
+				kubernetesClient,
+				clusterData.ID,
+				hpa,
+				scheduledHPAConfig,
+				e.ForceHPAApply,
+			)
+			if err != nil {
+				status = model.HPAUpdateFailed
+				message = err.Error()
+			}
+		}
+
+		if status == model.HPAUpdateFailed {
+			log.Errorf(
+				"[EventCronJob] Event : %s, Ramp step of HPA %s Namespace %s, Error : %s",
+				e.Name,
+				scheduledHPAConfig.Name,
+				scheduledHPAConfig.Namespace,
+				message,
+			)
+		} else {
+			log.Infof(
+				"[EventCronJob] Event : %s, Ramp step of HPA %s Namespace %s : %s",
+				e.Name,
+				scheduledHPAConfig.Name,
+				scheduledHPAConfig.Namespace,
+				status,
+			)
+		}
+
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAStepStatusMessage(db, step.ID, status, message)
+		if err != nil {
+			log.Errorf("[EventCronJob] Event : %s, Applying ramp steps error : %s", e.Name, err.Error())
+			return
+		}
+	}
+}
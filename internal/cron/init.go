@@ -1,18 +1,38 @@
 package cron
 
 import (
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/config"
 	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"os"
 )
 
 func BuildCron(useCases *useCase.UseCases, resources *config.KubeEPResources) Cron {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "kubeEP-cron"
+	}
 	return newCron(
 		useCases.Event,
 		useCases.Cluster,
 		useCases.GcpCluster,
 		useCases.GcpDatacenter,
 		useCases.ScheduledHPAConfig,
+		useCases.ScheduledWorkloadConfig,
 		useCases.UpdatedNodePool,
+		useCases.GcpEvent,
+		useCases.AwsCluster,
+		useCases.AwsDatacenter,
+		useCases.AwsEvent,
+		useCases.AzureCluster,
+		useCases.AzureDatacenter,
+		useCases.AzureEvent,
+		useCases.KubeconfigCluster,
+		useCases.KubeconfigDatacenter,
+		useCases.KubeconfigEvent,
+		useCases.RecurringEvent,
+		fmt.Sprintf("%s-%s", hostname, uuid.New().String()),
 		resources.DB,
 	)
 }
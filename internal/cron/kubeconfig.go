@@ -0,0 +1,509 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+	"sync"
+)
+
+func (c *cron) getAllKubeconfigClient(
+	clusterData *UCEntity.ClusterData,
+) (kubernetes.Interface, *UCEntity.KubeconfigCredentials, error) {
+	datacenter := clusterData.Datacenter.Datacenter
+	if datacenter != model.Kubeconfig {
+		return nil, nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+	credentials, err := c.kubeconfigDatacenterUC.ParseCredentials(
+		UCEntity.DatacenterData{
+			Credentials: clusterData.Datacenter.Credentials,
+			Name:        clusterData.Datacenter.Name,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	kubernetesClient, err := c.kubeconfigClusterUC.GetKubernetesClusterClient(
+		credentials,
+		clusterData,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kubernetesClient, credentials, nil
+}
+
+func (c *cron) execKubeconfigEvent(e *UCEntity.Event, db *gorm.DB, ctx context.Context) {
+	log.Infof("[EventCronJob] Executing event %s", e.Name)
+	db, ctx, releaseLease := c.holdEventLease(db, e, ctx)
+	defer releaseLease()
+
+	if !e.CalculateNodePool {
+		log.Infof("[EventCronJob] Event %s, skipping node pool calculation", e.Name)
+	}
+
+	clusterID := e.Cluster.ID
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Get Clients
+	kubernetesClient, credentials, err := c.getAllKubeconfigClient(clusterData)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	err = c.clusterUC.RefreshLatestHPAAPIVersion(db, kubernetesClient, clusterData)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	// Calculate Event Plan
+	log.Infof("[EventCronJob] Event : %s, Calculating event plan", e.Name)
+	plan, err := c.kubeconfigEventUC.CalculateKubeconfigEventPlan(
+		ctx,
+		db,
+		kubernetesClient,
+		credentials.NodePoolLabel,
+		clusterData,
+		e,
+	)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	//Give error message to missing hpa
+	for _, modifiedHPA := range plan.MissingHPAs {
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigStatusMessage(
+			db,
+			modifiedHPA.ID,
+			model.HPAUpdateFailed,
+			"hpa not found",
+		)
+		if err != nil {
+			log.Errorf(
+				"[EventCronJob] Event : %s, Error Update HPA %s Namespace %s : %s",
+				e.Name,
+				modifiedHPA.Name,
+				modifiedHPA.Namespace,
+				err.Error(),
+			)
+		}
+	}
+
+	if len(plan.SelectedHPAs) == 0 && len(plan.SelectedWorkloads) == 0 {
+		c.handleExecEventError(db, e, "no hpa or workload exist")
+		return
+	}
+
+	var existingModifiedHPAs []*UCEntity.EventModifiedHPAConfigData
+	for _, hpaPlan := range plan.SelectedHPAs {
+		modifiedHPA := hpaPlan.ModifiedHPAConfig
+		if modifiedHPA.OriginalMaxReplicas == nil {
+			currentMaxReplicas := hpaPlan.CurrentMaxReplicas
+			modifiedHPA.OriginalMinReplicas = hpaPlan.CurrentMinReplicas
+			modifiedHPA.OriginalMaxReplicas = &currentMaxReplicas
+			modifiedHPA.OriginalBehavior = hpaPlan.CurrentBehavior
+			modifiedHPA.OriginalMetrics = hpaPlan.CurrentMetrics
+		}
+		existingModifiedHPAs = append(existingModifiedHPAs, modifiedHPA)
+	}
+
+	// Reuse node pools registered by a previous failed attempt
+	existingUpdatedNodePools, err := c.updatedNodePoolUC.GetAllUpdatedNodePoolByEvent(db, e.ID)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+	existingUpdatedNodePoolMap := map[string]*UCEntity.UpdatedNodePoolData{}
+	for _, existingUpdatedNodePool := range existingUpdatedNodePools {
+		existingUpdatedNodePoolMap[existingUpdatedNodePool.NodePoolName] = existingUpdatedNodePool
+	}
+
+	var newUpdatedNodePools []*model.UpdatedNodePool
+	for _, nodePoolPlan := range plan.NodePools {
+		if _, ok := existingUpdatedNodePoolMap[nodePoolPlan.NodePoolName]; ok {
+			continue
+		}
+		updatedNodePool := &model.UpdatedNodePool{
+			NodePoolName:               nodePoolPlan.NodePoolName,
+			DistributionStrategy:       e.NodePoolDistribution,
+			MaxNode:                    nodePoolPlan.CurrentMaxNode,
+			OriginalMinNode:            nodePoolPlan.Capacity.MinNode,
+			OriginalMaxNode:            nodePoolPlan.Capacity.MaxNode,
+			OriginalAutoscalingEnabled: nodePoolPlan.Capacity.AutoscalingEnabled,
+		}
+		// Without a capacity backend the node pools are recorded for watching only
+		if plan.RecommendationOnly && e.CalculateNodePool {
+			updatedNodePool.MaxNode = nodePoolPlan.NewMaxNode
+			if nodePoolPlan.NeededNode > 0 {
+				log.Infof(
+					"[EventCronJob] Event : %s, Node pool %s needs %d more node(s) (recommendation only)",
+					e.Name,
+					nodePoolPlan.NodePoolName,
+					nodePoolPlan.NeededNode,
+				)
+			}
+		}
+		updatedNodePool.EventID.SetUUID(e.ID)
+
+		newUpdatedNodePools = append(newUpdatedNodePools, updatedNodePool)
+	}
+
+	if len(newUpdatedNodePools) != 0 {
+		if err := db.Create(&newUpdatedNodePools).Error; err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+	}
+
+	for _, newUpdatedNodePool := range newUpdatedNodePools {
+		existingUpdatedNodePoolMap[newUpdatedNodePool.NodePoolName] = &UCEntity.UpdatedNodePoolData{
+			ID:           newUpdatedNodePool.ID.GetUUID(),
+			NodePoolName: newUpdatedNodePool.NodePoolName,
+			MaxNode:      newUpdatedNodePool.MaxNode,
+		}
+	}
+
+	if e.CalculateNodePool && !plan.RecommendationOnly {
+		capacityBackend, err := c.kubeconfigClusterUC.GetCapacityBackend(clusterData)
+		if err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+
+		// Update the Node Pools through the capacity backend
+		log.Infof("[EventCronJob] Event : %s, Updating node pools based on event plan", e.Name)
+		errGroup, ctxEg := errgroup.WithContext(ctx)
+		var updateNodePoolLock sync.Mutex
+		for _, nodePoolPlan := range plan.NodePools {
+			if !nodePoolPlan.Capacity.AutoscalingEnabled {
+				log.Infof(
+					"[EventCronJob] Event : %s, Node pool %s is not managed by the capacity backend, skipping",
+					e.Name,
+					nodePoolPlan.NodePoolName,
+				)
+				continue
+			}
+			updatedNodePool := existingUpdatedNodePoolMap[nodePoolPlan.NodePoolName]
+			newMaxNode := nodePoolPlan.NewMaxNode
+			if updatedNodePool.AutoscalingModified {
+				// Keep the max node size decided by the previous attempt
+				newMaxNode = updatedNodePool.MaxNode
+				if nodePoolPlan.CurrentMaxNode == newMaxNode {
+					log.Infof(
+						"[EventCronJob] Event : %s, Node pool %s already updated with max node size %d, skipping",
+						e.Name,
+						nodePoolPlan.NodePoolName,
+						newMaxNode,
+					)
+					continue
+				}
+			}
+			errGroup.Go(
+				func(
+					nodePoolPlan *UCEntity.KubeconfigNodePoolPlan,
+					updatedNodePoolID uuid.UUID,
+					newMaxNode int32,
+				) func() error {
+					return func() error {
+						updateNodePoolLock.Lock()
+						defer updateNodePoolLock.Unlock()
+						err := c.updatedNodePoolUC.UpdateUpdatedNodePoolMaxNode(
+							db,
+							updatedNodePoolID,
+							newMaxNode,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							return err
+						}
+
+						log.Infof(
+							"[EventCronJob] Event : %s, Updating node pool %s with new max node size %d (before : %d)",
+							e.Name,
+							nodePoolPlan.NodePoolName,
+							newMaxNode,
+							nodePoolPlan.CurrentMaxNode,
+						)
+
+						err = capacityBackend.UpdateNodePoolMaxNode(
+							ctx,
+							kubernetesClient,
+							nodePoolPlan.NodePoolName,
+							nodePoolPlan.CurrentMaxNode,
+							newMaxNode,
+						)
+						if err != nil && ctxEg.Err() != nil {
+							return nil
+						}
+						return err
+					}
+				}(nodePoolPlan, updatedNodePool.ID, newMaxNode),
+			)
+		}
+
+		if err := errGroup.Wait(); err != nil {
+			c.handleExecEventError(db, e, err.Error())
+			return
+		}
+	}
+
+	// Snapshot Original HPA Spec
+	log.Infof("[EventCronJob] Event : %s, Saving original HPA configuration", e.Name)
+	for _, existingModifiedHPA := range existingModifiedHPAs {
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigOriginalSpec(
+			db,
+			existingModifiedHPA.ID,
+			existingModifiedHPA.OriginalMinReplicas,
+			*existingModifiedHPA.OriginalMaxReplicas,
+			existingModifiedHPA.OriginalBehavior,
+			existingModifiedHPA.OriginalMetrics,
+		)
+		if err != nil {
+			c.handleExecEventError(
+				db, e, fmt.Sprintf(
+					"Error Update HPA %s Namespace %s : %s", existingModifiedHPA.Name,
+					existingModifiedHPA.Namespace,
+					err.Error(),
+				),
+			)
+			return
+		}
+	}
+
+	// Update K8s HPA
+	log.Infof("[EventCronJob] Event : %s, Updating K8s HPA with new configuration", e.Name)
+	conflicts, err := c.clusterUC.UpdateHPAK8sObjectBatch(
+		ctx,
+		kubernetesClient,
+		clusterID,
+		plan.SelectedHPAs,
+		e.ForceHPAApply,
+	)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	for _, existingModifiedHPA := range existingModifiedHPAs {
+		// HPAs owned by another field manager are left untouched and skipped on restore
+		status := model.HPAUpdateSuccess
+		message := ""
+		if conflictErr, ok := conflicts[fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			util.GetHPAObjectName(existingModifiedHPA.Kind, existingModifiedHPA.Name),
+			existingModifiedHPA.Namespace,
+		)]; ok {
+			status = model.HPAUpdateFailed
+			message = conflictErr.Error()
+		}
+		err := c.scheduledHPAConfigUC.UpdateScheduledHPAConfigStatusMessage(
+			db,
+			existingModifiedHPA.ID,
+			status,
+			message,
+		)
+		if err != nil {
+			c.handleExecEventError(
+				db, e, fmt.Sprintf(
+					"Error Update HPA %s Namespace %s : %s", existingModifiedHPA.Name,
+					existingModifiedHPA.Namespace,
+					err.Error(),
+				),
+			)
+			return
+		}
+	}
+
+	// Scale Workloads
+	log.Infof("[EventCronJob] Event : %s, Scaling workloads with new replicas", e.Name)
+	err = c.scaleWorkloads(kubernetesClient, db, e, &plan.EventPlan, ctx)
+	if err != nil {
+		c.handleExecEventError(db, e, err.Error())
+		return
+	}
+
+	e.Status = model.EventPrescaled
+
+	err = c.eventUC.UpdateLeasedEventStatus(db, e, c.owner)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+	}
+
+	log.Infof("[EventCronJob] Event : %s, Done executing update and calculation", e.Name)
+}
+
+func (c *cron) restoreKubeconfigEvent(
+	e *UCEntity.Event,
+	db *gorm.DB,
+	ctx context.Context,
+	restoredStatus model.EventStatus,
+) {
+	log.Infof("[EventCronJob] Restoring event %s", e.Name)
+	db, ctx, releaseLease := c.holdEventLease(db, e, ctx)
+	defer releaseLease()
+
+	clusterID := e.Cluster.ID
+	clusterData, err := c.clusterUC.GetClusterAndDatacenterDataByClusterID(db, clusterID)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Get Clients
+	kubernetesClient, _, err := c.getAllKubeconfigClient(clusterData)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	err = c.clusterUC.RefreshLatestHPAAPIVersion(db, kubernetesClient, clusterData)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Restore K8s HPA
+	log.Infof("[EventCronJob] Event : %s, Restoring K8s HPA original configuration", e.Name)
+	failedHPAs, err := c.restoreHPA(kubernetesClient, db, e, clusterData, ctx)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Restore Workloads
+	log.Infof("[EventCronJob] Event : %s, Restoring workload original replicas", e.Name)
+	failedWorkloads, err := c.restoreWorkloads(kubernetesClient, db, e, ctx)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	// Restore Node Pools
+	log.Infof("[EventCronJob] Event : %s, Restoring node pool original capacity", e.Name)
+	failedNodePools, err := c.restoreKubeconfigNodePool(kubernetesClient, db, e, clusterData, ctx)
+	if err != nil {
+		c.handleRestoreEventError(db, e, err.Error())
+		return
+	}
+
+	var errMessages []string
+	if len(failedHPAs) != 0 {
+		errMessages = append(
+			errMessages,
+			fmt.Sprintf("failed to restore hpa : %s", strings.Join(failedHPAs, ", ")),
+		)
+	}
+	if len(failedWorkloads) != 0 {
+		errMessages = append(
+			errMessages,
+			fmt.Sprintf("failed to restore workload : %s", strings.Join(failedWorkloads, ", ")),
+		)
+	}
+	if len(failedNodePools) != 0 {
+		errMessages = append(
+			errMessages,
+			fmt.Sprintf("failed to restore node pool : %s", strings.Join(failedNodePools, ", ")),
+		)
+	}
+	if len(errMessages) != 0 {
+		c.handleRestoreEventError(db, e, strings.Join(errMessages, "\n"))
+		return
+	}
+
+	e.Status = restoredStatus
+
+	err = c.eventUC.UpdateLeasedEventStatus(db, e, c.owner)
+	if err != nil {
+		log.Errorf("[EventCronJob] Error Update Event : %s", err.Error())
+	}
+
+	log.Infof("[EventCronJob] Event : %s, Done restoring original configuration", e.Name)
+}
+
+func (c *cron) restoreKubeconfigNodePool(
+	kubernetesClient kubernetes.Interface,
+	db *gorm.DB,
+	event *UCEntity.Event,
+	clusterData *UCEntity.ClusterData,
+	ctx context.Context,
+) ([]string, error) {
+	updatedNodePools, err := c.updatedNodePoolUC.GetAllUpdatedNodePoolByEvent(db, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	capacityBackend, err := c.kubeconfigClusterUC.GetCapacityBackend(clusterData)
+	if err != nil {
+		return nil, err
+	}
+
+	var failedNodePools []string
+	for _, updatedNodePool := range updatedNodePools {
+		if updatedNodePool.RestoreStatus == model.NodePoolUpdateSuccess ||
+			updatedNodePool.RestoreStatus == model.NodePoolUpdateSkipped {
+			continue
+		}
+
+		restoreStatus := model.NodePoolUpdateSuccess
+		restoreMessage := ""
+		if updatedNodePool.AutoscalingModified && capacityBackend != nil {
+			log.Infof(
+				"[EventCronJob] Restoring event : %s, Updating node pool %s with original max node size %d (before : %d)",
+				event.Name,
+				updatedNodePool.NodePoolName,
+				updatedNodePool.OriginalMaxNode,
+				updatedNodePool.MaxNode,
+			)
+			err := capacityBackend.RestoreNodePool(ctx, kubernetesClient, updatedNodePool)
+			if err != nil {
+				restoreStatus = model.NodePoolUpdateFailed
+				restoreMessage = err.Error()
+				failedNodePools = append(failedNodePools, updatedNodePool.NodePoolName)
+				log.Errorf(
+					"[EventCronJob] Restoring event : %s, Node pool %s, Error : %s",
+					event.Name,
+					updatedNodePool.NodePoolName,
+					restoreMessage,
+				)
+			}
+		} else if capacityBackend == nil {
+			restoreStatus = model.NodePoolUpdateSkipped
+			restoreMessage = "node pool is recommendation only"
+		} else {
+			restoreStatus = model.NodePoolUpdateSkipped
+			restoreMessage = "node pool was not modified"
+		}
+
+		err := c.updatedNodePoolUC.UpdateUpdatedNodePoolRestoreStatusMessage(
+			db,
+			updatedNodePool.ID,
+			restoreStatus,
+			restoreMessage,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return failedNodePools, nil
+}
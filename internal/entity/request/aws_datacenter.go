@@ -0,0 +1,16 @@
+package request
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+)
+
+type AWSDatacenterData struct {
+	Name           *string          `json:"name" validate:"required"`
+	AWSCredentials *json.RawMessage `json:"aws_credentials" validate:"required"`
+	IsTemporary    *bool            `json:"is_temporary" validate:"required"`
+}
+
+type AWSExistingDatacenterData struct {
+	DatacenterID *uuid.UUID `json:"datacenter_id" query:"datacenter_id" validate:"required"`
+}
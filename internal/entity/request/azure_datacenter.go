@@ -0,0 +1,16 @@
+package request
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+)
+
+type AzureDatacenterData struct {
+	Name                        *string          `json:"name" validate:"required"`
+	ServicePrincipalCredentials *json.RawMessage `json:"service_principal_credentials" validate:"required"`
+	IsTemporary                 *bool            `json:"is_temporary" validate:"required"`
+}
+
+type AzureExistingDatacenterData struct {
+	DatacenterID *uuid.UUID `json:"datacenter_id" query:"datacenter_id" validate:"required"`
+}
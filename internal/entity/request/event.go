@@ -6,14 +6,19 @@ import (
 )
 
 type EventDataRequest struct {
-	Name               *string                      `json:"name" validate:"required"`
-	StartTime          *time.Time                   `json:"start_time" validate:"required,gtefield=ExecuteConfigAt"`
-	EndTime            *time.Time                   `json:"end_time" validate:"required,gtefield=StartTime"`
-	ClusterID          *uuid.UUID                   `json:"cluster_id" validate:"required"`
-	CalculateNodePool  *bool                        `json:"calculate_node_pool"`
-	ExecuteConfigAt    *time.Time                   `json:"execute_config_at" validate:"required"`
-	WatchingAt         *time.Time                   `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
-	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs" validate:"required,min=1,dive"`
+	Name                    *string                           `json:"name" validate:"required"`
+	StartTime               *time.Time                        `json:"start_time" validate:"required,gtefield=ExecuteConfigAt"`
+	EndTime                 *time.Time                        `json:"end_time" validate:"required,gtefield=StartTime"`
+	ClusterID               *uuid.UUID                        `json:"cluster_id" validate:"required"`
+	CalculateNodePool       *bool                             `json:"calculate_node_pool"`
+	ForceHPAApply           *bool                             `json:"force_hpa_apply"`
+	NodePoolDistribution    *string                           `json:"node_pool_distribution" validate:"omitempty,oneof=PROPORTIONAL PREFERRED_AFFINITY PRIMARY_POOL"`
+	PrimaryNodePool         *string                           `json:"primary_node_pool"`
+	ExecuteConfigAt         *time.Time                        `json:"execute_config_at" validate:"required"`
+	WatchingAt              *time.Time                        `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
+	ModifiedHPAConfigs      []EventModifiedHPAConfigData      `json:"modified_hpa_configs" validate:"required_without=ModifiedWorkloadConfigs,dive"`
+	ModifiedWorkloadConfigs []EventModifiedWorkloadConfigData `json:"modified_workload_configs" validate:"required_without=ModifiedHPAConfigs,dive"`
+	MergeConflicts          *bool                             `json:"merge_conflicts"`
 }
 
 type EventListRequest struct {
@@ -21,16 +26,31 @@ type EventListRequest struct {
 }
 
 type UpdateEventDataRequest struct {
-	Name               *string                      `json:"name" validate:"required"`
-	StartTime          *time.Time                   `json:"start_time" validate:"required"`
-	EndTime            *time.Time                   `json:"end_time" validate:"required,gtefield=StartTime"`
-	ModifiedHPAConfigs []EventModifiedHPAConfigData `json:"modified_hpa_configs" validate:"required,min=1,dive"`
-	CalculateNodePool  *bool                        `json:"calculate_node_pool"`
-	ExecuteConfigAt    *time.Time                   `json:"execute_config_at" validate:"required,gtefield=ExecuteConfigAt"`
-	WatchingAt         *time.Time                   `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
-	EventID            *uuid.UUID                   `json:"event_id" validator:"required"`
+	Name                    *string                           `json:"name" validate:"required"`
+	StartTime               *time.Time                        `json:"start_time" validate:"required"`
+	EndTime                 *time.Time                        `json:"end_time" validate:"required,gtefield=StartTime"`
+	ModifiedHPAConfigs      []EventModifiedHPAConfigData      `json:"modified_hpa_configs" validate:"required_without=ModifiedWorkloadConfigs,dive"`
+	ModifiedWorkloadConfigs []EventModifiedWorkloadConfigData `json:"modified_workload_configs" validate:"required_without=ModifiedHPAConfigs,dive"`
+	CalculateNodePool       *bool                             `json:"calculate_node_pool"`
+	ForceHPAApply           *bool                             `json:"force_hpa_apply"`
+	NodePoolDistribution    *string                           `json:"node_pool_distribution" validate:"omitempty,oneof=PROPORTIONAL PREFERRED_AFFINITY PRIMARY_POOL"`
+	PrimaryNodePool         *string                           `json:"primary_node_pool"`
+	ExecuteConfigAt         *time.Time                        `json:"execute_config_at" validate:"required,gtefield=ExecuteConfigAt"`
+	WatchingAt              *time.Time                        `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
+	EventID                 *uuid.UUID                        `json:"event_id" validator:"required"`
+	MergeConflicts          *bool                             `json:"merge_conflicts"`
 }
 
 type EventDetailRequest struct {
 	EventID *uuid.UUID `json:"event_id" query:"event_id" validator:"required"`
 }
+
+// EventScheduleRequest creates an event from the configs of an existing event or template
+type EventScheduleRequest struct {
+	Name            *string    `json:"name" validate:"required"`
+	StartTime       *time.Time `json:"start_time" validate:"required,gtefield=ExecuteConfigAt"`
+	EndTime         *time.Time `json:"end_time" validate:"required,gtefield=StartTime"`
+	ExecuteConfigAt *time.Time `json:"execute_config_at" validate:"required"`
+	WatchingAt      *time.Time `json:"watching_at" validate:"required,gtefield=ExecuteConfigAt,ltefield=StartTime"`
+	MergeConflicts  *bool      `json:"merge_conflicts"`
+}
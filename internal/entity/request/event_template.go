@@ -0,0 +1,18 @@
+package request
+
+import (
+	"github.com/google/uuid"
+)
+
+type EventTemplateDataRequest struct {
+	Name                    *string                           `json:"name" validate:"required"`
+	ClusterID               *uuid.UUID                        `json:"cluster_id" validate:"required"`
+	CalculateNodePool       *bool                             `json:"calculate_node_pool"`
+	ForceHPAApply           *bool                             `json:"force_hpa_apply"`
+	ModifiedHPAConfigs      []EventModifiedHPAConfigData      `json:"modified_hpa_configs" validate:"required_without=ModifiedWorkloadConfigs,dive"`
+	ModifiedWorkloadConfigs []EventModifiedWorkloadConfigData `json:"modified_workload_configs" validate:"required_without=ModifiedHPAConfigs,dive"`
+}
+
+type EventTemplateListRequest struct {
+	ClusterID *uuid.UUID `query:"cluster_id" validate:"required"`
+}
@@ -1,8 +1,29 @@
 package request
 
+import (
+	v2 "k8s.io/api/autoscaling/v2"
+	"time"
+)
+
+type EventHPAMetricTargetData struct {
+	ResourceName       *string `json:"resource_name" validate:"required"`
+	AverageUtilization *int32  `json:"average_utilization" validate:"required,min=1"`
+}
+
 type EventModifiedHPAConfigData struct {
-	Name        *string `json:"name" validate:"required"`
-	Namespace   *string `json:"namespace" validate:"required"`
-	MinReplicas *int32  `json:"min_replicas" validate:"required"`
-	MaxReplicas *int32  `json:"max_replicas" validate:"required"`
+	Kind          *string                             `json:"kind" validate:"omitempty,oneof=HorizontalPodAutoscaler ScaledObject"`
+	Name          *string                             `json:"name" validate:"required"`
+	Namespace     *string                             `json:"namespace" validate:"required"`
+	MinReplicas   *int32                              `json:"min_replicas" validate:"required"`
+	MaxReplicas   *int32                              `json:"max_replicas" validate:"required"`
+	Behavior      *v2.HorizontalPodAutoscalerBehavior `json:"behavior"`
+	MetricTargets []EventHPAMetricTargetData          `json:"metric_targets" validate:"dive"`
+	RampUpSteps   []EventHPAStepData                  `json:"ramp_up_steps" validate:"dive"`
+	RampDownSteps []EventHPAStepData                  `json:"ramp_down_steps" validate:"dive"`
+}
+
+type EventHPAStepData struct {
+	ExecuteAt   *time.Time `json:"execute_at" validate:"required"`
+	MinReplicas *int32     `json:"min_replicas" validate:"required"`
+	MaxReplicas *int32     `json:"max_replicas" validate:"required,min=1"`
 }
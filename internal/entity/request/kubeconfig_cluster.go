@@ -0,0 +1,14 @@
+package request
+
+import "github.com/google/uuid"
+
+type KubeconfigRegisterClusterData struct {
+	ClustersName          []string          `json:"clusters_name" validate:"required"`
+	DatacenterID          *uuid.UUID        `json:"datacenter_id" validate:"required"`
+	IsDatacenterTemporary *bool             `json:"is_datacenter_temporary" validate:"required"`
+	CapacityBackends      map[string]string `json:"capacity_backends"`
+}
+
+type KubeconfigCapacityBackendData struct {
+	CapacityBackend *string `json:"capacity_backend" validate:"required"`
+}
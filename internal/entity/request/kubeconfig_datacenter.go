@@ -0,0 +1,16 @@
+package request
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+)
+
+type KubeconfigDatacenterData struct {
+	Name                  *string          `json:"name" validate:"required"`
+	KubeconfigCredentials *json.RawMessage `json:"kubeconfig_credentials" validate:"required"`
+	IsTemporary           *bool            `json:"is_temporary" validate:"required"`
+}
+
+type KubeconfigExistingDatacenterData struct {
+	DatacenterID *uuid.UUID `json:"datacenter_id" query:"datacenter_id" validate:"required"`
+}
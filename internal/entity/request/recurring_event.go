@@ -0,0 +1,39 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type RecurringEventDataRequest struct {
+	Name                       *string                           `json:"name" validate:"required"`
+	ClusterID                  *uuid.UUID                        `json:"cluster_id" validate:"required"`
+	CronExpression             *string                           `json:"cron_expression" validate:"required"`
+	Timezone                   *string                           `json:"timezone"`
+	DurationMinutes            *int32                            `json:"duration_minutes" validate:"required,min=1"`
+	ExecuteConfigOffsetMinutes *int32                            `json:"execute_config_offset_minutes" validate:"required,min=0"`
+	WatchingOffsetMinutes      *int32                            `json:"watching_offset_minutes" validate:"required,min=0"`
+	CalculateNodePool          *bool                             `json:"calculate_node_pool"`
+	ForceHPAApply              *bool                             `json:"force_hpa_apply"`
+	NodePoolDistribution       *string                           `json:"node_pool_distribution" validate:"omitempty,oneof=PROPORTIONAL PREFERRED_AFFINITY PRIMARY_POOL"`
+	PrimaryNodePool            *string                           `json:"primary_node_pool"`
+	MergeConflicts             *bool                             `json:"merge_conflicts"`
+	ModifiedHPAConfigs         []EventModifiedHPAConfigData      `json:"modified_hpa_configs" validate:"required_without=ModifiedWorkloadConfigs,dive"`
+	ModifiedWorkloadConfigs    []EventModifiedWorkloadConfigData `json:"modified_workload_configs" validate:"required_without=ModifiedHPAConfigs,dive"`
+}
+
+type RecurringEventListRequest struct {
+	ClusterID *uuid.UUID `query:"cluster_id" validate:"required"`
+}
+
+type RecurringEventOccurrenceListRequest struct {
+	Count *int `query:"count" validate:"omitempty,min=1,max=100"`
+}
+
+// RecurringEventOccurrenceRequest configs replace the recurring event configs when any of them is set
+type RecurringEventOccurrenceRequest struct {
+	StartTime               *time.Time                        `json:"start_time" validate:"required"`
+	Skipped                 *bool                             `json:"skipped"`
+	ModifiedHPAConfigs      []EventModifiedHPAConfigData      `json:"modified_hpa_configs" validate:"dive"`
+	ModifiedWorkloadConfigs []EventModifiedWorkloadConfigData `json:"modified_workload_configs" validate:"dive"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type EventModifiedWorkloadConfigData struct {
+	Kind      *string `json:"kind" validate:"required,oneof=Deployment StatefulSet"`
+	Name      *string `json:"name" validate:"required"`
+	Namespace *string `json:"namespace" validate:"required"`
+	Replicas  *int32  `json:"replicas" validate:"required,min=0"`
+}
@@ -0,0 +1,11 @@
+package response
+
+type AWSCluster struct {
+	Cluster
+	Region string `json:"region"`
+}
+
+type AWSDatacenterClusters struct {
+	Clusters              []AWSCluster `json:"clusters"`
+	IsTemporaryDatacenter bool         `json:"is_temporary_datacenter"`
+}
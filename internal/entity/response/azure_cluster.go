@@ -0,0 +1,12 @@
+package response
+
+type AzureCluster struct {
+	Cluster
+	ResourceGroup string `json:"resource_group"`
+	Location      string `json:"location"`
+}
+
+type AzureDatacenterClusters struct {
+	Clusters              []AzureCluster `json:"clusters"`
+	IsTemporaryDatacenter bool           `json:"is_temporary_datacenter"`
+}
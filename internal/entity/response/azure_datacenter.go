@@ -0,0 +1,8 @@
+package response
+
+import "github.com/google/uuid"
+
+type AzureDatacenterData struct {
+	DatacenterID uuid.UUID `json:"datacenter_id"`
+	IsTemporary  bool      `json:"is_temporary"`
+}
@@ -13,9 +13,14 @@ type Cluster struct {
 }
 
 type UpdatedNodePool struct {
-	ID           uuid.UUID `json:"id"`
-	NodePoolName string    `json:"node_pool_name"`
-	MaxNode      int32     `json:"max_node"`
+	ID                   uuid.UUID                  `json:"id"`
+	NodePoolName         string                     `json:"node_pool_name"`
+	MaxNode              int32                      `json:"max_node"`
+	OriginalMinNode      int32                      `json:"original_min_node"`
+	OriginalMaxNode      int32                      `json:"original_max_node"`
+	DistributionStrategy model.NodePoolDistribution `json:"distribution_strategy"`
+	RestoreStatus        model.NodePoolUpdateStatus `json:"restore_status"`
+	RestoreMessage       string                     `json:"restore_message"`
 }
 
 type ClusterDetailResponse struct {
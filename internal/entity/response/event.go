@@ -1,6 +1,7 @@
 package response
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"time"
@@ -10,6 +11,28 @@ type EventCreationResponse struct {
 	EventID uuid.UUID `json:"event_id"`
 }
 
+type EventConflict struct {
+	EventID         uuid.UUID         `json:"event_id"`
+	Name            string            `json:"name"`
+	Status          model.EventStatus `json:"status"`
+	ExecuteConfigAt time.Time         `json:"execute_config_at"`
+	StartTime       time.Time         `json:"start_time"`
+	EndTime         time.Time         `json:"end_time"`
+	HPAs            []SimpleHPA       `json:"hpas"`
+	SharedNodePools []string          `json:"shared_node_pools"`
+}
+
+type EventConflictResponse struct {
+	Message             string          `json:"message"`
+	ConflictingEventIDs []uuid.UUID     `json:"conflicting_event_ids"`
+	Conflicts           []EventConflict `json:"conflicts"`
+}
+
+type EventInstantiationResponse struct {
+	EventID     uuid.UUID   `json:"event_id"`
+	MissingHPAs []SimpleHPA `json:"missing_hpas"`
+}
+
 type EventSimpleResponse struct {
 	ID        uuid.UUID         `json:"id"`
 	Name      string            `json:"name"`
@@ -20,12 +43,65 @@ type EventSimpleResponse struct {
 
 type EventDetailedResponse struct {
 	EventSimpleResponse
-	CreatedAt          time.Time           `json:"created_at"`
-	UpdatedAt          time.Time           `json:"updated_at"`
-	CalculateNodePool  bool                `json:"calculate_node_pool"`
-	ExecuteConfigAt    time.Time           `json:"execute_config_at"`
-	WatchingAt         time.Time           `json:"watching_at"`
-	Cluster            Cluster             `json:"cluster"`
-	ModifiedHPAConfigs []ModifiedHPAConfig `json:"modified_hpa_configs"`
-	UpdatedNodePools   []UpdatedNodePool   `json:"updated_node_pools"`
+	CreatedAt               time.Time                  `json:"created_at"`
+	UpdatedAt               time.Time                  `json:"updated_at"`
+	CalculateNodePool       bool                       `json:"calculate_node_pool"`
+	ForceHPAApply           bool                       `json:"force_hpa_apply"`
+	NodePoolDistribution    model.NodePoolDistribution `json:"node_pool_distribution"`
+	PrimaryNodePool         string                     `json:"primary_node_pool,omitempty"`
+	ExecuteConfigAt         time.Time                  `json:"execute_config_at"`
+	WatchingAt              time.Time                  `json:"watching_at"`
+	Cluster                 Cluster                    `json:"cluster"`
+	ModifiedHPAConfigs      []ModifiedHPAConfig        `json:"modified_hpa_configs"`
+	ModifiedWorkloadConfigs []ModifiedWorkloadConfig   `json:"modified_workload_configs"`
+	UpdatedNodePools        []UpdatedNodePool          `json:"updated_node_pools"`
+}
+
+type HPAPlan struct {
+	Kind               string            `json:"kind"`
+	Name               string            `json:"name"`
+	Namespace          string            `json:"namespace"`
+	CurrentMinReplicas *int32            `json:"current_min_replicas,omitempty"`
+	CurrentMaxReplicas int32             `json:"current_max_replicas"`
+	NewMinReplicas     *int32            `json:"new_min_replicas,omitempty"`
+	NewMaxReplicas     int32             `json:"new_max_replicas"`
+	TargetMinReplicas  *int32            `json:"target_min_replicas,omitempty"`
+	TargetMaxReplicas  int32             `json:"target_max_replicas"`
+	CurrentBehavior    json.RawMessage   `json:"current_behavior,omitempty"`
+	CurrentMetrics     json.RawMessage   `json:"current_metrics,omitempty"`
+	NewBehavior        json.RawMessage   `json:"new_behavior,omitempty"`
+	NewMetricTargets   []HPAMetricTarget `json:"new_metric_targets,omitempty"`
+	RampUpSteps        []HPAStep         `json:"ramp_up_steps,omitempty"`
+	NodePools          []string          `json:"node_pools"`
+}
+
+type NodePoolPlan struct {
+	NodePoolName       string           `json:"node_pool_name"`
+	RequestedCPU       float64          `json:"requested_cpu"`
+	RequestedMemory    float64          `json:"requested_memory"`
+	RequestedPods      int64            `json:"requested_pods"`
+	MaxAvailableCPU    float64          `json:"max_available_cpu"`
+	MaxAvailableMemory float64          `json:"max_available_memory"`
+	MaxAvailablePods   int64            `json:"max_available_pods"`
+	CurrentNodeCount   int              `json:"current_node_count"`
+	Zones              []string         `json:"zones,omitempty"`
+	PerZoneMaxNode     bool             `json:"per_zone_max_node"`
+	CurrentMaxNode     int32            `json:"current_max_node"`
+	ZoneNodeCounts     map[string]int32 `json:"zone_node_counts,omitempty"`
+	PackedNodeCount    int32            `json:"packed_node_count"`
+	PackingEfficiency  float64          `json:"packing_efficiency"`
+	UnschedulablePods  int64            `json:"unschedulable_pods"`
+	NeededNode         int32            `json:"needed_node"`
+	NewMaxNode         int32            `json:"new_max_node"`
+}
+
+type EventPlanResponse struct {
+	EventSimpleResponse
+	CalculateNodePool  bool             `json:"calculate_node_pool"`
+	RecommendationOnly bool             `json:"recommendation_only"`
+	HPAs               []HPAPlan        `json:"hpas"`
+	MissingHPAs        []SimpleHPA      `json:"missing_hpas"`
+	Workloads          []WorkloadPlan   `json:"workloads"`
+	MissingWorkloads   []SimpleWorkload `json:"missing_workloads"`
+	NodePools          []NodePoolPlan   `json:"node_pools"`
 }
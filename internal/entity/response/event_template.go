@@ -0,0 +1,22 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type EventTemplateCreationResponse struct {
+	EventTemplateID uuid.UUID `json:"event_template_id"`
+}
+
+type EventTemplate struct {
+	ID                      uuid.UUID                `json:"id"`
+	Name                    string                   `json:"name"`
+	ClusterID               uuid.UUID                `json:"cluster_id"`
+	CalculateNodePool       bool                     `json:"calculate_node_pool"`
+	ForceHPAApply           bool                     `json:"force_hpa_apply"`
+	CreatedAt               time.Time                `json:"created_at"`
+	UpdatedAt               time.Time                `json:"updated_at"`
+	ModifiedHPAConfigs      []ModifiedHPAConfig      `json:"modified_hpa_configs"`
+	ModifiedWorkloadConfigs []ModifiedWorkloadConfig `json:"modified_workload_configs"`
+}
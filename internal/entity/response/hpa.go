@@ -1,8 +1,14 @@
 package response
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
 
 type SimpleHPA struct {
+	Kind            string `json:"kind"`
 	Name            string `json:"name"`
 	Namespace       string `json:"namespace"`
 	MinReplicas     *int32 `json:"min_replicas,omitempty"`
@@ -10,10 +16,35 @@ type SimpleHPA struct {
 	CurrentReplicas int32  `json:"current_replicas"`
 }
 
+type HPAMetricTarget struct {
+	ResourceName       string `json:"resource_name"`
+	AverageUtilization int32  `json:"average_utilization"`
+}
+
 type ModifiedHPAConfig struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Namespace   string    `json:"namespace"`
-	MinReplicas *int32    `json:"min_replicas,omitempty"`
-	MaxReplicas int32     `json:"max_replicas"`
+	ID                  uuid.UUID             `json:"id"`
+	Kind                string                `json:"kind"`
+	Name                string                `json:"name"`
+	Namespace           string                `json:"namespace"`
+	MinReplicas         *int32                `json:"min_replicas,omitempty"`
+	MaxReplicas         int32                 `json:"max_replicas"`
+	Status              model.HPAUpdateStatus `json:"status"`
+	Message             string                `json:"message"`
+	OriginalMinReplicas *int32                `json:"original_min_replicas,omitempty"`
+	OriginalMaxReplicas *int32                `json:"original_max_replicas,omitempty"`
+	Behavior            json.RawMessage       `json:"behavior,omitempty"`
+	MetricTargets       []HPAMetricTarget     `json:"metric_targets,omitempty"`
+	RestoreStatus       model.HPAUpdateStatus `json:"restore_status"`
+	RestoreMessage      string                `json:"restore_message"`
+	RampUpSteps         []HPAStep             `json:"ramp_up_steps,omitempty"`
+	RampDownSteps       []HPAStep             `json:"ramp_down_steps,omitempty"`
+}
+
+type HPAStep struct {
+	ID          uuid.UUID             `json:"id"`
+	ExecuteAt   time.Time             `json:"execute_at"`
+	MinReplicas *int32                `json:"min_replicas,omitempty"`
+	MaxReplicas int32                 `json:"max_replicas"`
+	Status      model.HPAUpdateStatus `json:"status"`
+	Message     string                `json:"message"`
 }
@@ -0,0 +1,12 @@
+package response
+
+type KubeconfigCluster struct {
+	Cluster
+	Context         string `json:"context,omitempty"`
+	CapacityBackend string `json:"capacity_backend,omitempty"`
+}
+
+type KubeconfigDatacenterClusters struct {
+	Clusters              []KubeconfigCluster `json:"clusters"`
+	IsTemporaryDatacenter bool                `json:"is_temporary_datacenter"`
+}
@@ -0,0 +1,8 @@
+package response
+
+import "github.com/google/uuid"
+
+type KubeconfigDatacenterData struct {
+	DatacenterID uuid.UUID `json:"datacenter_id"`
+	IsTemporary  bool      `json:"is_temporary"`
+}
@@ -0,0 +1,41 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+type RecurringEventCreationResponse struct {
+	RecurringEventID uuid.UUID `json:"recurring_event_id"`
+}
+
+type RecurringEvent struct {
+	ID                         uuid.UUID                  `json:"id"`
+	Name                       string                     `json:"name"`
+	CronExpression             string                     `json:"cron_expression"`
+	Timezone                   string                     `json:"timezone"`
+	DurationMinutes            int32                      `json:"duration_minutes"`
+	ExecuteConfigOffsetMinutes int32                      `json:"execute_config_offset_minutes"`
+	WatchingOffsetMinutes      int32                      `json:"watching_offset_minutes"`
+	CalculateNodePool          bool                       `json:"calculate_node_pool"`
+	ForceHPAApply              bool                       `json:"force_hpa_apply"`
+	NodePoolDistribution       model.NodePoolDistribution `json:"node_pool_distribution"`
+	PrimaryNodePool            string                     `json:"primary_node_pool,omitempty"`
+	MergeConflicts             bool                       `json:"merge_conflicts"`
+	CreatedAt                  time.Time                  `json:"created_at"`
+	UpdatedAt                  time.Time                  `json:"updated_at"`
+	ModifiedHPAConfigs         []ModifiedHPAConfig        `json:"modified_hpa_configs"`
+	ModifiedWorkloadConfigs    []ModifiedWorkloadConfig   `json:"modified_workload_configs"`
+}
+
+type RecurringEventOccurrence struct {
+	StartTime               time.Time                `json:"start_time"`
+	EndTime                 time.Time                `json:"end_time"`
+	ExecuteConfigAt         time.Time                `json:"execute_config_at"`
+	WatchingAt              time.Time                `json:"watching_at"`
+	Skipped                 bool                     `json:"skipped"`
+	ModifiedHPAConfigs      []ModifiedHPAConfig      `json:"modified_hpa_configs,omitempty"`
+	ModifiedWorkloadConfigs []ModifiedWorkloadConfig `json:"modified_workload_configs,omitempty"`
+	EventID                 *uuid.UUID               `json:"event_id,omitempty"`
+}
@@ -14,3 +14,11 @@ type HPAStatus struct {
 	ReadyReplicas       int32     `json:"ready_replicas"`
 	UnavailableReplicas int32     `json:"unavailable_replicas"`
 }
+
+type WorkloadStatus struct {
+	CreatedAt           time.Time `json:"created_at"`
+	Replicas            int32     `json:"replicas"`
+	AvailableReplicas   int32     `json:"available_replicas"`
+	ReadyReplicas       int32     `json:"ready_replicas"`
+	UnavailableReplicas int32     `json:"unavailable_replicas"`
+}
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+)
+
+type ModifiedWorkloadConfig struct {
+	ID               uuid.UUID                  `json:"id"`
+	Kind             string                     `json:"kind"`
+	Name             string                     `json:"name"`
+	Namespace        string                     `json:"namespace"`
+	Replicas         int32                      `json:"replicas"`
+	OriginalReplicas *int32                     `json:"original_replicas,omitempty"`
+	Status           model.WorkloadUpdateStatus `json:"status"`
+	Message          string                     `json:"message"`
+	RestoreStatus    model.WorkloadUpdateStatus `json:"restore_status"`
+	RestoreMessage   string                     `json:"restore_message"`
+}
+
+type WorkloadPlan struct {
+	Kind            string   `json:"kind"`
+	Name            string   `json:"name"`
+	Namespace       string   `json:"namespace"`
+	CurrentReplicas int32    `json:"current_replicas"`
+	NewReplicas     int32    `json:"new_replicas"`
+	NodePools       []string `json:"node_pools"`
+}
+
+type SimpleWorkload struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Replicas  int32  `json:"replicas"`
+}
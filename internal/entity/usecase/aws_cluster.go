@@ -0,0 +1,22 @@
+package UCEntity
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+type AWSClusterData struct {
+	ClusterData
+	Region string
+}
+
+type AWSClusterMetaData struct {
+	Region string `json:"region"`
+}
+
+type AWSNodeGroupData struct {
+	NodeGroupObject *types.Nodegroup
+}
+
+type AWSClusterUpdateData struct {
+	UpdateData *types.Update
+}
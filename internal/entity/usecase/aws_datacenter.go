@@ -0,0 +1,14 @@
+package UCEntity
+
+type AWSCredentials struct {
+	AccessKeyID     *string `json:"access_key_id" validate:"required"`
+	SecretAccessKey *string `json:"secret_access_key" validate:"required"`
+	SessionToken    string  `json:"session_token"`
+	Region          *string `json:"region" validate:"required"`
+	Endpoint        string  `json:"endpoint"`
+}
+
+type AWSDatacenterMetaData struct {
+	Region      string `json:"region"`
+	AccessKeyID string `json:"access_key_id"`
+}
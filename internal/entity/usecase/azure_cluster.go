@@ -0,0 +1,27 @@
+package UCEntity
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice"
+)
+
+type AzureClusterData struct {
+	ClusterData
+	ResourceGroup string
+	ClusterName   string
+	Location      string
+}
+
+type AzureClusterMetaData struct {
+	ResourceGroup string `json:"resource_group"`
+	ClusterName   string `json:"cluster_name"`
+	Location      string `json:"location"`
+}
+
+type AzureAgentPoolData struct {
+	AgentPoolObject *armcontainerservice.AgentPool
+}
+
+type AzureAgentPoolOperationData struct {
+	Poller *runtime.Poller[armcontainerservice.AgentPoolsClientCreateOrUpdateResponse]
+}
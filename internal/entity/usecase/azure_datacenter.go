@@ -0,0 +1,14 @@
+package UCEntity
+
+type AzureServicePrincipalCredentials struct {
+	TenantID       *string `json:"tenant_id" validate:"required"`
+	ClientID       *string `json:"client_id" validate:"required"`
+	ClientSecret   *string `json:"client_secret" validate:"required"`
+	SubscriptionID *string `json:"subscription_id" validate:"required"`
+}
+
+type AzureDatacenterMetaData struct {
+	TenantID       string `json:"tenant_id"`
+	ClientID       string `json:"client_id"`
+	SubscriptionID string `json:"subscription_id"`
+}
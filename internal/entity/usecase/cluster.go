@@ -1,6 +1,7 @@
 package UCEntity
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
 	v1Apps "k8s.io/api/apps/v1"
@@ -12,6 +13,7 @@ type ClusterData struct {
 	Name                string
 	Certificate         string
 	ServerEndpoint      string
+	Metadata            json.RawMessage
 	Datacenter          DatacenterDetailedData
 	LatestHPAAPIVersion constant.HPAVersion
 }
@@ -25,6 +27,15 @@ type K8sDeploymentListData struct {
 	DeploymentListObject *v1Apps.DeploymentList
 }
 
+type ScaleTargetData struct {
+	Kind, Name, Namespace string
+	PodTemplate           v1Core.PodTemplateSpec
+	Replicas              int32
+	AvailableReplicas     int32
+	ReadyReplicas         int32
+	UnavailableReplicas   int32
+}
+
 type K8sNodeListData struct {
 	NodeListObject *v1Core.NodeList
 }
@@ -32,3 +43,7 @@ type K8sNodeListData struct {
 type K8sDaemonSetListData struct {
 	DaemonSetListObject *v1Apps.DaemonSetList
 }
+
+type K8sPodListData struct {
+	PodListObject *v1Core.PodList
+}
@@ -7,21 +7,33 @@ import (
 )
 
 type Event struct {
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-	ID                uuid.UUID
-	Name              string
-	StartTime         time.Time
-	EndTime           time.Time
-	Status            model.EventStatus
-	Message           string
-	CalculateNodePool bool
-	ExecuteConfigAt   time.Time
-	WatchingAt        time.Time
-	Cluster           ClusterData
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	ID                   uuid.UUID
+	Name                 string
+	StartTime            time.Time
+	EndTime              time.Time
+	Status               model.EventStatus
+	Message              string
+	CalculateNodePool    bool
+	ForceHPAApply        bool
+	NodePoolDistribution model.NodePoolDistribution
+	PrimaryNodePool      string
+	ExecuteConfigAt      time.Time
+	WatchingAt           time.Time
+	RestoredStatus       model.EventStatus
+	Cluster              ClusterData
 }
 
 type DetailedEvent struct {
 	Event
-	EventModifiedHPAConfigData []EventModifiedHPAConfigData
+	EventModifiedHPAConfigData      []EventModifiedHPAConfigData
+	EventModifiedWorkloadConfigData []EventModifiedWorkloadConfigData
+}
+
+// EventConflict holds the HPA configs the conflicting event shares with the checked event
+type EventConflict struct {
+	Event
+	HPAConfigs      []EventModifiedHPAConfigData
+	SharedNodePools []string
 }
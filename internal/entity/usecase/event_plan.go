@@ -0,0 +1,134 @@
+package UCEntity
+
+import (
+	"encoding/json"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"google.golang.org/genproto/googleapis/container/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type PodResourceGroup struct {
+	CPU        float64
+	Memory     float64
+	Count      int64
+	ZoneSpread bool
+}
+
+type NodePoolRequestedResourceData struct {
+	MaxCPU    float64
+	MaxMemory float64
+	MaxPods   int64
+	PodGroups []PodResourceGroup
+}
+
+type NodePoolResourceData struct {
+	MaxAvailablePods   int64
+	MaxAvailableCPU    float64
+	MaxAvailableMemory float64
+	AvailableCPU       float64
+	AvailableMemory    float64
+	AvailablePods      int64
+	CurrentNodeCount   int
+	NodeLabels         labels.Set
+	NodeTaints         []v1.Taint
+}
+
+type DaemonSetData struct {
+	NodeSelector    labels.Selector
+	NodeAffinity    *v1.NodeAffinity
+	Tolerations     []v1.Toleration
+	RequestedMemory float64
+	RequestedCPU    float64
+	Name, Namespace string
+}
+
+type HPAPlan struct {
+	ModifiedHPAConfig  *EventModifiedHPAConfigData
+	HPAObject          interface{}
+	CurrentMinReplicas *int32
+	CurrentMaxReplicas int32
+	CurrentBehavior    json.RawMessage
+	CurrentMetrics     json.RawMessage
+	AppliedMinReplicas *int32
+	AppliedMaxReplicas int32
+	NodePools          []string
+}
+
+type WorkloadPlan struct {
+	ModifiedWorkloadConfig *EventModifiedWorkloadConfigData
+	CurrentReplicas        int32
+	NodePools              []string
+}
+
+type NodePoolPlan struct {
+	NodePoolName       string
+	RequestedResources NodePoolRequestedResourceData
+	AvailableResources NodePoolResourceData
+	Zones              []string
+	PerZoneMaxNode     bool
+	CurrentMaxNode     int32
+	ZoneNodeCounts     map[string]int32
+	PackedNodeCount    int32
+	PackingEfficiency  float64
+	UnschedulablePods  int64
+	NeededNode         int32
+	NewMaxNode         int32
+}
+
+type EventPlan struct {
+	SelectedHPAs      []*HPAPlan
+	MissingHPAs       []*EventModifiedHPAConfigData
+	SelectedWorkloads []*WorkloadPlan
+	MissingWorkloads  []*EventModifiedWorkloadConfigData
+}
+
+type GCPNodePoolPlan struct {
+	NodePoolPlan
+	NodePoolObject *container.NodePool
+}
+
+type GCPEventPlan struct {
+	EventPlan
+	Project     string
+	Location    string
+	ClusterName string
+	NodePools   []*GCPNodePoolPlan
+}
+
+type AWSNodePoolPlan struct {
+	NodePoolPlan
+	NodeGroupObject *types.Nodegroup
+}
+
+type AWSEventPlan struct {
+	EventPlan
+	Region      string
+	ClusterName string
+	NodePools   []*AWSNodePoolPlan
+}
+
+type AzureNodePoolPlan struct {
+	NodePoolPlan
+	AgentPoolObject *armcontainerservice.AgentPool
+}
+
+type AzureEventPlan struct {
+	EventPlan
+	ResourceGroup string
+	ClusterName   string
+	NodePools     []*AzureNodePoolPlan
+}
+
+type KubeconfigNodePoolPlan struct {
+	NodePoolPlan
+	Capacity NodePoolCapacityData
+}
+
+type KubeconfigEventPlan struct {
+	EventPlan
+	NodePoolLabel      string
+	RecommendationOnly bool
+	NodePools          []*KubeconfigNodePoolPlan
+}
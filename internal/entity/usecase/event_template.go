@@ -0,0 +1,18 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type EventTemplate struct {
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+	ID                      uuid.UUID
+	Name                    string
+	CalculateNodePool       bool
+	ForceHPAApply           bool
+	ClusterID               uuid.UUID
+	ModifiedHPAConfigs      []EventModifiedHPAConfigData
+	ModifiedWorkloadConfigs []EventModifiedWorkloadConfigData
+}
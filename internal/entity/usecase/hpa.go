@@ -1,8 +1,11 @@
 package UCEntity
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	v1 "k8s.io/api/autoscaling/v1"
+	"time"
 )
 
 type HPAScaleTargetRef struct {
@@ -11,6 +14,7 @@ type HPAScaleTargetRef struct {
 }
 
 type SimpleHPAData struct {
+	Kind            string
 	Name            string
 	Namespace       string
 	MinReplicas     *int32
@@ -19,12 +23,47 @@ type SimpleHPAData struct {
 	ScaleTargetRef  HPAScaleTargetRef
 }
 
+type HPAMetricTargetData struct {
+	ResourceName       string `json:"resource_name"`
+	AverageUtilization int32  `json:"average_utilization"`
+}
+
 type EventModifiedHPAConfigData struct {
+	ID                  uuid.UUID
+	Kind                string
+	Name                string
+	Namespace           string
+	Status              model.HPAUpdateStatus
+	Message             string
+	MinReplicas         *int32
+	MaxReplicas         int32
+	OriginalMinReplicas *int32
+	OriginalMaxReplicas *int32
+	Behavior            json.RawMessage
+	MetricTargets       []HPAMetricTargetData
+	OriginalBehavior    json.RawMessage
+	OriginalMetrics     json.RawMessage
+	RestoreStatus       model.HPAUpdateStatus
+	RestoreMessage      string
+	RampUpSteps         []HPAStepData
+	RampDownSteps       []HPAStepData
+}
+
+type HPAStepData struct {
 	ID          uuid.UUID
-	Name        string
-	Namespace   string
-	Status      model.HPAUpdateStatus
-	Message     string
+	ExecuteAt   time.Time
 	MinReplicas *int32
 	MaxReplicas int32
+	Status      model.HPAUpdateStatus
+	Message     string
+}
+
+// ScaledObject holds the fields of a KEDA ScaledObject that events read and modify
+type ScaledObject struct {
+	Name            string
+	Namespace       string
+	MinReplicaCount *int32
+	MaxReplicaCount int32
+	ScaleTargetRef  v1.CrossVersionObjectReference
+	HPAName         string
 }
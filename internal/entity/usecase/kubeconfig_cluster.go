@@ -0,0 +1,20 @@
+package UCEntity
+
+import "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+
+type KubeconfigClusterData struct {
+	ClusterData
+	Context         string
+	CapacityBackend constant.CapacityBackend
+}
+
+type KubeconfigClusterMetaData struct {
+	Context         string                   `json:"context"`
+	CapacityBackend constant.CapacityBackend `json:"capacity_backend"`
+}
+
+type NodePoolCapacityData struct {
+	MinNode            int32
+	MaxNode            int32
+	AutoscalingEnabled bool
+}
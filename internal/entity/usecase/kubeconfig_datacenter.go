@@ -0,0 +1,13 @@
+package UCEntity
+
+type KubeconfigCredentials struct {
+	Kubeconfig     string `json:"kubeconfig" validate:"required_without_all=ServerEndpoint Token"`
+	ServerEndpoint string `json:"server_endpoint" validate:"required_without=Kubeconfig"`
+	Certificate    string `json:"certificate"`
+	Token          string `json:"token" validate:"required_without=Kubeconfig"`
+	NodePoolLabel  string `json:"node_pool_label"`
+}
+
+type KubeconfigDatacenterMetaData struct {
+	NodePoolLabel string `json:"node_pool_label"`
+}
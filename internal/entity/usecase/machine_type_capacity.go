@@ -0,0 +1,7 @@
+package UCEntity
+
+type MachineTypeCapacity struct {
+	MachineType string
+	GuestCPUs   int32
+	MemoryMB    int32
+}
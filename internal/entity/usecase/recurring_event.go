@@ -0,0 +1,39 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+type RecurringEvent struct {
+	CreatedAt                  time.Time
+	UpdatedAt                  time.Time
+	ID                         uuid.UUID
+	Name                       string
+	CronExpression             string
+	Timezone                   string
+	DurationMinutes            int32
+	ExecuteConfigOffsetMinutes int32
+	WatchingOffsetMinutes      int32
+	CalculateNodePool          bool
+	ForceHPAApply              bool
+	NodePoolDistribution       model.NodePoolDistribution
+	PrimaryNodePool            string
+	MergeConflicts             bool
+	ClusterID                  uuid.UUID
+	ModifiedHPAConfigs         []EventModifiedHPAConfigData
+	ModifiedWorkloadConfigs    []EventModifiedWorkloadConfigData
+}
+
+// RecurringEventOccurrence configs are only set when the occurrence overrides the recurring event
+type RecurringEventOccurrence struct {
+	StartTime               time.Time
+	EndTime                 time.Time
+	ExecuteConfigAt         time.Time
+	WatchingAt              time.Time
+	Skipped                 bool
+	ModifiedHPAConfigs      []EventModifiedHPAConfigData
+	ModifiedWorkloadConfigs []EventModifiedWorkloadConfigData
+	EventID                 *uuid.UUID
+}
@@ -2,13 +2,24 @@ package UCEntity
 
 import (
 	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"time"
 )
 
 type UpdatedNodePoolData struct {
-	ID           uuid.UUID
-	NodePoolName string
-	MaxNode      int32
+	ID                         uuid.UUID
+	NodePoolName               string
+	MaxNode                    int32
+	OriginalMinNode            int32
+	OriginalMaxNode            int32
+	OriginalAutoscalingEnabled bool
+	OriginalAutoprovisioned    bool
+	OriginalTotalNodeLimit     bool
+	OriginalLocationPolicy     int32
+	AutoscalingModified        bool
+	DistributionStrategy       model.NodePoolDistribution
+	RestoreStatus              model.NodePoolUpdateStatus
+	RestoreMessage             string
 }
 
 type NodePoolStatusData struct {
@@ -23,3 +34,11 @@ type HPAStatusData struct {
 	ReadyReplicas       int32
 	UnavailableReplicas int32
 }
+
+type WorkloadStatusData struct {
+	CreatedAt           time.Time
+	Replicas            int32
+	AvailableReplicas   int32
+	ReadyReplicas       int32
+	UnavailableReplicas int32
+}
@@ -0,0 +1,19 @@
+package UCEntity
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+)
+
+type EventModifiedWorkloadConfigData struct {
+	ID               uuid.UUID
+	Kind             string
+	Name             string
+	Namespace        string
+	Replicas         int32
+	OriginalReplicas *int32
+	Status           model.WorkloadUpdateStatus
+	Message          string
+	RestoreStatus    model.WorkloadUpdateStatus
+	RestoreMessage   string
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"gorm.io/gorm"
+)
+
+type Aws interface {
+	RegisterDatacenter(c *fiber.Ctx) error
+	GetClustersByDatacenterID(c *fiber.Ctx) error
+	RegisterClusterWithDatacenter(c *fiber.Ctx) error
+}
+
+type aws struct {
+	baseHandler
+	validatorInst    *validator.Validate
+	clusterUC        useCase.AWSCluster
+	generalClusterUC useCase.Cluster
+	datacenterUC     useCase.AWSDatacenter
+	db               *gorm.DB
+}
+
+func newAWSHandler(
+	validatorInst *validator.Validate,
+	clusterUC useCase.AWSCluster,
+	datacenterUC useCase.AWSDatacenter,
+	db *gorm.DB,
+	generalClusterUC useCase.Cluster,
+) Aws {
+
+	return &aws{
+		validatorInst:    validatorInst,
+		clusterUC:        clusterUC,
+		datacenterUC:     datacenterUC,
+		generalClusterUC: generalClusterUC,
+		db:               db,
+	}
+}
+
+func (a *aws) RegisterDatacenter(c *fiber.Ctx) error {
+	reqData := &request.AWSDatacenterData{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	err = a.validatorInst.Struct(reqData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	ctx := c.Context()
+	tx := a.db.WithContext(ctx)
+
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: *reqData.AWSCredentials,
+		Name:        *reqData.Name,
+	}
+	credentials, err := a.datacenterUC.ParseCredentials(datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	var id uuid.UUID
+	if *reqData.IsTemporary {
+		id, err = a.datacenterUC.SaveTemporaryDatacenter(ctx, datacenterData, credentials)
+	} else {
+		id, err = a.datacenterUC.SaveDatacenter(tx, datacenterData, credentials)
+	}
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	return a.successResponse(
+		c,
+		response.AWSDatacenterData{DatacenterID: id, IsTemporary: *reqData.IsTemporary},
+	)
+}
+
+func (a *aws) GetClustersByDatacenterID(c *fiber.Ctx) error {
+	reqData := &request.AWSExistingDatacenterData{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+	err = a.validatorInst.Struct(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	ctx := c.Context()
+	tx := a.db.WithContext(ctx)
+
+	isTemporaryDatacenter := true
+	data, err := a.datacenterUC.GetTemporaryDatacenterData(ctx, *reqData.DatacenterID)
+	if err != nil {
+		isTemporaryDatacenter = false
+		data, err = a.datacenterUC.GetDatacenterData(tx, *reqData.DatacenterID)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+	}
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: data.Credentials,
+		Name:        data.Name,
+	}
+	awsConfig, err := a.datacenterUC.GetAWSConfig(ctx, datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	clusters, err := a.clusterUC.GetAllClustersInAWSRegion(
+		ctx,
+		awsConfig.Region,
+		a.clusterUC.GetEKSClusterClient(awsConfig),
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	clusterData := make([]response.AWSCluster, 0)
+	for _, cluster := range clusters {
+		clusterData = append(
+			clusterData, response.AWSCluster{
+				Cluster: response.Cluster{
+					Name:           cluster.Name,
+					Datacenter:     model.AWS,
+					DatacenterName: data.Name,
+				},
+				Region: cluster.Region,
+			},
+		)
+	}
+
+	return a.successResponse(
+		c, response.AWSDatacenterClusters{
+			Clusters:              clusterData,
+			IsTemporaryDatacenter: isTemporaryDatacenter,
+		},
+	)
+}
+
+func (a *aws) RegisterClusterWithDatacenter(c *fiber.Ctx) error {
+	reqData := &request.AWSRegisterClusterData{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	err = a.validatorInst.Struct(reqData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	ctx := c.Context()
+	tx := a.db.WithContext(ctx)
+
+	var data *UCEntity.DatacenterDetailedData
+	if *reqData.IsDatacenterTemporary {
+		data, err = a.datacenterUC.GetTemporaryDatacenterData(ctx, *reqData.DatacenterID)
+	} else {
+		data, err = a.datacenterUC.GetDatacenterData(tx, *reqData.DatacenterID)
+	}
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: data.Credentials,
+		Name:        data.Name,
+	}
+	awsConfig, err := a.datacenterUC.GetAWSConfig(ctx, datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	clusters, err := a.clusterUC.GetAllClustersInAWSRegion(
+		ctx,
+		awsConfig.Region,
+		a.clusterUC.GetEKSClusterClient(awsConfig),
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	existingCluster, err := a.generalClusterUC.GetAllClustersInLocalByDatacenterID(
+		tx,
+		*reqData.DatacenterID,
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	var selectedClusters []*UCEntity.AWSClusterData
+	for _, clusterName := range reqData.ClustersName {
+		for _, cluster := range existingCluster {
+			if cluster.Name == clusterName {
+				return a.errorResponse(c, fmt.Sprintf(errorConstant.ClusterExists, clusterName))
+			}
+		}
+
+		contains := false
+		for _, cluster := range clusters {
+			if cluster.Name == clusterName {
+				selectedClusters = append(selectedClusters, cluster)
+				contains = true
+				break
+			}
+		}
+		if !contains {
+			return a.errorResponse(c, fmt.Sprintf(errorConstant.ClusterNotFound, clusterName))
+		}
+	}
+
+	a.clusterUC.RegisterAWSCredentials(datacenterData.Name, awsConfig)
+
+	for _, cluster := range selectedClusters {
+		kubernetesClient, err := a.clusterUC.GetKubernetesClusterClient(
+			datacenterData.Name,
+			&cluster.ClusterData,
+		)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+		latestHPAAPIVersion, err := a.generalClusterUC.GetLatestHPAAPIVersion(kubernetesClient)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+		cluster.LatestHPAAPIVersion = latestHPAAPIVersion
+	}
+
+	tx = tx.Begin()
+
+	if *reqData.IsDatacenterTemporary {
+		_, err = a.datacenterUC.SaveDatacenterDetailedData(tx, data)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+	}
+
+	err = a.clusterUC.RegisterClusters(tx, *reqData.DatacenterID, selectedClusters)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	responses := make([]response.AWSCluster, 0)
+	for _, cluster := range selectedClusters {
+		responses = append(
+			responses, response.AWSCluster{
+				Cluster: response.Cluster{
+					ID:             &cluster.ID,
+					Name:           cluster.Name,
+					Datacenter:     model.AWS,
+					DatacenterName: data.Name,
+				},
+				Region: cluster.Region,
+			},
+		)
+	}
+
+	return a.successResponse(c, responses)
+}
@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"gorm.io/gorm"
+)
+
+type Azure interface {
+	RegisterDatacenter(c *fiber.Ctx) error
+	GetClustersByDatacenterID(c *fiber.Ctx) error
+	RegisterClusterWithDatacenter(c *fiber.Ctx) error
+}
+
+type azure struct {
+	baseHandler
+	validatorInst    *validator.Validate
+	clusterUC        useCase.AzureCluster
+	generalClusterUC useCase.Cluster
+	datacenterUC     useCase.AzureDatacenter
+	db               *gorm.DB
+}
+
+func newAzureHandler(
+	validatorInst *validator.Validate,
+	clusterUC useCase.AzureCluster,
+	datacenterUC useCase.AzureDatacenter,
+	db *gorm.DB,
+	generalClusterUC useCase.Cluster,
+) Azure {
+
+	return &azure{
+		validatorInst:    validatorInst,
+		clusterUC:        clusterUC,
+		datacenterUC:     datacenterUC,
+		generalClusterUC: generalClusterUC,
+		db:               db,
+	}
+}
+
+func (a *azure) RegisterDatacenter(c *fiber.Ctx) error {
+	reqData := &request.AzureDatacenterData{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	err = a.validatorInst.Struct(reqData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	ctx := c.Context()
+	tx := a.db.WithContext(ctx)
+
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: *reqData.ServicePrincipalCredentials,
+		Name:        *reqData.Name,
+	}
+	credentials, err := a.datacenterUC.ParseServicePrincipal(datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	var id uuid.UUID
+	if *reqData.IsTemporary {
+		id, err = a.datacenterUC.SaveTemporaryDatacenter(ctx, datacenterData, credentials)
+	} else {
+		id, err = a.datacenterUC.SaveDatacenter(tx, datacenterData, credentials)
+	}
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	return a.successResponse(
+		c,
+		response.AzureDatacenterData{DatacenterID: id, IsTemporary: *reqData.IsTemporary},
+	)
+}
+
+func (a *azure) GetClustersByDatacenterID(c *fiber.Ctx) error {
+	reqData := &request.AzureExistingDatacenterData{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+	err = a.validatorInst.Struct(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	ctx := c.Context()
+	tx := a.db.WithContext(ctx)
+
+	isTemporaryDatacenter := true
+	data, err := a.datacenterUC.GetTemporaryDatacenterData(ctx, *reqData.DatacenterID)
+	if err != nil {
+		isTemporaryDatacenter = false
+		data, err = a.datacenterUC.GetDatacenterData(tx, *reqData.DatacenterID)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+	}
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: data.Credentials,
+		Name:        data.Name,
+	}
+	SPCredentials, err := a.datacenterUC.ParseServicePrincipal(datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	azureCredential, err := a.datacenterUC.GetAzureCredential(datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	managedClustersClient, err := a.clusterUC.GetManagedClustersClient(
+		*SPCredentials.SubscriptionID,
+		azureCredential,
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	clusters, err := a.clusterUC.GetAllClustersInAzureSubscription(
+		ctx,
+		*SPCredentials.SubscriptionID,
+		managedClustersClient,
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	clusterData := make([]response.AzureCluster, 0)
+	for _, cluster := range clusters {
+		clusterData = append(
+			clusterData, response.AzureCluster{
+				Cluster: response.Cluster{
+					Name:           cluster.Name,
+					Datacenter:     model.Azure,
+					DatacenterName: data.Name,
+				},
+				ResourceGroup: cluster.ResourceGroup,
+				Location:      cluster.Location,
+			},
+		)
+	}
+
+	return a.successResponse(
+		c, response.AzureDatacenterClusters{
+			Clusters:              clusterData,
+			IsTemporaryDatacenter: isTemporaryDatacenter,
+		},
+	)
+}
+
+func (a *azure) RegisterClusterWithDatacenter(c *fiber.Ctx) error {
+	reqData := &request.AzureRegisterClusterData{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return a.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	err = a.validatorInst.Struct(reqData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	ctx := c.Context()
+	tx := a.db.WithContext(ctx)
+
+	var data *UCEntity.DatacenterDetailedData
+	if *reqData.IsDatacenterTemporary {
+		data, err = a.datacenterUC.GetTemporaryDatacenterData(ctx, *reqData.DatacenterID)
+	} else {
+		data, err = a.datacenterUC.GetDatacenterData(tx, *reqData.DatacenterID)
+	}
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: data.Credentials,
+		Name:        data.Name,
+	}
+	SPCredentials, err := a.datacenterUC.ParseServicePrincipal(datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	azureCredential, err := a.datacenterUC.GetAzureCredential(datacenterData)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	managedClustersClient, err := a.clusterUC.GetManagedClustersClient(
+		*SPCredentials.SubscriptionID,
+		azureCredential,
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+	clusters, err := a.clusterUC.GetAllClustersInAzureSubscription(
+		ctx,
+		*SPCredentials.SubscriptionID,
+		managedClustersClient,
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	existingCluster, err := a.generalClusterUC.GetAllClustersInLocalByDatacenterID(
+		tx,
+		*reqData.DatacenterID,
+	)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	var selectedClusters []*UCEntity.AzureClusterData
+	for _, clusterName := range reqData.ClustersName {
+		for _, cluster := range existingCluster {
+			if cluster.Name == clusterName {
+				return a.errorResponse(c, fmt.Sprintf(errorConstant.ClusterExists, clusterName))
+			}
+		}
+
+		contains := false
+		for _, cluster := range clusters {
+			if cluster.Name == clusterName {
+				selectedClusters = append(selectedClusters, cluster)
+				contains = true
+				break
+			}
+		}
+		if !contains {
+			return a.errorResponse(c, fmt.Sprintf(errorConstant.ClusterNotFound, clusterName))
+		}
+	}
+
+	for _, cluster := range selectedClusters {
+		kubernetesClient, err := a.clusterUC.GetKubernetesClusterClient(
+			ctx,
+			managedClustersClient,
+			&cluster.ClusterData,
+		)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+		latestHPAAPIVersion, err := a.generalClusterUC.GetLatestHPAAPIVersion(kubernetesClient)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+		cluster.LatestHPAAPIVersion = latestHPAAPIVersion
+	}
+
+	tx = tx.Begin()
+
+	if *reqData.IsDatacenterTemporary {
+		_, err = a.datacenterUC.SaveDatacenterDetailedData(tx, data)
+		if err != nil {
+			return a.errorResponse(c, err.Error())
+		}
+	}
+
+	err = a.clusterUC.RegisterClusters(tx, *reqData.DatacenterID, selectedClusters)
+	if err != nil {
+		return a.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	responses := make([]response.AzureCluster, 0)
+	for _, cluster := range selectedClusters {
+		responses = append(
+			responses, response.AzureCluster{
+				Cluster: response.Cluster{
+					ID:             &cluster.ID,
+					Name:           cluster.Name,
+					Datacenter:     model.Azure,
+					DatacenterName: data.Name,
+				},
+				ResourceGroup: cluster.ResourceGroup,
+				Location:      cluster.Location,
+			},
+		)
+	}
+
+	return a.successResponse(c, responses)
+}
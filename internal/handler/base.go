@@ -58,9 +58,15 @@ func (h baseHandler) failResponse(c *fiber.Ctx, data interface{}) error {
 
 type kubernetesBaseHandler struct {
 	baseHandler
-	generalClusterUC useCase.Cluster
-	gcpClusterUC     useCase.GCPCluster
-	gcpDatacenterUC  useCase.GCPDatacenter
+	generalClusterUC       useCase.Cluster
+	gcpClusterUC           useCase.GCPCluster
+	gcpDatacenterUC        useCase.GCPDatacenter
+	awsClusterUC           useCase.AWSCluster
+	awsDatacenterUC        useCase.AWSDatacenter
+	azureClusterUC         useCase.AzureCluster
+	azureDatacenterUC      useCase.AzureDatacenter
+	kubeconfigClusterUC    useCase.KubeconfigCluster
+	kubeconfigDatacenterUC useCase.KubeconfigDatacenter
 }
 
 func (h kubernetesBaseHandler) getClusterKubernetesClient(
@@ -98,8 +104,75 @@ func (h kubernetesBaseHandler) getClusterKubernetesClient(
 		if err != nil {
 			return nil, nil, err
 		}
+	case model.AWS:
+		datacenterName := clusterData.Datacenter.Name
+		datacenterData := UCEntity.DatacenterData{
+			Credentials: clusterData.Datacenter.Credentials,
+			Name:        datacenterName,
+		}
+		awsConfig, err := h.awsDatacenterUC.GetAWSConfig(ctx, datacenterData)
+		if err != nil {
+			return nil, nil, err
+		}
+		h.awsClusterUC.RegisterAWSCredentials(datacenterName, awsConfig)
+		kubernetesClient, err = h.awsClusterUC.GetKubernetesClusterClient(
+			datacenterName,
+			clusterData,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	case model.Azure:
+		datacenterData := UCEntity.DatacenterData{
+			Credentials: clusterData.Datacenter.Credentials,
+			Name:        clusterData.Datacenter.Name,
+		}
+		SPCredentials, err := h.azureDatacenterUC.ParseServicePrincipal(datacenterData)
+		if err != nil {
+			return nil, nil, err
+		}
+		azureCredential, err := h.azureDatacenterUC.GetAzureCredential(datacenterData)
+		if err != nil {
+			return nil, nil, err
+		}
+		managedClustersClient, err := h.azureClusterUC.GetManagedClustersClient(
+			*SPCredentials.SubscriptionID,
+			azureCredential,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		kubernetesClient, err = h.azureClusterUC.GetKubernetesClusterClient(
+			ctx,
+			managedClustersClient,
+			clusterData,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	case model.Kubeconfig:
+		credentials, err := h.kubeconfigDatacenterUC.ParseCredentials(
+			UCEntity.DatacenterData{
+				Credentials: clusterData.Datacenter.Credentials,
+				Name:        clusterData.Datacenter.Name,
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		kubernetesClient, err = h.kubeconfigClusterUC.GetKubernetesClusterClient(
+			credentials,
+			clusterData,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	default:
 		return nil, nil, errors.New(errorConstant.DatacenterTypeNotFound)
 	}
+	err = h.generalClusterUC.RefreshLatestHPAAPIVersion(tx, kubernetesClient, clusterData)
+	if err != nil {
+		return nil, nil, err
+	}
 	return kubernetesClient, clusterData, nil
 }
@@ -123,6 +123,7 @@ func (ch *cluster) GetClusterAllHPA(c *fiber.Ctx) error {
 	for _, hpa := range HPAs {
 		listHPA = append(
 			listHPA, response.SimpleHPA{
+				Kind:            hpa.Kind,
 				Name:            hpa.Name,
 				Namespace:       hpa.Namespace,
 				MinReplicas:     hpa.MinReplicas,
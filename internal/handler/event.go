@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -10,8 +13,10 @@ import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
 	"gorm.io/gorm"
+	"sort"
 	"time"
 )
 
@@ -23,32 +28,69 @@ type Event interface {
 	DeleteEvent(c *fiber.Ctx) error
 	ListNodePoolStatusByUpdatedNodePool(c *fiber.Ctx) error
 	ListHPAStatusByScheduledHPAConfig(c *fiber.Ctx) error
+	ListWorkloadStatusByScheduledWorkloadConfig(c *fiber.Ctx) error
+	PlanEvent(c *fiber.Ctx) error
+	ScheduleEventNow(c *fiber.Ctx) error
+	CancelEvent(c *fiber.Ctx) error
+	RetryEvent(c *fiber.Ctx) error
+	CloneEvent(c *fiber.Ctx) error
+	RegisterRecurringEvent(c *fiber.Ctx) error
+	ListRecurringEventByCluster(c *fiber.Ctx) error
+	ListRecurringEventOccurrences(c *fiber.Ctx) error
+	UpdateRecurringEventOccurrence(c *fiber.Ctx) error
+	DeleteRecurringEvent(c *fiber.Ctx) error
+	RegisterEventTemplate(c *fiber.Ctx) error
+	ListEventTemplateByCluster(c *fiber.Ctx) error
+	GetDetailedEventTemplate(c *fiber.Ctx) error
+	DeleteEventTemplate(c *fiber.Ctx) error
+	InstantiateEventTemplate(c *fiber.Ctx) error
 }
 
 type event struct {
 	kubernetesBaseHandler
-	validatorInst        *validator.Validate
-	db                   *gorm.DB
-	eventUC              useCase.Event
-	scheduledHPAConfigUC useCase.ScheduledHPAConfig
-	statisticUC          useCase.Statistic
+	validatorInst             *validator.Validate
+	db                        *gorm.DB
+	eventUC                   useCase.Event
+	scheduledHPAConfigUC      useCase.ScheduledHPAConfig
+	scheduledWorkloadConfigUC useCase.ScheduledWorkloadConfig
+	statisticUC               useCase.Statistic
+	gcpEventUC                useCase.GCPEvent
+	awsEventUC                useCase.AWSEvent
+	azureEventUC              useCase.AzureEvent
+	kubeconfigEventUC         useCase.KubeconfigEvent
+	recurringEventUC          useCase.RecurringEvent
+	eventTemplateUC           useCase.EventTemplate
 }
 
 func newEventHandler(
 	validatorInst *validator.Validate,
 	eventUC useCase.Event,
 	scheduledHPAConfigUC useCase.ScheduledHPAConfig,
+	scheduledWorkloadConfigUC useCase.ScheduledWorkloadConfig,
 	updatedNodePoolUC useCase.Statistic,
+	gcpEventUC useCase.GCPEvent,
+	awsEventUC useCase.AWSEvent,
+	azureEventUC useCase.AzureEvent,
+	kubeconfigEventUC useCase.KubeconfigEvent,
+	recurringEventUC useCase.RecurringEvent,
+	eventTemplateUC useCase.EventTemplate,
 	db *gorm.DB,
 	kubeHandler kubernetesBaseHandler,
 ) Event {
 	return &event{
-		kubernetesBaseHandler: kubeHandler,
-		validatorInst:         validatorInst,
-		eventUC:               eventUC,
-		scheduledHPAConfigUC:  scheduledHPAConfigUC,
-		statisticUC:           updatedNodePoolUC,
-		db:                    db,
+		kubernetesBaseHandler:     kubeHandler,
+		validatorInst:             validatorInst,
+		eventUC:                   eventUC,
+		scheduledHPAConfigUC:      scheduledHPAConfigUC,
+		scheduledWorkloadConfigUC: scheduledWorkloadConfigUC,
+		statisticUC:               updatedNodePoolUC,
+		gcpEventUC:                gcpEventUC,
+		awsEventUC:                awsEventUC,
+		azureEventUC:              azureEventUC,
+		kubeconfigEventUC:         kubeconfigEventUC,
+		recurringEventUC:          recurringEventUC,
+		eventTemplateUC:           eventTemplateUC,
+		db:                        db,
 	}
 }
 
@@ -69,6 +111,18 @@ func (e *event) RegisterEvents(c *fiber.Ctx) error {
 		reqData.CalculateNodePool = &active
 	}
 
+	nodePoolDistribution := model.NodePoolDistributionProportional
+	if reqData.NodePoolDistribution != nil {
+		nodePoolDistribution = model.NodePoolDistribution(*reqData.NodePoolDistribution)
+	}
+	primaryNodePool := ""
+	if reqData.PrimaryNodePool != nil {
+		primaryNodePool = *reqData.PrimaryNodePool
+	}
+	if nodePoolDistribution == model.NodePoolDistributionPrimaryPool && primaryNodePool == "" {
+		return e.errorResponse(c, errorConstant.PrimaryNodePoolRequired)
+	}
+
 	utcNow := time.Now().UTC()
 	if utcNow.After(*reqData.StartTime) || utcNow.After(*reqData.EndTime) {
 		return e.errorResponse(c, errorConstant.InvalidRequestBody)
@@ -98,46 +152,72 @@ func (e *event) RegisterEvents(c *fiber.Ctx) error {
 	}
 
 	eventData := &UCEntity.Event{
-		Name:              *reqData.Name,
-		ExecuteConfigAt:   *reqData.ExecuteConfigAt,
-		WatchingAt:        *reqData.WatchingAt,
-		StartTime:         *reqData.StartTime,
-		EndTime:           *reqData.EndTime,
-		CalculateNodePool: *reqData.CalculateNodePool,
+		Name:                 *reqData.Name,
+		ExecuteConfigAt:      *reqData.ExecuteConfigAt,
+		WatchingAt:           *reqData.WatchingAt,
+		StartTime:            *reqData.StartTime,
+		EndTime:              *reqData.EndTime,
+		CalculateNodePool:    *reqData.CalculateNodePool,
+		ForceHPAApply:        reqData.ForceHPAApply != nil && *reqData.ForceHPAApply,
+		NodePoolDistribution: nodePoolDistribution,
+		PrimaryNodePool:      primaryNodePool,
 	}
 	eventData.Cluster.ID = *reqData.ClusterID
 
-	eventID, err := e.eventUC.RegisterEvents(tx, eventData)
-	if err != nil {
-		return e.errorResponse(c, err.Error())
-	}
-
 	var HPAConfigs []UCEntity.EventModifiedHPAConfigData
 	for _, hpaConfig := range reqData.ModifiedHPAConfigs {
 		found := false
+		kind := constant.HorizontalPodAutoscaler
+		if hpaConfig.Kind != nil {
+			kind = *hpaConfig.Kind
+		}
 		for _, HPA := range HPAs {
-			if *hpaConfig.Name == HPA.Name && *hpaConfig.Namespace == HPA.Namespace {
+			if kind == HPA.Kind && *hpaConfig.Name == HPA.Name && *hpaConfig.Namespace == HPA.Namespace {
 				found = true
 				break
 			}
 		}
 		if found {
-			HPAConfigs = append(
-				HPAConfigs, UCEntity.EventModifiedHPAConfigData{
-					Name:        *hpaConfig.Name,
-					Namespace:   *hpaConfig.Namespace,
-					MinReplicas: hpaConfig.MinReplicas,
-					MaxReplicas: *hpaConfig.MaxReplicas,
-				},
-			)
+			HPAConfig, err := e.parseModifiedHPAConfig(hpaConfig, eventData)
+			if err != nil {
+				return e.errorResponse(c, err.Error())
+			}
+			HPAConfigs = append(HPAConfigs, HPAConfig)
 		}
 	}
 
+	HPAConfigs, conflictRes, err := e.resolveEventConflicts(
+		tx,
+		eventData,
+		HPAConfigs,
+		reqData.MergeConflicts != nil && *reqData.MergeConflicts,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	if conflictRes != nil {
+		return e.errorResponse(c, conflictRes)
+	}
+
+	eventID, err := e.eventUC.RegisterEvents(tx, eventData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
 	_, err = e.scheduledHPAConfigUC.RegisterModifiedHPAConfigs(tx, HPAConfigs, eventID)
 	if err != nil {
 		return e.errorResponse(c, err.Error())
 	}
 
+	_, err = e.scheduledWorkloadConfigUC.RegisterModifiedWorkloadConfigs(
+		tx,
+		e.parseModifiedWorkloadConfigs(reqData.ModifiedWorkloadConfigs),
+		eventID,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
 	tx.Commit()
 
 	return e.successResponse(c, response.EventCreationResponse{EventID: eventID})
@@ -200,6 +280,11 @@ func (e *event) UpdateEvent(c *fiber.Ctx) error {
 		return e.errorResponse(c, errorConstant.EventNotExist)
 	}
 
+	// The HPA configs hold the original replicas snapshot once the event is executed
+	if eventData.Status != model.EventPending {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.EventStatusInvalid, eventData.Status, "updated"))
+	}
+
 	if eventData.Name != *req.Name {
 		eventData.Name = *req.Name
 	}
@@ -208,29 +293,55 @@ func (e *event) UpdateEvent(c *fiber.Ctx) error {
 		eventData.CalculateNodePool = *req.CalculateNodePool
 	}
 
+	if req.ForceHPAApply != nil {
+		eventData.ForceHPAApply = *req.ForceHPAApply
+	}
+
+	if req.NodePoolDistribution != nil {
+		eventData.NodePoolDistribution = model.NodePoolDistribution(*req.NodePoolDistribution)
+	}
+
+	if req.PrimaryNodePool != nil {
+		eventData.PrimaryNodePool = *req.PrimaryNodePool
+	}
+
+	if eventData.NodePoolDistribution == model.NodePoolDistributionPrimaryPool && eventData.PrimaryNodePool == "" {
+		return e.errorResponse(c, errorConstant.PrimaryNodePoolRequired)
+	}
+
 	eventData.StartTime = *req.StartTime
 	eventData.EndTime = *req.EndTime
 	eventData.ExecuteConfigAt = *req.ExecuteConfigAt
 	eventData.WatchingAt = *req.WatchingAt
 
-	if err := e.eventUC.UpdateEvent(tx, eventData); err != nil {
+	var newModifiedHPAConfigs []UCEntity.EventModifiedHPAConfigData
+	for _, hpaConfig := range req.ModifiedHPAConfigs {
+		newModifiedHPAConfig, err := e.parseModifiedHPAConfig(hpaConfig, eventData)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		newModifiedHPAConfigs = append(newModifiedHPAConfigs, newModifiedHPAConfig)
+	}
+
+	newModifiedHPAConfigs, conflictRes, err := e.resolveEventConflicts(
+		tx,
+		eventData,
+		newModifiedHPAConfigs,
+		req.MergeConflicts != nil && *req.MergeConflicts,
+	)
+	if err != nil {
 		return e.errorResponse(c, err.Error())
 	}
+	if conflictRes != nil {
+		return e.errorResponse(c, conflictRes)
+	}
 
-	if err := e.scheduledHPAConfigUC.DeleteEventModifiedHPAConfigs(tx, eventData.ID); err != nil {
+	if err := e.eventUC.UpdateEvent(tx, eventData); err != nil {
 		return e.errorResponse(c, err.Error())
 	}
 
-	var newModifiedHPAConfigs []UCEntity.EventModifiedHPAConfigData
-	for _, hpaConfig := range req.ModifiedHPAConfigs {
-		newModifiedHPAConfigs = append(
-			newModifiedHPAConfigs, UCEntity.EventModifiedHPAConfigData{
-				Name:        *hpaConfig.Name,
-				Namespace:   *hpaConfig.Namespace,
-				MinReplicas: hpaConfig.MinReplicas,
-				MaxReplicas: *hpaConfig.MaxReplicas,
-			},
-		)
+	if err := e.scheduledHPAConfigUC.DeleteEventModifiedHPAConfigs(tx, eventData.ID); err != nil {
+		return e.errorResponse(c, err.Error())
 	}
 
 	_, err = e.scheduledHPAConfigUC.RegisterModifiedHPAConfigs(
@@ -242,6 +353,20 @@ func (e *event) UpdateEvent(c *fiber.Ctx) error {
 		return e.errorResponse(c, err.Error())
 	}
 
+	err = e.scheduledWorkloadConfigUC.DeleteEventModifiedWorkloadConfigs(tx, eventData.ID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	_, err = e.scheduledWorkloadConfigUC.RegisterModifiedWorkloadConfigs(
+		tx,
+		e.parseModifiedWorkloadConfigs(req.ModifiedWorkloadConfigs),
+		eventData.ID,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
 	tx.Commit()
 
 	res := &response.EventCreationResponse{EventID: eventData.ID}
@@ -263,18 +388,10 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 		return e.errorResponse(c, errorConstant.EventNotExist)
 	}
 
-	var modifiedHPAConfigRes []response.ModifiedHPAConfig
-	for _, hpa := range eventData.EventModifiedHPAConfigData {
-		modifiedHPAConfigRes = append(
-			modifiedHPAConfigRes, response.ModifiedHPAConfig{
-				ID:          hpa.ID,
-				Name:        hpa.Name,
-				Namespace:   hpa.Namespace,
-				MinReplicas: hpa.MinReplicas,
-				MaxReplicas: hpa.MaxReplicas,
-			},
-		)
-	}
+	modifiedHPAConfigRes := e.parseModifiedHPAConfigResponses(eventData.EventModifiedHPAConfigData)
+	modifiedWorkloadConfigRes := e.parseModifiedWorkloadConfigResponses(
+		eventData.EventModifiedWorkloadConfigData,
+	)
 
 	updatedNodePools, err := e.statisticUC.GetAllUpdatedNodePoolByEvent(db, eventID)
 	if err != nil {
@@ -285,9 +402,14 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 	for _, updatedNodePool := range updatedNodePools {
 		updatedNodePoolRes = append(
 			updatedNodePoolRes, response.UpdatedNodePool{
-				ID:           updatedNodePool.ID,
-				NodePoolName: updatedNodePool.NodePoolName,
-				MaxNode:      updatedNodePool.MaxNode,
+				ID:                   updatedNodePool.ID,
+				NodePoolName:         updatedNodePool.NodePoolName,
+				MaxNode:              updatedNodePool.MaxNode,
+				OriginalMinNode:      updatedNodePool.OriginalMinNode,
+				OriginalMaxNode:      updatedNodePool.OriginalMaxNode,
+				DistributionStrategy: updatedNodePool.DistributionStrategy,
+				RestoreStatus:        updatedNodePool.RestoreStatus,
+				RestoreMessage:       updatedNodePool.RestoreMessage,
 			},
 		)
 	}
@@ -308,11 +430,15 @@ func (e *event) GetDetailedEvent(c *fiber.Ctx) error {
 			Datacenter:     eventData.Cluster.Datacenter.Datacenter,
 			DatacenterName: eventData.Cluster.Datacenter.Name,
 		},
-		ModifiedHPAConfigs: modifiedHPAConfigRes,
-		UpdatedNodePools:   updatedNodePoolRes,
-		CalculateNodePool:  eventData.CalculateNodePool,
-		ExecuteConfigAt:    eventData.ExecuteConfigAt,
-		WatchingAt:         eventData.WatchingAt,
+		ModifiedHPAConfigs:      modifiedHPAConfigRes,
+		ModifiedWorkloadConfigs: modifiedWorkloadConfigRes,
+		UpdatedNodePools:        updatedNodePoolRes,
+		CalculateNodePool:       eventData.CalculateNodePool,
+		ForceHPAApply:           eventData.ForceHPAApply,
+		NodePoolDistribution:    eventData.NodePoolDistribution,
+		PrimaryNodePool:         eventData.PrimaryNodePool,
+		ExecuteConfigAt:         eventData.ExecuteConfigAt,
+		WatchingAt:              eventData.WatchingAt,
 	}
 
 	return e.successResponse(c, res)
@@ -329,11 +455,28 @@ func (e *event) DeleteEvent(c *fiber.Ctx) error {
 	db := e.db.WithContext(ctx)
 	tx := db.Begin()
 
-	_, err = e.eventUC.GetEventByID(db, eventID)
+	eventData, err := e.eventUC.GetEventByID(db, eventID)
 	if err != nil {
 		return e.errorResponse(c, errorConstant.EventNotExist)
 	}
 
+	// Running events have to be cancelled or finish restoring first, the restore skips deleted events
+	switch eventData.Status {
+	case model.EventPending, model.EventSuccess, model.EventFailed:
+	case model.EventCancelled:
+		cancelledEvents, err := e.eventUC.GetAllCancelledUnrestoredEvent(db)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		for _, cancelledEvent := range cancelledEvents {
+			if cancelledEvent.ID == eventID {
+				return e.errorResponse(c, errorConstant.EventRestorePending)
+			}
+		}
+	default:
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.EventStatusInvalid, eventData.Status, "deleted"))
+	}
+
 	err = e.eventUC.DeleteEvent(tx, eventID)
 	if err != nil {
 		return e.errorResponse(c, err.Error())
@@ -344,6 +487,11 @@ func (e *event) DeleteEvent(c *fiber.Ctx) error {
 		return e.errorResponse(c, err.Error())
 	}
 
+	err = e.scheduledWorkloadConfigUC.SoftDeleteEventModifiedWorkloadConfigs(tx, eventID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
 	tx.Commit()
 
 	return e.successResponse(c, constant.ActionDone)
@@ -416,3 +564,809 @@ func (e *event) ListHPAStatusByScheduledHPAConfig(c *fiber.Ctx) error {
 
 	return e.successResponse(c, resp)
 }
+
+func (e *event) ListWorkloadStatusByScheduledWorkloadConfig(c *fiber.Ctx) error {
+	scheduledWorkloadConfigIDStr := c.Params("scheduled_workload_config_id")
+	scheduledWorkloadConfigID, err := uuid.Parse(scheduledWorkloadConfigIDStr)
+	if err != nil {
+		return e.errorResponse(
+			c,
+			fmt.Sprintf(errorConstant.ParamInvalid, "scheduled_workload_config_id"),
+		)
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	workloadStatuses, err := e.statisticUC.GetAllWorkloadStatusByScheduledWorkloadConfigID(
+		db,
+		scheduledWorkloadConfigID,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	var resp []response.WorkloadStatus
+	for _, workloadStatus := range workloadStatuses {
+		resp = append(
+			resp, response.WorkloadStatus{
+				CreatedAt:           workloadStatus.CreatedAt,
+				Replicas:            workloadStatus.Replicas,
+				ReadyReplicas:       workloadStatus.ReadyReplicas,
+				UnavailableReplicas: workloadStatus.UnavailableReplicas,
+				AvailableReplicas:   workloadStatus.AvailableReplicas,
+			},
+		)
+	}
+
+	return e.successResponse(c, resp)
+}
+
+func (e *event) PlanEvent(c *fiber.Ctx) error {
+	eventIDStr := c.Params("event_id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+
+	eventData, err := e.eventUC.GetDetailedEventData(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventNotExist)
+	}
+
+	kubernetesClient, clusterData, err := e.getClusterKubernetesClient(
+		ctx,
+		db,
+		eventData.Cluster.ID,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	res := &response.EventPlanResponse{
+		EventSimpleResponse: response.EventSimpleResponse{
+			ID:        eventData.ID,
+			Name:      eventData.Name,
+			StartTime: eventData.StartTime,
+			EndTime:   eventData.EndTime,
+			Status:    eventData.Status,
+		},
+		CalculateNodePool: eventData.CalculateNodePool,
+		HPAs:              make([]response.HPAPlan, 0),
+		MissingHPAs:       make([]response.SimpleHPA, 0),
+		Workloads:         make([]response.WorkloadPlan, 0),
+		MissingWorkloads:  make([]response.SimpleWorkload, 0),
+		NodePools:         make([]response.NodePoolPlan, 0),
+	}
+
+	var plan *UCEntity.EventPlan
+	var nodePoolPlans []*UCEntity.NodePoolPlan
+	switch clusterData.Datacenter.Datacenter {
+	case model.GCP:
+		datacenterData := UCEntity.DatacenterData{
+			Credentials: clusterData.Datacenter.Credentials,
+			Name:        clusterData.Datacenter.Name,
+		}
+		googleCredentials, err := e.gcpDatacenterUC.GetGoogleCredentials(ctx, datacenterData)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		clusterClient, err := e.gcpClusterUC.GetGoogleClusterClient(ctx, googleCredentials)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		machineTypesClient, err := e.gcpClusterUC.GetGoogleMachineTypesClient(ctx, googleCredentials)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		gcpPlan, err := e.gcpEventUC.CalculateGCPEventPlan(
+			ctx,
+			db,
+			kubernetesClient,
+			clusterClient,
+			machineTypesClient,
+			clusterData,
+			&eventData.Event,
+		)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		plan = &gcpPlan.EventPlan
+		for _, nodePoolPlan := range gcpPlan.NodePools {
+			nodePoolPlans = append(nodePoolPlans, &nodePoolPlan.NodePoolPlan)
+		}
+	case model.AWS:
+		datacenterData := UCEntity.DatacenterData{
+			Credentials: clusterData.Datacenter.Credentials,
+			Name:        clusterData.Datacenter.Name,
+		}
+		awsConfig, err := e.awsDatacenterUC.GetAWSConfig(ctx, datacenterData)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		awsPlan, err := e.awsEventUC.CalculateAWSEventPlan(
+			ctx,
+			db,
+			kubernetesClient,
+			e.awsClusterUC.GetEKSClusterClient(awsConfig),
+			clusterData,
+			&eventData.Event,
+		)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		plan = &awsPlan.EventPlan
+		for _, nodePoolPlan := range awsPlan.NodePools {
+			nodePoolPlans = append(nodePoolPlans, &nodePoolPlan.NodePoolPlan)
+		}
+	case model.Azure:
+		datacenterData := UCEntity.DatacenterData{
+			Credentials: clusterData.Datacenter.Credentials,
+			Name:        clusterData.Datacenter.Name,
+		}
+		SPCredentials, err := e.azureDatacenterUC.ParseServicePrincipal(datacenterData)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		azureCredential, err := e.azureDatacenterUC.GetAzureCredential(datacenterData)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		agentPoolsClient, err := e.azureClusterUC.GetAgentPoolsClient(
+			*SPCredentials.SubscriptionID,
+			azureCredential,
+		)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		azurePlan, err := e.azureEventUC.CalculateAzureEventPlan(
+			ctx,
+			db,
+			kubernetesClient,
+			agentPoolsClient,
+			clusterData,
+			&eventData.Event,
+		)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		plan = &azurePlan.EventPlan
+		for _, nodePoolPlan := range azurePlan.NodePools {
+			nodePoolPlans = append(nodePoolPlans, &nodePoolPlan.NodePoolPlan)
+		}
+	case model.Kubeconfig:
+		credentials, err := e.kubeconfigDatacenterUC.ParseCredentials(
+			UCEntity.DatacenterData{
+				Credentials: clusterData.Datacenter.Credentials,
+				Name:        clusterData.Datacenter.Name,
+			},
+		)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		kubeconfigPlan, err := e.kubeconfigEventUC.CalculateKubeconfigEventPlan(
+			ctx,
+			db,
+			kubernetesClient,
+			credentials.NodePoolLabel,
+			clusterData,
+			&eventData.Event,
+		)
+		if err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		plan = &kubeconfigPlan.EventPlan
+		for _, nodePoolPlan := range kubeconfigPlan.NodePools {
+			nodePoolPlans = append(nodePoolPlans, &nodePoolPlan.NodePoolPlan)
+		}
+		res.RecommendationOnly = kubeconfigPlan.RecommendationOnly
+	default:
+		return e.errorResponse(c, errorConstant.DatacenterTypeNotFound)
+	}
+
+	for _, hpaPlan := range plan.SelectedHPAs {
+		res.HPAs = append(
+			res.HPAs, response.HPAPlan{
+				Kind:               hpaPlan.ModifiedHPAConfig.Kind,
+				Name:               hpaPlan.ModifiedHPAConfig.Name,
+				Namespace:          hpaPlan.ModifiedHPAConfig.Namespace,
+				CurrentMinReplicas: hpaPlan.CurrentMinReplicas,
+				CurrentMaxReplicas: hpaPlan.CurrentMaxReplicas,
+				NewMinReplicas:     hpaPlan.AppliedMinReplicas,
+				NewMaxReplicas:     hpaPlan.AppliedMaxReplicas,
+				TargetMinReplicas:  hpaPlan.ModifiedHPAConfig.MinReplicas,
+				TargetMaxReplicas:  hpaPlan.ModifiedHPAConfig.MaxReplicas,
+				CurrentBehavior:    hpaPlan.CurrentBehavior,
+				CurrentMetrics:     hpaPlan.CurrentMetrics,
+				NewBehavior:        hpaPlan.ModifiedHPAConfig.Behavior,
+				NewMetricTargets:   e.parseHPAMetricTargets(hpaPlan.ModifiedHPAConfig.MetricTargets),
+				RampUpSteps:        e.parseHPASteps(hpaPlan.ModifiedHPAConfig.RampUpSteps),
+				NodePools:          hpaPlan.NodePools,
+			},
+		)
+	}
+
+	for _, missingHPA := range plan.MissingHPAs {
+		res.MissingHPAs = append(
+			res.MissingHPAs, response.SimpleHPA{
+				Kind:        missingHPA.Kind,
+				Name:        missingHPA.Name,
+				Namespace:   missingHPA.Namespace,
+				MinReplicas: missingHPA.MinReplicas,
+				MaxReplicas: missingHPA.MaxReplicas,
+			},
+		)
+	}
+
+	for _, workloadPlan := range plan.SelectedWorkloads {
+		res.Workloads = append(
+			res.Workloads, response.WorkloadPlan{
+				Kind:            workloadPlan.ModifiedWorkloadConfig.Kind,
+				Name:            workloadPlan.ModifiedWorkloadConfig.Name,
+				Namespace:       workloadPlan.ModifiedWorkloadConfig.Namespace,
+				CurrentReplicas: workloadPlan.CurrentReplicas,
+				NewReplicas:     workloadPlan.ModifiedWorkloadConfig.Replicas,
+				NodePools:       workloadPlan.NodePools,
+			},
+		)
+	}
+
+	for _, missingWorkload := range plan.MissingWorkloads {
+		res.MissingWorkloads = append(
+			res.MissingWorkloads, response.SimpleWorkload{
+				Kind:      missingWorkload.Kind,
+				Name:      missingWorkload.Name,
+				Namespace: missingWorkload.Namespace,
+				Replicas:  missingWorkload.Replicas,
+			},
+		)
+	}
+
+	for _, nodePoolPlan := range nodePoolPlans {
+		res.NodePools = append(
+			res.NodePools, response.NodePoolPlan{
+				NodePoolName:       nodePoolPlan.NodePoolName,
+				RequestedCPU:       nodePoolPlan.RequestedResources.MaxCPU,
+				RequestedMemory:    nodePoolPlan.RequestedResources.MaxMemory,
+				RequestedPods:      nodePoolPlan.RequestedResources.MaxPods,
+				MaxAvailableCPU:    nodePoolPlan.AvailableResources.MaxAvailableCPU,
+				MaxAvailableMemory: nodePoolPlan.AvailableResources.MaxAvailableMemory,
+				MaxAvailablePods:   nodePoolPlan.AvailableResources.MaxAvailablePods,
+				CurrentNodeCount:   nodePoolPlan.AvailableResources.CurrentNodeCount,
+				Zones:              nodePoolPlan.Zones,
+				PerZoneMaxNode:     nodePoolPlan.PerZoneMaxNode,
+				CurrentMaxNode:     nodePoolPlan.CurrentMaxNode,
+				ZoneNodeCounts:     nodePoolPlan.ZoneNodeCounts,
+				PackedNodeCount:    nodePoolPlan.PackedNodeCount,
+				PackingEfficiency:  nodePoolPlan.PackingEfficiency,
+				UnschedulablePods:  nodePoolPlan.UnschedulablePods,
+				NeededNode:         nodePoolPlan.NeededNode,
+				NewMaxNode:         nodePoolPlan.NewMaxNode,
+			},
+		)
+	}
+
+	return e.successResponse(c, res)
+}
+
+// ScheduleEventNow only reschedules the event, the event cron executes it on its next tick
+func (e *event) ScheduleEventNow(c *fiber.Ctx) error {
+	eventIDStr := c.Params("event_id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.eventUC.GetEventByID(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventNotExist)
+	}
+
+	err = e.eventUC.ScheduleEventNow(tx, eventID, time.Now())
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, constant.ActionDone)
+}
+
+func (e *event) CancelEvent(c *fiber.Ctx) error {
+	eventIDStr := c.Params("event_id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.eventUC.GetEventByID(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventNotExist)
+	}
+
+	err = e.eventUC.CancelEvent(tx, eventID, time.Now())
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, constant.ActionDone)
+}
+
+func (e *event) RetryEvent(c *fiber.Ctx) error {
+	eventIDStr := c.Params("event_id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.eventUC.GetEventByID(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventNotExist)
+	}
+
+	err = e.eventUC.RetryEvent(tx, eventID, time.Now())
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, constant.ActionDone)
+}
+
+func (e *event) CloneEvent(c *fiber.Ctx) error {
+	eventIDStr := c.Params("event_id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_id"))
+	}
+
+	reqData := &request.EventScheduleRequest{}
+	err = c.BodyParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	utcNow := time.Now().UTC()
+	if utcNow.After(*reqData.StartTime) || utcNow.After(*reqData.EndTime) {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	sourceEvent, err := e.eventUC.GetDetailedEventData(db, eventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventNotExist)
+	}
+
+	eventData := &UCEntity.Event{
+		Name:                 *reqData.Name,
+		ExecuteConfigAt:      *reqData.ExecuteConfigAt,
+		WatchingAt:           *reqData.WatchingAt,
+		StartTime:            *reqData.StartTime,
+		EndTime:              *reqData.EndTime,
+		CalculateNodePool:    sourceEvent.CalculateNodePool,
+		ForceHPAApply:        sourceEvent.ForceHPAApply,
+		NodePoolDistribution: sourceEvent.NodePoolDistribution,
+		PrimaryNodePool:      sourceEvent.PrimaryNodePool,
+	}
+	eventData.Cluster.ID = sourceEvent.Cluster.ID
+
+	// Ramp steps keep their distance to the start and end of the source event
+	startShift := eventData.StartTime.Sub(sourceEvent.StartTime)
+	endShift := eventData.EndTime.Sub(sourceEvent.EndTime)
+	var hpaConfigs []UCEntity.EventModifiedHPAConfigData
+	for _, sourceConfig := range sourceEvent.EventModifiedHPAConfigData {
+		hpaConfig := UCEntity.EventModifiedHPAConfigData{
+			Kind:          sourceConfig.Kind,
+			Name:          sourceConfig.Name,
+			Namespace:     sourceConfig.Namespace,
+			MinReplicas:   sourceConfig.MinReplicas,
+			MaxReplicas:   sourceConfig.MaxReplicas,
+			Behavior:      sourceConfig.Behavior,
+			MetricTargets: sourceConfig.MetricTargets,
+			RampUpSteps:   e.shiftHPASteps(sourceConfig.RampUpSteps, startShift),
+			RampDownSteps: e.shiftHPASteps(sourceConfig.RampDownSteps, endShift),
+		}
+		if err := e.validateHPASteps(hpaConfig, eventData); err != nil {
+			return e.errorResponse(c, err.Error())
+		}
+		hpaConfigs = append(hpaConfigs, hpaConfig)
+	}
+
+	var workloadConfigs []UCEntity.EventModifiedWorkloadConfigData
+	for _, sourceConfig := range sourceEvent.EventModifiedWorkloadConfigData {
+		workloadConfigs = append(
+			workloadConfigs, UCEntity.EventModifiedWorkloadConfigData{
+				Kind:      sourceConfig.Kind,
+				Name:      sourceConfig.Name,
+				Namespace: sourceConfig.Namespace,
+				Replicas:  sourceConfig.Replicas,
+			},
+		)
+	}
+
+	res, conflictRes, err := e.registerEventWithConfigs(
+		ctx,
+		db,
+		tx,
+		eventData,
+		hpaConfigs,
+		workloadConfigs,
+		reqData.MergeConflicts != nil && *reqData.MergeConflicts,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	if conflictRes != nil {
+		return e.errorResponse(c, conflictRes)
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, res)
+}
+
+func (e *event) parseModifiedHPAConfig(
+	hpaConfig request.EventModifiedHPAConfigData,
+	eventData *UCEntity.Event,
+) (UCEntity.EventModifiedHPAConfigData, error) {
+	data := UCEntity.EventModifiedHPAConfigData{
+		Kind:        constant.HorizontalPodAutoscaler,
+		Name:        *hpaConfig.Name,
+		Namespace:   *hpaConfig.Namespace,
+		MinReplicas: hpaConfig.MinReplicas,
+		MaxReplicas: *hpaConfig.MaxReplicas,
+	}
+	if hpaConfig.Kind != nil {
+		data.Kind = *hpaConfig.Kind
+	}
+	if hpaConfig.Behavior != nil {
+		behavior, err := json.Marshal(hpaConfig.Behavior)
+		if err != nil {
+			return data, err
+		}
+		data.Behavior = behavior
+	}
+	for _, metricTarget := range hpaConfig.MetricTargets {
+		data.MetricTargets = append(
+			data.MetricTargets, UCEntity.HPAMetricTargetData{
+				ResourceName:       *metricTarget.ResourceName,
+				AverageUtilization: *metricTarget.AverageUtilization,
+			},
+		)
+	}
+
+	data.RampUpSteps = e.parseModifiedHPASteps(hpaConfig.RampUpSteps)
+	data.RampDownSteps = e.parseModifiedHPASteps(hpaConfig.RampDownSteps)
+	return data, e.validateHPASteps(data, eventData)
+}
+
+func (e *event) validateHPASteps(
+	data UCEntity.EventModifiedHPAConfigData,
+	eventData *UCEntity.Event,
+) error {
+	for _, step := range data.RampUpSteps {
+		if step.ExecuteAt.Before(eventData.ExecuteConfigAt) || step.ExecuteAt.After(eventData.StartTime) {
+			return fmt.Errorf(errorConstant.HPARampUpStepInvalid, data.Name)
+		}
+	}
+	for _, step := range data.RampDownSteps {
+		if !step.ExecuteAt.After(eventData.EndTime) {
+			return fmt.Errorf(errorConstant.HPARampDownStepInvalid, data.Name)
+		}
+	}
+	for _, step := range append(data.RampUpSteps, data.RampDownSteps...) {
+		// Node pools are sized for the event target, steps may not go above it
+		if step.MaxReplicas > data.MaxReplicas ||
+			(step.MinReplicas != nil && *step.MinReplicas > step.MaxReplicas) {
+			return fmt.Errorf(errorConstant.HPARampStepReplicasInvalid, data.Name, data.MaxReplicas)
+		}
+	}
+	return nil
+}
+
+func (e *event) parseModifiedHPASteps(steps []request.EventHPAStepData) []UCEntity.HPAStepData {
+	var data []UCEntity.HPAStepData
+	for _, step := range steps {
+		data = append(
+			data, UCEntity.HPAStepData{
+				ExecuteAt:   *step.ExecuteAt,
+				MinReplicas: step.MinReplicas,
+				MaxReplicas: *step.MaxReplicas,
+			},
+		)
+	}
+	sort.Slice(
+		data, func(i, j int) bool {
+			return data[i].ExecuteAt.Before(data[j].ExecuteAt)
+		},
+	)
+	return data
+}
+
+func (e *event) parseHPASteps(steps []UCEntity.HPAStepData) []response.HPAStep {
+	var data []response.HPAStep
+	for _, step := range steps {
+		data = append(
+			data, response.HPAStep{
+				ID:          step.ID,
+				ExecuteAt:   step.ExecuteAt,
+				MinReplicas: step.MinReplicas,
+				MaxReplicas: step.MaxReplicas,
+				Status:      step.Status,
+				Message:     step.Message,
+			},
+		)
+	}
+	return data
+}
+
+func (e *event) parseModifiedWorkloadConfigs(
+	workloadConfigs []request.EventModifiedWorkloadConfigData,
+) []UCEntity.EventModifiedWorkloadConfigData {
+	var data []UCEntity.EventModifiedWorkloadConfigData
+	for _, workloadConfig := range workloadConfigs {
+		data = append(
+			data, UCEntity.EventModifiedWorkloadConfigData{
+				Kind:      *workloadConfig.Kind,
+				Name:      *workloadConfig.Name,
+				Namespace: *workloadConfig.Namespace,
+				Replicas:  *workloadConfig.Replicas,
+			},
+		)
+	}
+	return data
+}
+
+func (e *event) parseHPAMetricTargets(metricTargets []UCEntity.HPAMetricTargetData) []response.HPAMetricTarget {
+	var metricTargetsRes []response.HPAMetricTarget
+	for _, metricTarget := range metricTargets {
+		metricTargetsRes = append(
+			metricTargetsRes, response.HPAMetricTarget{
+				ResourceName:       metricTarget.ResourceName,
+				AverageUtilization: metricTarget.AverageUtilization,
+			},
+		)
+	}
+	return metricTargetsRes
+}
+
+func (e *event) parseModifiedHPAConfigResponses(
+	configs []UCEntity.EventModifiedHPAConfigData,
+) []response.ModifiedHPAConfig {
+	var modifiedHPAConfigRes []response.ModifiedHPAConfig
+	for _, hpa := range configs {
+		modifiedHPAConfigRes = append(
+			modifiedHPAConfigRes, response.ModifiedHPAConfig{
+				ID:                  hpa.ID,
+				Kind:                hpa.Kind,
+				Name:                hpa.Name,
+				Namespace:           hpa.Namespace,
+				MinReplicas:         hpa.MinReplicas,
+				MaxReplicas:         hpa.MaxReplicas,
+				Status:              hpa.Status,
+				Message:             hpa.Message,
+				OriginalMinReplicas: hpa.OriginalMinReplicas,
+				OriginalMaxReplicas: hpa.OriginalMaxReplicas,
+				Behavior:            hpa.Behavior,
+				MetricTargets:       e.parseHPAMetricTargets(hpa.MetricTargets),
+				RestoreStatus:       hpa.RestoreStatus,
+				RestoreMessage:      hpa.RestoreMessage,
+				RampUpSteps:         e.parseHPASteps(hpa.RampUpSteps),
+				RampDownSteps:       e.parseHPASteps(hpa.RampDownSteps),
+			},
+		)
+	}
+	return modifiedHPAConfigRes
+}
+
+func (e *event) parseModifiedWorkloadConfigResponses(
+	configs []UCEntity.EventModifiedWorkloadConfigData,
+) []response.ModifiedWorkloadConfig {
+	modifiedWorkloadConfigRes := make([]response.ModifiedWorkloadConfig, 0)
+	for _, workload := range configs {
+		modifiedWorkloadConfigRes = append(
+			modifiedWorkloadConfigRes, response.ModifiedWorkloadConfig{
+				ID:               workload.ID,
+				Kind:             workload.Kind,
+				Name:             workload.Name,
+				Namespace:        workload.Namespace,
+				Replicas:         workload.Replicas,
+				OriginalReplicas: workload.OriginalReplicas,
+				Status:           workload.Status,
+				Message:          workload.Message,
+				RestoreStatus:    workload.RestoreStatus,
+				RestoreMessage:   workload.RestoreMessage,
+			},
+		)
+	}
+	return modifiedWorkloadConfigRes
+}
+
+func (e *event) shiftHPASteps(steps []UCEntity.HPAStepData, shift time.Duration) []UCEntity.HPAStepData {
+	var data []UCEntity.HPAStepData
+	for _, step := range steps {
+		data = append(
+			data, UCEntity.HPAStepData{
+				ExecuteAt:   step.ExecuteAt.Add(shift),
+				MinReplicas: step.MinReplicas,
+				MaxReplicas: step.MaxReplicas,
+			},
+		)
+	}
+	return data
+}
+
+// parseStepLessModifiedHPAConfigs is used for configs that are not tied to event times yet
+func (e *event) parseStepLessModifiedHPAConfigs(
+	hpaConfigs []request.EventModifiedHPAConfigData,
+	stepUnsupportedErr string,
+) ([]UCEntity.EventModifiedHPAConfigData, error) {
+	var data []UCEntity.EventModifiedHPAConfigData
+	for _, hpaConfig := range hpaConfigs {
+		if len(hpaConfig.RampUpSteps) > 0 || len(hpaConfig.RampDownSteps) > 0 {
+			return nil, errors.New(stepUnsupportedErr)
+		}
+		HPAConfig, err := e.parseModifiedHPAConfig(hpaConfig, nil)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, HPAConfig)
+	}
+	return data, nil
+}
+
+// registerEventWithConfigs registers the event with the configs of HPAs that still exist in the cluster
+func (e *event) registerEventWithConfigs(
+	ctx context.Context,
+	db *gorm.DB,
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+	workloadConfigs []UCEntity.EventModifiedWorkloadConfigData,
+	mergeConflicts bool,
+) (*response.EventInstantiationResponse, *response.EventConflictResponse, error) {
+	kubernetesClient, clusterData, err := e.getClusterKubernetesClient(
+		ctx,
+		db,
+		eventData.Cluster.ID,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	HPAs, err := e.generalClusterUC.GetAllHPAInCluster(
+		ctx,
+		kubernetesClient,
+		clusterData.ID,
+		clusterData.LatestHPAAPIVersion,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &response.EventInstantiationResponse{MissingHPAs: make([]response.SimpleHPA, 0)}
+	var existingHPAConfigs []UCEntity.EventModifiedHPAConfigData
+	for _, hpaConfig := range hpaConfigs {
+		found := false
+		for _, HPA := range HPAs {
+			if hpaConfig.Kind == HPA.Kind && hpaConfig.Name == HPA.Name && hpaConfig.Namespace == HPA.Namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res.MissingHPAs = append(
+				res.MissingHPAs, response.SimpleHPA{
+					Kind:        hpaConfig.Kind,
+					Name:        hpaConfig.Name,
+					Namespace:   hpaConfig.Namespace,
+					MinReplicas: hpaConfig.MinReplicas,
+					MaxReplicas: hpaConfig.MaxReplicas,
+				},
+			)
+			continue
+		}
+		existingHPAConfigs = append(existingHPAConfigs, hpaConfig)
+	}
+
+	existingHPAConfigs, conflictRes, err := e.resolveEventConflicts(
+		tx,
+		eventData,
+		existingHPAConfigs,
+		mergeConflicts,
+	)
+	if err != nil || conflictRes != nil {
+		return nil, conflictRes, err
+	}
+
+	res.EventID, err = e.eventUC.RegisterEvents(tx, eventData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = e.scheduledHPAConfigUC.RegisterModifiedHPAConfigs(tx, existingHPAConfigs, res.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = e.scheduledWorkloadConfigUC.RegisterModifiedWorkloadConfigs(tx, workloadConfigs, res.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, nil, nil
+}
+
+// resolveEventConflicts returns the conflicts when the event conflicts with other events and merging is not requested
+func (e *event) resolveEventConflicts(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+	mergeConflicts bool,
+) ([]UCEntity.EventModifiedHPAConfigData, *response.EventConflictResponse, error) {
+	conflicts, err := e.eventUC.FindConflictingEvents(tx, eventData, hpaConfigs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(conflicts) == 0 {
+		return hpaConfigs, nil, nil
+	}
+
+	if mergeConflicts {
+		hpaConfigs, err = e.eventUC.MergeConflictingHPAConfigs(hpaConfigs, conflicts)
+		return hpaConfigs, nil, err
+	}
+
+	res := &response.EventConflictResponse{Message: errorConstant.EventConflict}
+	for _, conflict := range conflicts {
+		conflictRes := response.EventConflict{
+			EventID:         conflict.ID,
+			Name:            conflict.Name,
+			Status:          conflict.Status,
+			ExecuteConfigAt: conflict.ExecuteConfigAt,
+			StartTime:       conflict.StartTime,
+			EndTime:         conflict.EndTime,
+			HPAs:            make([]response.SimpleHPA, 0),
+			SharedNodePools: conflict.SharedNodePools,
+		}
+		for _, hpaConfig := range conflict.HPAConfigs {
+			conflictRes.HPAs = append(
+				conflictRes.HPAs, response.SimpleHPA{
+					Kind:        hpaConfig.Kind,
+					Name:        hpaConfig.Name,
+					Namespace:   hpaConfig.Namespace,
+					MinReplicas: hpaConfig.MinReplicas,
+					MaxReplicas: hpaConfig.MaxReplicas,
+				},
+			)
+		}
+		res.ConflictingEventIDs = append(res.ConflictingEventIDs, conflict.ID)
+		res.Conflicts = append(res.Conflicts, conflictRes)
+	}
+	return nil, res, nil
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"time"
+)
+
+func (e *event) RegisterEventTemplate(c *fiber.Ctx) error {
+	reqData := &request.EventTemplateDataRequest{}
+
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	if reqData.CalculateNodePool == nil {
+		active := true
+		reqData.CalculateNodePool = &active
+	}
+
+	hpaConfigs, err := e.parseStepLessModifiedHPAConfigs(
+		reqData.ModifiedHPAConfigs,
+		errorConstant.EventTemplateRampStepUnsupported,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.generalClusterUC.GetClusterAndDatacenterDataByClusterID(db, *reqData.ClusterID)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ClusterNotFound, reqData.ClusterID.String()))
+	}
+
+	eventTemplateID, err := e.eventTemplateUC.RegisterEventTemplate(
+		tx, &UCEntity.EventTemplate{
+			Name:                    *reqData.Name,
+			CalculateNodePool:       *reqData.CalculateNodePool,
+			ForceHPAApply:           reqData.ForceHPAApply != nil && *reqData.ForceHPAApply,
+			ClusterID:               *reqData.ClusterID,
+			ModifiedHPAConfigs:      hpaConfigs,
+			ModifiedWorkloadConfigs: e.parseModifiedWorkloadConfigs(reqData.ModifiedWorkloadConfigs),
+		},
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(
+		c,
+		response.EventTemplateCreationResponse{EventTemplateID: eventTemplateID},
+	)
+}
+
+func (e *event) ListEventTemplateByCluster(c *fiber.Ctx) error {
+	reqData := &request.EventTemplateListRequest{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	ctx := c.Context()
+	tx := e.db.WithContext(ctx)
+
+	eventTemplates, err := e.eventTemplateUC.ListEventTemplateByClusterID(tx, *reqData.ClusterID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	responseData := make([]response.EventTemplate, 0)
+	for _, eventTemplate := range eventTemplates {
+		responseData = append(responseData, e.parseEventTemplateResponse(eventTemplate))
+	}
+
+	return e.successResponse(c, responseData)
+}
+
+func (e *event) GetDetailedEventTemplate(c *fiber.Ctx) error {
+	eventTemplateIDStr := c.Params("event_template_id")
+	eventTemplateID, err := uuid.Parse(eventTemplateIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_template_id"))
+	}
+
+	ctx := c.Context()
+	tx := e.db.WithContext(ctx)
+
+	eventTemplate, err := e.eventTemplateUC.GetEventTemplateByID(tx, eventTemplateID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventTemplateNotExist)
+	}
+
+	return e.successResponse(c, e.parseEventTemplateResponse(eventTemplate))
+}
+
+func (e *event) DeleteEventTemplate(c *fiber.Ctx) error {
+	eventTemplateIDStr := c.Params("event_template_id")
+	eventTemplateID, err := uuid.Parse(eventTemplateIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_template_id"))
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.eventTemplateUC.GetEventTemplateByID(db, eventTemplateID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventTemplateNotExist)
+	}
+
+	err = e.eventTemplateUC.DeleteEventTemplate(tx, eventTemplateID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, constant.ActionDone)
+}
+
+func (e *event) InstantiateEventTemplate(c *fiber.Ctx) error {
+	eventTemplateIDStr := c.Params("event_template_id")
+	eventTemplateID, err := uuid.Parse(eventTemplateIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "event_template_id"))
+	}
+
+	reqData := &request.EventScheduleRequest{}
+	err = c.BodyParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	utcNow := time.Now().UTC()
+	if utcNow.After(*reqData.StartTime) || utcNow.After(*reqData.EndTime) {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	eventTemplate, err := e.eventTemplateUC.GetEventTemplateByID(db, eventTemplateID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.EventTemplateNotExist)
+	}
+
+	eventData := &UCEntity.Event{
+		Name:              *reqData.Name,
+		ExecuteConfigAt:   *reqData.ExecuteConfigAt,
+		WatchingAt:        *reqData.WatchingAt,
+		StartTime:         *reqData.StartTime,
+		EndTime:           *reqData.EndTime,
+		CalculateNodePool: eventTemplate.CalculateNodePool,
+		ForceHPAApply:     eventTemplate.ForceHPAApply,
+	}
+	eventData.Cluster.ID = eventTemplate.ClusterID
+
+	res, conflictRes, err := e.registerEventWithConfigs(
+		ctx,
+		db,
+		tx,
+		eventData,
+		eventTemplate.ModifiedHPAConfigs,
+		eventTemplate.ModifiedWorkloadConfigs,
+		reqData.MergeConflicts != nil && *reqData.MergeConflicts,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	if conflictRes != nil {
+		return e.errorResponse(c, conflictRes)
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, res)
+}
+
+func (e *event) parseEventTemplateResponse(eventTemplate *UCEntity.EventTemplate) response.EventTemplate {
+	return response.EventTemplate{
+		ID:                      eventTemplate.ID,
+		Name:                    eventTemplate.Name,
+		ClusterID:               eventTemplate.ClusterID,
+		CalculateNodePool:       eventTemplate.CalculateNodePool,
+		ForceHPAApply:           eventTemplate.ForceHPAApply,
+		CreatedAt:               eventTemplate.CreatedAt,
+		UpdatedAt:               eventTemplate.UpdatedAt,
+		ModifiedHPAConfigs:      e.parseModifiedHPAConfigResponses(eventTemplate.ModifiedHPAConfigs),
+		ModifiedWorkloadConfigs: e.parseModifiedWorkloadConfigResponses(eventTemplate.ModifiedWorkloadConfigs),
+	}
+}
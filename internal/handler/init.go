@@ -6,16 +6,25 @@ import (
 )
 
 type Handlers struct {
-	GcpHandler     Gcp
-	ClusterHandler Cluster
-	EventHandler   Event
+	GcpHandler        Gcp
+	AwsHandler        Aws
+	AzureHandler      Azure
+	KubeconfigHandler Kubeconfig
+	ClusterHandler    Cluster
+	EventHandler      Event
 }
 
 func BuildHandlers(useCases *useCase.UseCases, resources *config.KubeEPResources) *Handlers {
 	kubernetesBaseHandler := kubernetesBaseHandler{
-		generalClusterUC: useCases.Cluster,
-		gcpClusterUC:     useCases.GcpCluster,
-		gcpDatacenterUC:  useCases.GcpDatacenter,
+		generalClusterUC:       useCases.Cluster,
+		gcpClusterUC:           useCases.GcpCluster,
+		gcpDatacenterUC:        useCases.GcpDatacenter,
+		awsClusterUC:           useCases.AwsCluster,
+		awsDatacenterUC:        useCases.AwsDatacenter,
+		azureClusterUC:         useCases.AzureCluster,
+		azureDatacenterUC:      useCases.AzureDatacenter,
+		kubeconfigClusterUC:    useCases.KubeconfigCluster,
+		kubeconfigDatacenterUC: useCases.KubeconfigDatacenter,
 	}
 	return &Handlers{
 		GcpHandler: newGCPHandler(
@@ -25,6 +34,27 @@ func BuildHandlers(useCases *useCase.UseCases, resources *config.KubeEPResources
 			resources.DB,
 			useCases.Cluster,
 		),
+		AwsHandler: newAWSHandler(
+			resources.ValidatorInst,
+			useCases.AwsCluster,
+			useCases.AwsDatacenter,
+			resources.DB,
+			useCases.Cluster,
+		),
+		AzureHandler: newAzureHandler(
+			resources.ValidatorInst,
+			useCases.AzureCluster,
+			useCases.AzureDatacenter,
+			resources.DB,
+			useCases.Cluster,
+		),
+		KubeconfigHandler: newKubeconfigHandler(
+			resources.ValidatorInst,
+			useCases.KubeconfigCluster,
+			useCases.KubeconfigDatacenter,
+			resources.DB,
+			useCases.Cluster,
+		),
 		ClusterHandler: newClusterHandler(
 			resources.ValidatorInst,
 			resources.DB,
@@ -35,7 +65,14 @@ func BuildHandlers(useCases *useCase.UseCases, resources *config.KubeEPResources
 			resources.ValidatorInst,
 			useCases.Event,
 			useCases.ScheduledHPAConfig,
+			useCases.ScheduledWorkloadConfig,
 			useCases.UpdatedNodePool,
+			useCases.GcpEvent,
+			useCases.AwsEvent,
+			useCases.AzureEvent,
+			useCases.KubeconfigEvent,
+			useCases.RecurringEvent,
+			useCases.EventTemplate,
 			resources.DB,
 			kubernetesBaseHandler,
 		),
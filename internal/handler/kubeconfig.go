@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	useCase "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/usecase"
+	"gorm.io/gorm"
+)
+
+type Kubeconfig interface {
+	RegisterDatacenter(c *fiber.Ctx) error
+	GetClustersByDatacenterID(c *fiber.Ctx) error
+	RegisterClusterWithDatacenter(c *fiber.Ctx) error
+	UpdateCapacityBackend(c *fiber.Ctx) error
+}
+
+type kubeconfig struct {
+	baseHandler
+	validatorInst    *validator.Validate
+	clusterUC        useCase.KubeconfigCluster
+	generalClusterUC useCase.Cluster
+	datacenterUC     useCase.KubeconfigDatacenter
+	db               *gorm.DB
+}
+
+func newKubeconfigHandler(
+	validatorInst *validator.Validate,
+	clusterUC useCase.KubeconfigCluster,
+	datacenterUC useCase.KubeconfigDatacenter,
+	db *gorm.DB,
+	generalClusterUC useCase.Cluster,
+) Kubeconfig {
+
+	return &kubeconfig{
+		validatorInst:    validatorInst,
+		clusterUC:        clusterUC,
+		datacenterUC:     datacenterUC,
+		generalClusterUC: generalClusterUC,
+		db:               db,
+	}
+}
+
+func (k *kubeconfig) RegisterDatacenter(c *fiber.Ctx) error {
+	reqData := &request.KubeconfigDatacenterData{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return k.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	err = k.validatorInst.Struct(reqData)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+	ctx := c.Context()
+	tx := k.db.WithContext(ctx)
+
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: *reqData.KubeconfigCredentials,
+		Name:        *reqData.Name,
+	}
+	credentials, err := k.datacenterUC.ParseCredentials(datacenterData)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+	var id uuid.UUID
+	if *reqData.IsTemporary {
+		id, err = k.datacenterUC.SaveTemporaryDatacenter(ctx, datacenterData, credentials)
+	} else {
+		id, err = k.datacenterUC.SaveDatacenter(tx, datacenterData, credentials)
+	}
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	return k.successResponse(
+		c,
+		response.KubeconfigDatacenterData{DatacenterID: id, IsTemporary: *reqData.IsTemporary},
+	)
+}
+
+func (k *kubeconfig) GetClustersByDatacenterID(c *fiber.Ctx) error {
+	reqData := &request.KubeconfigExistingDatacenterData{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return k.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+	err = k.validatorInst.Struct(reqData)
+	if err != nil {
+		return k.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	ctx := c.Context()
+	tx := k.db.WithContext(ctx)
+
+	isTemporaryDatacenter := true
+	data, err := k.datacenterUC.GetTemporaryDatacenterData(ctx, *reqData.DatacenterID)
+	if err != nil {
+		isTemporaryDatacenter = false
+		data, err = k.datacenterUC.GetDatacenterData(tx, *reqData.DatacenterID)
+		if err != nil {
+			return k.errorResponse(c, err.Error())
+		}
+	}
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: data.Credentials,
+		Name:        data.Name,
+	}
+	credentials, err := k.datacenterUC.ParseCredentials(datacenterData)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+	clusters, err := k.clusterUC.GetAllClustersInKubeconfig(datacenterData.Name, credentials)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	clusterData := make([]response.KubeconfigCluster, 0)
+	for _, cluster := range clusters {
+		clusterData = append(
+			clusterData, response.KubeconfigCluster{
+				Cluster: response.Cluster{
+					Name:           cluster.Name,
+					Datacenter:     model.Kubeconfig,
+					DatacenterName: data.Name,
+				},
+				Context: cluster.Context,
+			},
+		)
+	}
+
+	return k.successResponse(
+		c, response.KubeconfigDatacenterClusters{
+			Clusters:              clusterData,
+			IsTemporaryDatacenter: isTemporaryDatacenter,
+		},
+	)
+}
+
+func (k *kubeconfig) RegisterClusterWithDatacenter(c *fiber.Ctx) error {
+	reqData := &request.KubeconfigRegisterClusterData{}
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return k.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	err = k.validatorInst.Struct(reqData)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	ctx := c.Context()
+	tx := k.db.WithContext(ctx)
+
+	var data *UCEntity.DatacenterDetailedData
+	if *reqData.IsDatacenterTemporary {
+		data, err = k.datacenterUC.GetTemporaryDatacenterData(ctx, *reqData.DatacenterID)
+	} else {
+		data, err = k.datacenterUC.GetDatacenterData(tx, *reqData.DatacenterID)
+	}
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+	datacenterData := UCEntity.DatacenterData{
+		Credentials: data.Credentials,
+		Name:        data.Name,
+	}
+	credentials, err := k.datacenterUC.ParseCredentials(datacenterData)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+	clusters, err := k.clusterUC.GetAllClustersInKubeconfig(datacenterData.Name, credentials)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	existingCluster, err := k.generalClusterUC.GetAllClustersInLocalByDatacenterID(
+		tx,
+		*reqData.DatacenterID,
+	)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	var selectedClusters []*UCEntity.KubeconfigClusterData
+	for _, clusterName := range reqData.ClustersName {
+		for _, cluster := range existingCluster {
+			if cluster.Name == clusterName {
+				return k.errorResponse(c, fmt.Sprintf(errorConstant.ClusterExists, clusterName))
+			}
+		}
+
+		contains := false
+		for _, cluster := range clusters {
+			if cluster.Name == clusterName {
+				cluster.CapacityBackend = reqData.CapacityBackends[clusterName]
+				if cluster.CapacityBackend != "" {
+					err := k.clusterUC.ValidateCapacityBackend(cluster.CapacityBackend)
+					if err != nil {
+						return k.errorResponse(c, err.Error())
+					}
+				}
+				selectedClusters = append(selectedClusters, cluster)
+				contains = true
+				break
+			}
+		}
+		if !contains {
+			return k.errorResponse(c, fmt.Sprintf(errorConstant.ClusterNotFound, clusterName))
+		}
+	}
+
+	for _, cluster := range selectedClusters {
+		kubernetesClient, err := k.clusterUC.GetKubernetesClusterClient(
+			credentials,
+			&cluster.ClusterData,
+		)
+		if err != nil {
+			return k.errorResponse(c, err.Error())
+		}
+		latestHPAAPIVersion, err := k.generalClusterUC.GetLatestHPAAPIVersion(kubernetesClient)
+		if err != nil {
+			return k.errorResponse(c, err.Error())
+		}
+		cluster.LatestHPAAPIVersion = latestHPAAPIVersion
+	}
+
+	tx = tx.Begin()
+
+	if *reqData.IsDatacenterTemporary {
+		_, err = k.datacenterUC.SaveDatacenterDetailedData(tx, data)
+		if err != nil {
+			return k.errorResponse(c, err.Error())
+		}
+	}
+
+	err = k.clusterUC.RegisterClusters(tx, *reqData.DatacenterID, selectedClusters)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	responses := make([]response.KubeconfigCluster, 0)
+	for _, cluster := range selectedClusters {
+		responses = append(
+			responses, response.KubeconfigCluster{
+				Cluster: response.Cluster{
+					ID:             &cluster.ID,
+					Name:           cluster.Name,
+					Datacenter:     model.Kubeconfig,
+					DatacenterName: data.Name,
+				},
+				Context:         cluster.Context,
+				CapacityBackend: cluster.CapacityBackend,
+			},
+		)
+	}
+
+	return k.successResponse(c, responses)
+}
+
+func (k *kubeconfig) UpdateCapacityBackend(c *fiber.Ctx) error {
+	clusterIDStr := c.Params("cluster_id")
+	clusterID, err := uuid.Parse(clusterIDStr)
+	if err != nil {
+		return k.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "cluster_id"))
+	}
+
+	reqData := &request.KubeconfigCapacityBackendData{}
+	err = c.BodyParser(reqData)
+	if err != nil {
+		return k.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+	err = k.validatorInst.Struct(reqData)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	ctx := c.Context()
+	tx := k.db.WithContext(ctx)
+
+	clusterData, err := k.generalClusterUC.GetClusterAndDatacenterDataByClusterID(tx, clusterID)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	err = k.clusterUC.UpdateCapacityBackend(tx, clusterData, *reqData.CapacityBackend)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	metadata, err := k.clusterUC.GetKubeconfigClusterMetaData(clusterData)
+	if err != nil {
+		return k.errorResponse(c, err.Error())
+	}
+
+	return k.successResponse(
+		c, response.KubeconfigCluster{
+			Cluster: response.Cluster{
+				ID:             &clusterData.ID,
+				Name:           clusterData.Name,
+				Datacenter:     model.Kubeconfig,
+				DatacenterName: clusterData.Datacenter.Name,
+			},
+			Context:         metadata.Context,
+			CapacityBackend: *reqData.CapacityBackend,
+		},
+	)
+}
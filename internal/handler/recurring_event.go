@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/request"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/response"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"time"
+)
+
+func (e *event) RegisterRecurringEvent(c *fiber.Ctx) error {
+	reqData := &request.RecurringEventDataRequest{}
+
+	err := c.BodyParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	if reqData.CalculateNodePool == nil {
+		active := true
+		reqData.CalculateNodePool = &active
+	}
+
+	nodePoolDistribution := model.NodePoolDistributionProportional
+	if reqData.NodePoolDistribution != nil {
+		nodePoolDistribution = model.NodePoolDistribution(*reqData.NodePoolDistribution)
+	}
+	primaryNodePool := ""
+	if reqData.PrimaryNodePool != nil {
+		primaryNodePool = *reqData.PrimaryNodePool
+	}
+	if nodePoolDistribution == model.NodePoolDistributionPrimaryPool && primaryNodePool == "" {
+		return e.errorResponse(c, errorConstant.PrimaryNodePoolRequired)
+	}
+
+	timezone := "UTC"
+	if reqData.Timezone != nil {
+		timezone = *reqData.Timezone
+	}
+
+	hpaConfigs, err := e.parseStepLessModifiedHPAConfigs(
+		reqData.ModifiedHPAConfigs,
+		errorConstant.RecurringEventRampStepUnsupported,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.generalClusterUC.GetClusterAndDatacenterDataByClusterID(db, *reqData.ClusterID)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ClusterNotFound, reqData.ClusterID.String()))
+	}
+
+	recurringEventID, err := e.recurringEventUC.RegisterRecurringEvent(
+		tx, &UCEntity.RecurringEvent{
+			Name:                       *reqData.Name,
+			CronExpression:             *reqData.CronExpression,
+			Timezone:                   timezone,
+			DurationMinutes:            *reqData.DurationMinutes,
+			ExecuteConfigOffsetMinutes: *reqData.ExecuteConfigOffsetMinutes,
+			WatchingOffsetMinutes:      *reqData.WatchingOffsetMinutes,
+			CalculateNodePool:          *reqData.CalculateNodePool,
+			ForceHPAApply:              reqData.ForceHPAApply != nil && *reqData.ForceHPAApply,
+			NodePoolDistribution:       nodePoolDistribution,
+			PrimaryNodePool:            primaryNodePool,
+			MergeConflicts:             reqData.MergeConflicts != nil && *reqData.MergeConflicts,
+			ClusterID:                  *reqData.ClusterID,
+			ModifiedHPAConfigs:         hpaConfigs,
+			ModifiedWorkloadConfigs:    e.parseModifiedWorkloadConfigs(reqData.ModifiedWorkloadConfigs),
+		},
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(
+		c,
+		response.RecurringEventCreationResponse{RecurringEventID: recurringEventID},
+	)
+}
+
+func (e *event) ListRecurringEventByCluster(c *fiber.Ctx) error {
+	reqData := &request.RecurringEventListRequest{}
+	err := c.QueryParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	ctx := c.Context()
+	tx := e.db.WithContext(ctx)
+
+	recurringEvents, err := e.recurringEventUC.ListRecurringEventByClusterID(tx, *reqData.ClusterID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	responseData := make([]response.RecurringEvent, 0)
+	for _, recurringEvent := range recurringEvents {
+		responseData = append(
+			responseData, response.RecurringEvent{
+				ID:                         recurringEvent.ID,
+				Name:                       recurringEvent.Name,
+				CronExpression:             recurringEvent.CronExpression,
+				Timezone:                   recurringEvent.Timezone,
+				DurationMinutes:            recurringEvent.DurationMinutes,
+				ExecuteConfigOffsetMinutes: recurringEvent.ExecuteConfigOffsetMinutes,
+				WatchingOffsetMinutes:      recurringEvent.WatchingOffsetMinutes,
+				CalculateNodePool:          recurringEvent.CalculateNodePool,
+				ForceHPAApply:              recurringEvent.ForceHPAApply,
+				NodePoolDistribution:       recurringEvent.NodePoolDistribution,
+				PrimaryNodePool:            recurringEvent.PrimaryNodePool,
+				MergeConflicts:             recurringEvent.MergeConflicts,
+				CreatedAt:                  recurringEvent.CreatedAt,
+				UpdatedAt:                  recurringEvent.UpdatedAt,
+				ModifiedHPAConfigs:         e.parseModifiedHPAConfigResponses(recurringEvent.ModifiedHPAConfigs),
+				ModifiedWorkloadConfigs: e.parseModifiedWorkloadConfigResponses(
+					recurringEvent.ModifiedWorkloadConfigs,
+				),
+			},
+		)
+	}
+
+	return e.successResponse(c, responseData)
+}
+
+func (e *event) ListRecurringEventOccurrences(c *fiber.Ctx) error {
+	recurringEventIDStr := c.Params("recurring_event_id")
+	recurringEventID, err := uuid.Parse(recurringEventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "recurring_event_id"))
+	}
+
+	reqData := &request.RecurringEventOccurrenceListRequest{}
+	err = c.QueryParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidQueryParam)
+	}
+
+	count := constant.RecurringEventDefaultOccurrenceCount
+	if reqData.Count != nil {
+		count = *reqData.Count
+	}
+
+	ctx := c.Context()
+	tx := e.db.WithContext(ctx)
+
+	_, err = e.recurringEventUC.GetRecurringEventByID(tx, recurringEventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.RecurringEventNotExist)
+	}
+
+	occurrences, err := e.recurringEventUC.ListUpcomingOccurrences(
+		tx,
+		recurringEventID,
+		time.Now(),
+		count,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	responseData := make([]response.RecurringEventOccurrence, 0)
+	for _, occurrence := range occurrences {
+		responseData = append(
+			responseData, response.RecurringEventOccurrence{
+				StartTime:          occurrence.StartTime,
+				EndTime:            occurrence.EndTime,
+				ExecuteConfigAt:    occurrence.ExecuteConfigAt,
+				WatchingAt:         occurrence.WatchingAt,
+				Skipped:            occurrence.Skipped,
+				ModifiedHPAConfigs: e.parseModifiedHPAConfigResponses(occurrence.ModifiedHPAConfigs),
+				ModifiedWorkloadConfigs: e.parseModifiedWorkloadConfigResponses(
+					occurrence.ModifiedWorkloadConfigs,
+				),
+				EventID: occurrence.EventID,
+			},
+		)
+	}
+
+	return e.successResponse(c, responseData)
+}
+
+func (e *event) UpdateRecurringEventOccurrence(c *fiber.Ctx) error {
+	recurringEventIDStr := c.Params("recurring_event_id")
+	recurringEventID, err := uuid.Parse(recurringEventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "recurring_event_id"))
+	}
+
+	reqData := &request.RecurringEventOccurrenceRequest{}
+	err = c.BodyParser(reqData)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+	err = e.validatorInst.Struct(reqData)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.InvalidRequestBody)
+	}
+
+	hpaConfigs, err := e.parseStepLessModifiedHPAConfigs(
+		reqData.ModifiedHPAConfigs,
+		errorConstant.RecurringEventRampStepUnsupported,
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.recurringEventUC.GetRecurringEventByID(db, recurringEventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.RecurringEventNotExist)
+	}
+
+	err = e.recurringEventUC.UpdateOccurrence(
+		tx, recurringEventID, UCEntity.RecurringEventOccurrence{
+			StartTime:               *reqData.StartTime,
+			Skipped:                 reqData.Skipped != nil && *reqData.Skipped,
+			ModifiedHPAConfigs:      hpaConfigs,
+			ModifiedWorkloadConfigs: e.parseModifiedWorkloadConfigs(reqData.ModifiedWorkloadConfigs),
+		},
+	)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, constant.ActionDone)
+}
+
+func (e *event) DeleteRecurringEvent(c *fiber.Ctx) error {
+	recurringEventIDStr := c.Params("recurring_event_id")
+	recurringEventID, err := uuid.Parse(recurringEventIDStr)
+	if err != nil {
+		return e.errorResponse(c, fmt.Sprintf(errorConstant.ParamInvalid, "recurring_event_id"))
+	}
+
+	ctx := c.Context()
+	db := e.db.WithContext(ctx)
+	tx := db.Begin()
+
+	_, err = e.recurringEventUC.GetRecurringEventByID(db, recurringEventID)
+	if err != nil {
+		return e.errorResponse(c, errorConstant.RecurringEventNotExist)
+	}
+
+	err = e.recurringEventUC.DeleteRecurringEvent(tx, recurringEventID)
+	if err != nil {
+		return e.errorResponse(c, err.Error())
+	}
+
+	tx.Commit()
+
+	return e.successResponse(c, constant.ActionDone)
+}
@@ -0,0 +1,117 @@
+package awsCustomAuth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"golang.org/x/oauth2"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	CredentialsNameConfigKey = "credentials_name"
+	ClusterNameConfigKey     = "cluster_name"
+	AuthName                 = "aws_custom"
+
+	clusterIDHeader = "x-k8s-aws-id"
+	tokenPrefix     = "k8s-aws-v1."
+	// EKS accepts presigned tokens for 15 minutes, refresh them a minute earlier
+	tokenExpiration = 14 * time.Minute
+)
+
+var (
+	lock sync.Mutex
+
+	credentialList = make(map[string]aws.Config)
+)
+
+func RegisterAWSCredentials(credentialsName string, credential aws.Config) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	credentialList[credentialsName] = credential
+}
+
+func RegisterK8SAWSCustomAuthProvider() {
+	if err := restclient.RegisterAuthProviderPlugin(
+		AuthName,
+		newAWSCustomAuthProvider,
+	); err != nil {
+		klog.Fatalf("Failed to register aws_custom auth plugin: %v", err)
+	}
+}
+
+type awsCustomAuthProvider struct {
+	tokenSource oauth2.TokenSource
+}
+
+func newAWSCustomAuthProvider(
+	_ string,
+	awsConfig map[string]string,
+	_ restclient.AuthProviderConfigPersister,
+) (restclient.AuthProvider, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	credentialsName := awsConfig[CredentialsNameConfigKey]
+	credentials, ok := credentialList[credentialsName]
+	if !ok {
+		return nil, errors.New("credentials not found")
+	}
+	clusterName := awsConfig[ClusterNameConfigKey]
+	if clusterName == "" {
+		return nil, errors.New("cluster name not found")
+	}
+	return &awsCustomAuthProvider{
+		oauth2.ReuseTokenSource(
+			nil, &eksTokenSource{
+				presignClient: sts.NewPresignClient(sts.NewFromConfig(credentials)),
+				clusterName:   clusterName,
+			},
+		),
+	}, nil
+}
+
+func (a *awsCustomAuthProvider) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &oauth2.Transport{Source: a.tokenSource, Base: rt}
+}
+
+func (a *awsCustomAuthProvider) Login() error { return nil }
+
+type eksTokenSource struct {
+	presignClient *sts.PresignClient
+	clusterName   string
+}
+
+func (s *eksTokenSource) Token() (*oauth2.Token, error) {
+	presignedRequest, err := s.presignClient.PresignGetCallerIdentity(
+		context.Background(),
+		&sts.GetCallerIdentityInput{},
+		func(options *sts.PresignOptions) {
+			options.ClientOptions = append(
+				options.ClientOptions, func(options *sts.Options) {
+					options.APIOptions = append(
+						options.APIOptions,
+						smithyhttp.AddHeaderValue(clusterIDHeader, s.clusterName),
+					)
+				},
+			)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: tokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presignedRequest.URL)),
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(tokenExpiration),
+	}, nil
+}
@@ -11,6 +11,7 @@ type Credentials struct {
 	Certificate        string
 	Name               string
 	ServerEndpoint     string
+	Token              string
 	AuthProviderConfig *api.AuthProviderConfig
 }
 
@@ -41,6 +42,7 @@ func GetClient(credentials *Credentials) (*kubernetes.Clientset, error) {
 	}
 
 	kubernetesConfig.AuthInfos[name] = &api.AuthInfo{
+		Token:        credentials.Token,
 		AuthProvider: credentials.AuthProviderConfig,
 	}
 
@@ -62,3 +64,28 @@ func GetClient(credentials *Credentials) (*kubernetes.Clientset, error) {
 
 	return k8sClient, nil
 }
+
+func GetClientFromKubeconfig(kubeconfig []byte, contextName string) (*kubernetes.Clientset, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := clientcmd.
+		NewNonInteractiveClientConfig(
+			*config,
+			contextName,
+			&clientcmd.ConfigOverrides{CurrentContext: contextName},
+			nil,
+		).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return k8sClient, nil
+}
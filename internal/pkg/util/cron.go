@@ -0,0 +1,157 @@
+package util
+
+import (
+	"fmt"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type cronField struct {
+	min, max int
+}
+
+var (
+	cronMinute     = cronField{min: 0, max: 59}
+	cronHour       = cronField{min: 0, max: 23}
+	cronDayOfMonth = cronField{min: 1, max: 31}
+	cronMonth      = cronField{min: 1, max: 12}
+	cronDayOfWeek  = cronField{min: 0, max: 7}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule is a standard five field cron expression (minute hour day-of-month month day-of-week)
+type CronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	dayOfMonthStar, dayOfWeekStar              bool
+}
+
+func ParseCronExpression(expression string) (*CronSchedule, error) {
+	normalized := strings.TrimSpace(expression)
+	if descriptor, ok := cronDescriptors[normalized]; ok {
+		normalized = descriptor
+	}
+	fields := strings.Fields(normalized)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf(errorConstant.CronExpressionInvalid, expression)
+	}
+
+	schedule := &CronSchedule{
+		dayOfMonthStar: fields[2] == "*",
+		dayOfWeekStar:  fields[4] == "*",
+	}
+	var err error
+	for i, target := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&schedule.minute, cronMinute},
+		{&schedule.hour, cronHour},
+		{&schedule.dayOfMonth, cronDayOfMonth},
+		{&schedule.month, cronMonth},
+		{&schedule.dayOfWeek, cronDayOfWeek},
+	} {
+		*target.bits, err = parseCronField(fields[i], target.field)
+		if err != nil {
+			return nil, fmt.Errorf(errorConstant.CronExpressionInvalid, expression)
+		}
+	}
+	// Sunday can be written as 0 or 7
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			parsedStep, err := strconv.Atoi(part[i+1:])
+			if err != nil || parsedStep <= 0 {
+				return 0, fmt.Errorf("invalid step %s", part)
+			}
+			step = parsedStep
+		}
+
+		start, end := field.min, field.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, err
+			}
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, err
+			}
+		default:
+			parsed, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			start = parsed
+			if step == 1 {
+				end = parsed
+			}
+		}
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("value %s out of range", part)
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	// When both day fields are restricted a time matches either of them
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Next returns the first activation strictly after t in the location of t,
+// or the zero time when there is none within five years
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
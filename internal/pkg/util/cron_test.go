@@ -0,0 +1,160 @@
+package util
+
+import (
+	"fmt"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"testing"
+	"time"
+)
+
+func TestParseCronExpressionInvalid(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+	}{
+		{name: "empty", expression: ""},
+		{name: "missing field", expression: "* * * *"},
+		{name: "extra field", expression: "0 * * * * *"},
+		{name: "unknown descriptor", expression: "@weekdays"},
+		{name: "minute out of range", expression: "60 * * * *"},
+		{name: "hour out of range", expression: "0 24 * * *"},
+		{name: "day of month below range", expression: "0 0 0 * *"},
+		{name: "month out of range", expression: "0 0 1 13 *"},
+		{name: "day of week out of range", expression: "0 0 * * 8"},
+		{name: "reversed range", expression: "0 0 * * 5-1"},
+		{name: "zero step", expression: "*/0 * * * *"},
+		{name: "negative step", expression: "*/-5 * * * *"},
+		{name: "not a number", expression: "a * * * *"},
+		{name: "empty list item", expression: "1,,2 * * * *"},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				_, err := ParseCronExpression(testCase.expression)
+				if err == nil {
+					t.Fatalf("expected %q to be rejected", testCase.expression)
+				}
+				expected := fmt.Sprintf(errorConstant.CronExpressionInvalid, testCase.expression)
+				if err.Error() != expected {
+					t.Fatalf("expected error %q, got %q", expected, err.Error())
+				}
+			},
+		)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2026-10-16 is a Friday
+	from := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC)
+	testCases := []struct {
+		name       string
+		expression string
+		from       time.Time
+		expected   time.Time
+	}{
+		{
+			name:       "every minute is strictly after the current minute",
+			expression: "* * * * *",
+			from:       from,
+			expected:   time.Date(2026, 10, 16, 10, 8, 0, 0, time.UTC),
+		},
+		{
+			name:       "step",
+			expression: "*/15 * * * *",
+			from:       from,
+			expected:   time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:       "step from a start value",
+			expression: "5/20 * * * *",
+			from:       from,
+			expected:   time.Date(2026, 10, 16, 10, 25, 0, 0, time.UTC),
+		},
+		{
+			name:       "list",
+			expression: "0 8,20 * * *",
+			from:       from,
+			expected:   time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "weekday range skips the weekend",
+			expression: "0 9 * * 1-5",
+			from:       from,
+			expected:   time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "sunday written as 7",
+			expression: "30 8 * * 7",
+			from:       from,
+			expected:   time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "sunday written as 0",
+			expression: "30 8 * * 0",
+			from:       from,
+			expected:   time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "restricted day of month and day of week match either",
+			expression: "0 0 15 * 1",
+			from:       from,
+			expected:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "day of month rolls over to the next month",
+			expression: "0 0 1 * *",
+			from:       from,
+			expected:   time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "month rolls over to the next year",
+			expression: "0 0 1 3 *",
+			from:       from,
+			expected:   time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "descriptor",
+			expression: "@daily",
+			from:       from,
+			expected:   time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "descriptor with surrounding spaces",
+			expression: "  @hourly ",
+			from:       from,
+			expected:   time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 2 *",
+			from:       from,
+			expected:   time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "never activating",
+			expression: "0 0 30 2 *",
+			from:       from,
+			expected:   time.Time{},
+		},
+		{
+			name:       "location of the given time",
+			expression: "0 9 * * *",
+			from:       time.Date(2026, 10, 16, 10, 0, 0, 0, time.FixedZone("WIB", 7*60*60)),
+			expected:   time.Date(2026, 10, 17, 9, 0, 0, 0, time.FixedZone("WIB", 7*60*60)),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				schedule, err := ParseCronExpression(testCase.expression)
+				if err != nil {
+					t.Fatalf("unexpected error : %s", err.Error())
+				}
+				next := schedule.Next(testCase.from)
+				if !next.Equal(testCase.expected) {
+					t.Fatalf("expected %s, got %s", testCase.expected, next)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,112 @@
+package util
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"google.golang.org/genproto/googleapis/container/v1"
+	v1Core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"math"
+)
+
+type reservationTier struct {
+	upTo     float64
+	fraction float64
+}
+
+var (
+	gkeCPUReservationTiers = []reservationTier{
+		{upTo: 1, fraction: 0.06},
+		{upTo: 2, fraction: 0.01},
+		{upTo: 4, fraction: 0.005},
+		{upTo: math.Inf(1), fraction: 0.0025},
+	}
+	gkeMemoryReservationTiers = []reservationTier{
+		{upTo: 4, fraction: 0.25},
+		{upTo: 8, fraction: 0.2},
+		{upTo: 16, fraction: 0.1},
+		{upTo: 128, fraction: 0.06},
+		{upTo: math.Inf(1), fraction: 0.02},
+	}
+)
+
+const (
+	gkeSmallMachineMemoryReservationMiB = 255
+	gkeEvictionThresholdMiB             = 100
+)
+
+func calculateTieredReservation(capacity float64, tiers []reservationTier) float64 {
+	reserved := float64(0)
+	lowerBound := float64(0)
+	for _, tier := range tiers {
+		if capacity <= lowerBound {
+			break
+		}
+		reserved += (math.Min(capacity, tier.upTo) - lowerBound) * tier.fraction
+		lowerBound = tier.upTo
+	}
+	return reserved
+}
+
+// GetGKENodeAllocatable returns the allocatable of a GKE node of the machine type after
+// the kubelet and system reservations and the eviction threshold
+func GetGKENodeAllocatable(guestCPUs, memoryMB int32, maxPods int64) v1Core.ResourceList {
+	cpuCores := float64(guestCPUs)
+	allocatableCPU := cpuCores - calculateTieredReservation(cpuCores, gkeCPUReservationTiers)
+
+	memoryMiB := float64(memoryMB)
+	reservedMemoryMiB := float64(gkeSmallMachineMemoryReservationMiB)
+	if memoryMiB >= 1024 {
+		reservedMemoryMiB = calculateTieredReservation(memoryMiB/1024, gkeMemoryReservationTiers) * 1024
+	}
+	allocatableMemoryMiB := memoryMiB - reservedMemoryMiB - gkeEvictionThresholdMiB
+
+	return v1Core.ResourceList{
+		v1Core.ResourceCPU: *resource.NewMilliQuantity(
+			int64(allocatableCPU*1000),
+			resource.DecimalSI,
+		),
+		v1Core.ResourceMemory: *resource.NewQuantity(
+			int64(allocatableMemoryMiB*1024*1024),
+			resource.BinarySI,
+		),
+		v1Core.ResourcePods: *resource.NewQuantity(maxPods, resource.DecimalSI),
+	}
+}
+
+var gkeTaintEffects = map[container.NodeTaint_Effect]v1Core.TaintEffect{
+	container.NodeTaint_NO_SCHEDULE:        v1Core.TaintEffectNoSchedule,
+	container.NodeTaint_PREFER_NO_SCHEDULE: v1Core.TaintEffectPreferNoSchedule,
+	container.NodeTaint_NO_EXECUTE:         v1Core.TaintEffectNoExecute,
+}
+
+// GetGKENodePoolTaints returns the taints of the node pool config,
+// GKE also taints every node of a node pool with GPUs attached
+func GetGKENodePoolTaints(config *container.NodeConfig) []v1Core.Taint {
+	taints := make([]v1Core.Taint, 0)
+	if config == nil {
+		return taints
+	}
+	for _, taint := range config.Taints {
+		effect, ok := gkeTaintEffects[taint.Effect]
+		if !ok {
+			continue
+		}
+		taints = append(
+			taints, v1Core.Taint{
+				Key:    taint.Key,
+				Value:  taint.Value,
+				Effect: effect,
+			},
+		)
+	}
+	if len(config.Accelerators) > 0 {
+		taints = append(
+			taints, v1Core.Taint{
+				Key:    constant.GKEGPUTaintKey,
+				Value:  constant.GKEGPUTaintValue,
+				Effect: v1Core.TaintEffectNoSchedule,
+			},
+		)
+	}
+	return taints
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"google.golang.org/genproto/googleapis/container/v1"
+	v1Core "k8s.io/api/core/v1"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGetGKENodeAllocatable(t *testing.T) {
+	testCases := []struct {
+		name      string
+		guestCPUs int32
+		memoryMB  int32
+		maxPods   int64
+		cpuMilli  int64
+		memoryMiB float64
+	}{
+		{
+			name:      "small machine memory reservation",
+			guestCPUs: 1,
+			memoryMB:  614,
+			maxPods:   110,
+			cpuMilli:  940,
+			memoryMiB: 614 - 255 - 100,
+		},
+		{
+			name:      "small machine memory reservation below 1 GiB",
+			guestCPUs: 2,
+			memoryMB:  1023,
+			maxPods:   110,
+			cpuMilli:  1930,
+			memoryMiB: 1023 - 255 - 100,
+		},
+		{
+			name:      "tiered memory reservation from 1 GiB",
+			guestCPUs: 2,
+			memoryMB:  1024,
+			maxPods:   110,
+			cpuMilli:  1930,
+			memoryMiB: 1024 - 256 - 100,
+		},
+		{
+			name:      "single CPU",
+			guestCPUs: 1,
+			memoryMB:  3840,
+			maxPods:   110,
+			cpuMilli:  940,
+			memoryMiB: 3840 - 960 - 100,
+		},
+		{
+			name:      "e2-standard-4",
+			guestCPUs: 4,
+			memoryMB:  16384,
+			maxPods:   110,
+			cpuMilli:  3920,
+			memoryMiB: 16384 - 2662.4 - 100,
+		},
+		{
+			name:      "e2-standard-8",
+			guestCPUs: 8,
+			memoryMB:  32768,
+			maxPods:   110,
+			cpuMilli:  7910,
+			memoryMiB: 32768 - 3645.44 - 100,
+		},
+		{
+			name:      "n2-standard-96 crosses every tier",
+			guestCPUs: 96,
+			memoryMB:  368640,
+			maxPods:   32,
+			cpuMilli:  95690,
+			memoryMiB: 368640 - 14295.04 - 100,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				allocatable := GetGKENodeAllocatable(testCase.guestCPUs, testCase.memoryMB, testCase.maxPods)
+				cpu := allocatable[v1Core.ResourceCPU]
+				if cpu.MilliValue() != testCase.cpuMilli {
+					t.Fatalf("expected %dm cpu, got %dm", testCase.cpuMilli, cpu.MilliValue())
+				}
+				memory := allocatable[v1Core.ResourceMemory]
+				memoryMiB := float64(memory.Value()) / (1024 * 1024)
+				if math.Abs(memoryMiB-testCase.memoryMiB) > 0.001 {
+					t.Fatalf("expected %f MiB memory, got %f MiB", testCase.memoryMiB, memoryMiB)
+				}
+				pods := allocatable[v1Core.ResourcePods]
+				if pods.Value() != testCase.maxPods {
+					t.Fatalf("expected %d pods, got %d", testCase.maxPods, pods.Value())
+				}
+			},
+		)
+	}
+}
+
+func TestGetGKENodePoolTaints(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *container.NodeConfig
+		expected []v1Core.Taint
+	}{
+		{
+			name:     "no config",
+			expected: []v1Core.Taint{},
+		},
+		{
+			name: "node pool taints",
+			config: &container.NodeConfig{
+				Taints: []*container.NodeTaint{
+					{Key: "dedicated", Value: "batch", Effect: container.NodeTaint_NO_EXECUTE},
+					{Key: "unknown", Value: "effect", Effect: container.NodeTaint_EFFECT_UNSPECIFIED},
+				},
+			},
+			expected: []v1Core.Taint{
+				{Key: "dedicated", Value: "batch", Effect: v1Core.TaintEffectNoExecute},
+			},
+		},
+		{
+			name: "GPU node pool",
+			config: &container.NodeConfig{
+				Accelerators: []*container.AcceleratorConfig{{AcceleratorCount: 1}},
+			},
+			expected: []v1Core.Taint{
+				{
+					Key:    constant.GKEGPUTaintKey,
+					Value:  constant.GKEGPUTaintValue,
+					Effect: v1Core.TaintEffectNoSchedule,
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				taints := GetGKENodePoolTaints(testCase.config)
+				if !reflect.DeepEqual(taints, testCase.expected) {
+					t.Fatalf("expected %v, got %v", testCase.expected, taints)
+				}
+			},
+		)
+	}
+}
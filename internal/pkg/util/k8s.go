@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
 	v1 "k8s.io/api/core/v1"
 	v1Core "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -16,10 +17,14 @@ func ParseGCPNodePoolByNodeName(projectName, nodeName string) string {
 	return re.ReplaceAllString(nodePoolName, "")
 }
 
+// CheckPodNodePoolMatch checks the pod node selector, required node affinity and tolerations against the node pool,
+// only NoSchedule and NoExecute taints keep pods out of the node pool
 func CheckPodNodePoolMatch(
 	nodeLabels labels.Set,
+	nodeTaints []v1.Taint,
 	podNodeAffinity *v1.NodeAffinity,
 	nodeSelector labels.Selector,
+	podTolerations []v1.Toleration,
 ) (res bool, err error) {
 	matchNodeSelector := nodeSelector.Matches(nodeLabels)
 	nodeData := &v1.Node{ObjectMeta: v1Core.ObjectMeta{Labels: nodeLabels}}
@@ -36,6 +41,88 @@ func CheckPodNodePoolMatch(
 		}
 	}
 
-	return matchNodeSelector && matchNodeAffinity, nil
+	_, hasUntoleratedTaint := corev1.FindMatchingUntoleratedTaint(
+		nodeTaints,
+		podTolerations,
+		func(t *v1.Taint) bool {
+			return t.Effect == v1.TaintEffectNoSchedule || t.Effect == v1.TaintEffectNoExecute
+		},
+	)
 
+	return matchNodeSelector && matchNodeAffinity && !hasUntoleratedTaint, nil
+
+}
+
+// GetNodePoolTaints drops the taints set on a node by the node controllers and cluster autoscaler
+func GetNodePoolTaints(nodeTaints []v1.Taint) []v1.Taint {
+	taints := make([]v1.Taint, 0)
+	for _, taint := range nodeTaints {
+		controllerTaint := false
+		for _, prefix := range constant.NodeControllerTaintPrefixes {
+			if strings.HasPrefix(taint.Key, prefix) {
+				controllerTaint = true
+			}
+		}
+		for _, key := range constant.ClusterAutoscalerTaintKeys {
+			if taint.Key == key {
+				controllerTaint = true
+			}
+		}
+		if !controllerTaint {
+			taints = append(taints, taint)
+		}
+	}
+	return taints
+}
+
+// GetHPAObjectName prefixes ScaledObject names so they never collide with an HPA of the same name
+func GetHPAObjectName(kind, name string) string {
+	if kind == constant.ScaledObject {
+		return fmt.Sprintf("%s/%s", kind, name)
+	}
+	return name
+}
+
+// GetPreferredNodeAffinityWeight sums the weights of the preferred node affinity terms matching the node pool
+func GetPreferredNodeAffinityWeight(nodeLabels labels.Set, podNodeAffinity *v1.NodeAffinity) (int32, error) {
+	if podNodeAffinity == nil {
+		return 0, nil
+	}
+	nodeData := &v1.Node{ObjectMeta: v1Core.ObjectMeta{Labels: nodeLabels}}
+	weight := int32(0)
+	for _, term := range podNodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		match, err := corev1.MatchNodeSelectorTerms(
+			nodeData,
+			&v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{term.Preference}},
+		)
+		if err != nil {
+			return 0, err
+		}
+		if match {
+			weight += term.Weight
+		}
+	}
+	return weight, nil
+}
+
+func isZoneTopologyKey(topologyKey string) bool {
+	return topologyKey == v1.LabelTopologyZone || topologyKey == v1.LabelFailureDomainBetaZone
+}
+
+// IsPodSpreadAcrossZones checks whether the scheduler has to balance the pods between zones,
+// either by a DoNotSchedule zone topology spread constraint or a required zone pod anti-affinity
+func IsPodSpreadAcrossZones(podSpec v1.PodSpec) bool {
+	for _, constraint := range podSpec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable == v1.DoNotSchedule && isZoneTopologyKey(constraint.TopologyKey) {
+			return true
+		}
+	}
+	if podSpec.Affinity != nil && podSpec.Affinity.PodAntiAffinity != nil {
+		for _, term := range podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if isZoneTopologyKey(term.TopologyKey) {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,166 @@
+package util
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"testing"
+)
+
+func TestCheckPodNodePoolMatch(t *testing.T) {
+	nodeLabels := labels.Set{"pool": "gpu", "zone": "zone-a"}
+	gpuTaint := v1.Taint{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule}
+	testCases := []struct {
+		name            string
+		nodeTaints      []v1.Taint
+		podNodeAffinity *v1.NodeAffinity
+		nodeSelector    labels.Selector
+		podTolerations  []v1.Toleration
+		expected        bool
+	}{
+		{
+			name:         "no constraints",
+			nodeSelector: labels.Everything(),
+			expected:     true,
+		},
+		{
+			name:         "matching node selector",
+			nodeSelector: labels.SelectorFromSet(labels.Set{"pool": "gpu"}),
+			expected:     true,
+		},
+		{
+			name:         "unmatched node selector",
+			nodeSelector: labels.SelectorFromSet(labels.Set{"pool": "cpu"}),
+			expected:     false,
+		},
+		{
+			name: "unmatched required node affinity",
+			podNodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{
+							MatchExpressions: []v1.NodeSelectorRequirement{
+								{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"zone-b"}},
+							},
+						},
+					},
+				},
+			},
+			nodeSelector: labels.Everything(),
+			expected:     false,
+		},
+		{
+			name:         "untolerated NoSchedule taint",
+			nodeTaints:   []v1.Taint{gpuTaint},
+			nodeSelector: labels.Everything(),
+			expected:     false,
+		},
+		{
+			name:         "untolerated NoExecute taint",
+			nodeTaints:   []v1.Taint{{Key: "dedicated", Value: "batch", Effect: v1.TaintEffectNoExecute}},
+			nodeSelector: labels.Everything(),
+			expected:     false,
+		},
+		{
+			name:         "untolerated PreferNoSchedule taint",
+			nodeTaints:   []v1.Taint{{Key: "dedicated", Value: "batch", Effect: v1.TaintEffectPreferNoSchedule}},
+			nodeSelector: labels.Everything(),
+			expected:     true,
+		},
+		{
+			name:         "taint tolerated by value",
+			nodeTaints:   []v1.Taint{gpuTaint},
+			nodeSelector: labels.Everything(),
+			podTolerations: []v1.Toleration{
+				{Key: "gpu", Operator: v1.TolerationOpEqual, Value: "true", Effect: v1.TaintEffectNoSchedule},
+			},
+			expected: true,
+		},
+		{
+			name:         "taint tolerated by a different value",
+			nodeTaints:   []v1.Taint{gpuTaint},
+			nodeSelector: labels.Everything(),
+			podTolerations: []v1.Toleration{
+				{Key: "gpu", Operator: v1.TolerationOpEqual, Value: "false", Effect: v1.TaintEffectNoSchedule},
+			},
+			expected: false,
+		},
+		{
+			name:         "taint tolerated by key existence for every effect",
+			nodeTaints:   []v1.Taint{gpuTaint},
+			nodeSelector: labels.Everything(),
+			podTolerations: []v1.Toleration{
+				{Key: "gpu", Operator: v1.TolerationOpExists},
+			},
+			expected: true,
+		},
+		{
+			name:         "taint tolerated for another effect",
+			nodeTaints:   []v1.Taint{gpuTaint},
+			nodeSelector: labels.Everything(),
+			podTolerations: []v1.Toleration{
+				{Key: "gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+			},
+			expected: false,
+		},
+		{
+			name:         "every taint tolerated by an empty key",
+			nodeTaints:   []v1.Taint{gpuTaint, {Key: "dedicated", Effect: v1.TaintEffectNoExecute}},
+			nodeSelector: labels.Everything(),
+			podTolerations: []v1.Toleration{
+				{Operator: v1.TolerationOpExists},
+			},
+			expected: true,
+		},
+		{
+			name:         "only some taints tolerated",
+			nodeTaints:   []v1.Taint{gpuTaint, {Key: "dedicated", Effect: v1.TaintEffectNoExecute}},
+			nodeSelector: labels.Everything(),
+			podTolerations: []v1.Toleration{
+				{Key: "gpu", Operator: v1.TolerationOpExists},
+			},
+			expected: false,
+		},
+		{
+			name:         "tolerated taint with unmatched node selector",
+			nodeTaints:   []v1.Taint{gpuTaint},
+			nodeSelector: labels.SelectorFromSet(labels.Set{"pool": "cpu"}),
+			podTolerations: []v1.Toleration{
+				{Key: "gpu", Operator: v1.TolerationOpExists},
+			},
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				res, err := CheckPodNodePoolMatch(
+					nodeLabels,
+					testCase.nodeTaints,
+					testCase.podNodeAffinity,
+					testCase.nodeSelector,
+					testCase.podTolerations,
+				)
+				if err != nil {
+					t.Fatalf("unexpected error : %s", err.Error())
+				}
+				if res != testCase.expected {
+					t.Fatalf("expected %t, got %t", testCase.expected, res)
+				}
+			},
+		)
+	}
+}
+
+func TestGetNodePoolTaints(t *testing.T) {
+	taints := GetNodePoolTaints(
+		[]v1.Taint{
+			{Key: "node.kubernetes.io/unschedulable", Effect: v1.TaintEffectNoSchedule},
+			{Key: "node.cloudprovider.kubernetes.io/uninitialized", Effect: v1.TaintEffectNoSchedule},
+			{Key: "ToBeDeletedByClusterAutoscaler", Effect: v1.TaintEffectNoSchedule},
+			{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule},
+		},
+	)
+	if len(taints) != 1 || taints[0].Key != "gpu" {
+		t.Fatalf("expected only the gpu taint, got %v", taints)
+	}
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// EKSClient is the part of the EKS API used by kubeEP
+type EKSClient interface {
+	ListClusters(
+		ctx context.Context,
+		params *eks.ListClustersInput,
+		optFns ...func(*eks.Options),
+	) (*eks.ListClustersOutput, error)
+	DescribeCluster(
+		ctx context.Context,
+		params *eks.DescribeClusterInput,
+		optFns ...func(*eks.Options),
+	) (*eks.DescribeClusterOutput, error)
+	ListNodegroups(
+		ctx context.Context,
+		params *eks.ListNodegroupsInput,
+		optFns ...func(*eks.Options),
+	) (*eks.ListNodegroupsOutput, error)
+	DescribeNodegroup(
+		ctx context.Context,
+		params *eks.DescribeNodegroupInput,
+		optFns ...func(*eks.Options),
+	) (*eks.DescribeNodegroupOutput, error)
+	UpdateNodegroupConfig(
+		ctx context.Context,
+		params *eks.UpdateNodegroupConfigInput,
+		optFns ...func(*eks.Options),
+	) (*eks.UpdateNodegroupConfigOutput, error)
+	DescribeUpdate(
+		ctx context.Context,
+		params *eks.DescribeUpdateInput,
+		optFns ...func(*eks.Options),
+	) (*eks.DescribeUpdateOutput, error)
+}
+
+type AWSCluster interface {
+	GetAllClusterName(ctx context.Context, clusterClient EKSClient) ([]string, error)
+	GetCluster(
+		ctx context.Context,
+		clusterClient EKSClient,
+		clusterName string,
+	) (*types.Cluster, error)
+	GetAllNodeGroupName(
+		ctx context.Context,
+		clusterClient EKSClient,
+		clusterName string,
+	) ([]string, error)
+	GetNodeGroup(
+		ctx context.Context,
+		clusterClient EKSClient,
+		clusterName, nodeGroupName string,
+	) (*types.Nodegroup, error)
+	UpdateNodeGroupScalingConfig(
+		ctx context.Context,
+		clusterClient EKSClient,
+		clusterName, nodeGroupName string,
+		scalingConfig *types.NodegroupScalingConfig,
+	) (*types.Update, error)
+	GetNodeGroupUpdate(
+		ctx context.Context,
+		clusterClient EKSClient,
+		clusterName, nodeGroupName, updateID string,
+	) (*types.Update, error)
+}
+
+type awsCluster struct {
+}
+
+func newAwsCluster() AWSCluster {
+	return &awsCluster{}
+}
+
+func (a *awsCluster) GetAllClusterName(
+	ctx context.Context,
+	clusterClient EKSClient,
+) ([]string, error) {
+	var clusterNames []string
+	var nextToken *string
+	for {
+		output, err := clusterClient.ListClusters(
+			ctx, &eks.ListClustersInput{NextToken: nextToken},
+		)
+		if err != nil {
+			return nil, err
+		}
+		clusterNames = append(clusterNames, output.Clusters...)
+		if output.NextToken == nil {
+			return clusterNames, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+func (a *awsCluster) GetCluster(
+	ctx context.Context,
+	clusterClient EKSClient,
+	clusterName string,
+) (*types.Cluster, error) {
+	output, err := clusterClient.DescribeCluster(
+		ctx, &eks.DescribeClusterInput{Name: aws.String(clusterName)},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return output.Cluster, nil
+}
+
+func (a *awsCluster) GetAllNodeGroupName(
+	ctx context.Context,
+	clusterClient EKSClient,
+	clusterName string,
+) ([]string, error) {
+	var nodeGroupNames []string
+	var nextToken *string
+	for {
+		output, err := clusterClient.ListNodegroups(
+			ctx, &eks.ListNodegroupsInput{
+				ClusterName: aws.String(clusterName),
+				NextToken:   nextToken,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		nodeGroupNames = append(nodeGroupNames, output.Nodegroups...)
+		if output.NextToken == nil {
+			return nodeGroupNames, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+func (a *awsCluster) GetNodeGroup(
+	ctx context.Context,
+	clusterClient EKSClient,
+	clusterName, nodeGroupName string,
+) (*types.Nodegroup, error) {
+	output, err := clusterClient.DescribeNodegroup(
+		ctx, &eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(clusterName),
+			NodegroupName: aws.String(nodeGroupName),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return output.Nodegroup, nil
+}
+
+func (a *awsCluster) UpdateNodeGroupScalingConfig(
+	ctx context.Context,
+	clusterClient EKSClient,
+	clusterName, nodeGroupName string,
+	scalingConfig *types.NodegroupScalingConfig,
+) (*types.Update, error) {
+	output, err := clusterClient.UpdateNodegroupConfig(
+		ctx, &eks.UpdateNodegroupConfigInput{
+			ClusterName:   aws.String(clusterName),
+			NodegroupName: aws.String(nodeGroupName),
+			ScalingConfig: scalingConfig,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return output.Update, nil
+}
+
+func (a *awsCluster) GetNodeGroupUpdate(
+	ctx context.Context,
+	clusterClient EKSClient,
+	clusterName, nodeGroupName, updateID string,
+) (*types.Update, error) {
+	output, err := clusterClient.DescribeUpdate(
+		ctx, &eks.DescribeUpdateInput{
+			Name:          aws.String(clusterName),
+			NodegroupName: aws.String(nodeGroupName),
+			UpdateId:      aws.String(updateID),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return output.Update, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice"
+)
+
+type AzureCluster interface {
+	GetAllCluster(
+		ctx context.Context,
+		clusterClient *armcontainerservice.ManagedClustersClient,
+	) ([]*armcontainerservice.ManagedCluster, error)
+	GetClusterAdminCredentials(
+		ctx context.Context,
+		clusterClient *armcontainerservice.ManagedClustersClient,
+		resourceGroup, clusterName string,
+	) ([]*armcontainerservice.CredentialResult, error)
+	GetAllAgentPool(
+		ctx context.Context,
+		agentPoolClient *armcontainerservice.AgentPoolsClient,
+		resourceGroup, clusterName string,
+	) ([]*armcontainerservice.AgentPool, error)
+	GetAgentPool(
+		ctx context.Context,
+		agentPoolClient *armcontainerservice.AgentPoolsClient,
+		resourceGroup, clusterName, agentPoolName string,
+	) (*armcontainerservice.AgentPool, error)
+	UpdateAgentPool(
+		ctx context.Context,
+		agentPoolClient *armcontainerservice.AgentPoolsClient,
+		resourceGroup, clusterName, agentPoolName string,
+		agentPool *armcontainerservice.AgentPool,
+	) (*runtime.Poller[armcontainerservice.AgentPoolsClientCreateOrUpdateResponse], error)
+}
+
+type azureCluster struct {
+}
+
+func newAzureCluster() AzureCluster {
+	return &azureCluster{}
+}
+
+func (a *azureCluster) GetAllCluster(
+	ctx context.Context,
+	clusterClient *armcontainerservice.ManagedClustersClient,
+) ([]*armcontainerservice.ManagedCluster, error) {
+	var clusters []*armcontainerservice.ManagedCluster
+	pager := clusterClient.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, page.Value...)
+	}
+	return clusters, nil
+}
+
+func (a *azureCluster) GetClusterAdminCredentials(
+	ctx context.Context,
+	clusterClient *armcontainerservice.ManagedClustersClient,
+	resourceGroup, clusterName string,
+) ([]*armcontainerservice.CredentialResult, error) {
+	res, err := clusterClient.ListClusterAdminCredentials(ctx, resourceGroup, clusterName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Kubeconfigs, nil
+}
+
+func (a *azureCluster) GetAllAgentPool(
+	ctx context.Context,
+	agentPoolClient *armcontainerservice.AgentPoolsClient,
+	resourceGroup, clusterName string,
+) ([]*armcontainerservice.AgentPool, error) {
+	var agentPools []*armcontainerservice.AgentPool
+	pager := agentPoolClient.NewListPager(resourceGroup, clusterName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		agentPools = append(agentPools, page.Value...)
+	}
+	return agentPools, nil
+}
+
+func (a *azureCluster) GetAgentPool(
+	ctx context.Context,
+	agentPoolClient *armcontainerservice.AgentPoolsClient,
+	resourceGroup, clusterName, agentPoolName string,
+) (*armcontainerservice.AgentPool, error) {
+	res, err := agentPoolClient.Get(ctx, resourceGroup, clusterName, agentPoolName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &res.AgentPool, nil
+}
+
+func (a *azureCluster) UpdateAgentPool(
+	ctx context.Context,
+	agentPoolClient *armcontainerservice.AgentPoolsClient,
+	resourceGroup, clusterName, agentPoolName string,
+	agentPool *armcontainerservice.AgentPool,
+) (*runtime.Poller[armcontainerservice.AgentPoolsClientCreateOrUpdateResponse], error) {
+	return agentPoolClient.BeginCreateOrUpdate(
+		ctx,
+		resourceGroup,
+		clusterName,
+		agentPoolName,
+		*agentPool,
+		nil,
+	)
+}
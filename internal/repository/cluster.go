@@ -13,6 +13,8 @@ type Cluster interface {
 	InsertClusterBatch(tx *gorm.DB, data []*model.Cluster) error
 	ListAllRegisteredCluster(tx *gorm.DB) ([]*model.Cluster, error)
 	GetClusterByID(tx *gorm.DB, id uuid.UUID) (*model.Cluster, error)
+	UpdateClusterMetadata(tx *gorm.DB, data *model.Cluster) error
+	UpdateClusterLatestHPAAPIVersion(tx *gorm.DB, data *model.Cluster) error
 }
 
 type cluster struct {
@@ -132,3 +134,11 @@ func (d cluster) InsertCluster(tx *gorm.DB, data *model.Cluster) error {
 func (d cluster) InsertClusterBatch(tx *gorm.DB, data []*model.Cluster) error {
 	return tx.Create(&data).Error
 }
+
+func (d cluster) UpdateClusterMetadata(tx *gorm.DB, data *model.Cluster) error {
+	return tx.Model(data).Update("metadata", data.Metadata).Error
+}
+
+func (d cluster) UpdateClusterLatestHPAAPIVersion(tx *gorm.DB, data *model.Cluster) error {
+	return tx.Model(data).Update("latest_hpa_api_version", data.LatestHPAAPIVersion).Error
+}
@@ -50,6 +50,84 @@ type Event interface {
 		[]*model.Event,
 		error,
 	)
+	FindEventByEndTime(
+		tx *gorm.DB,
+		status model.EventStatus,
+		now time.Time,
+	) (
+		[]*model.Event,
+		error,
+	)
+	FindEventWithDueHPAStep(
+		tx *gorm.DB,
+		statuses []model.EventStatus,
+		now time.Time,
+	) (
+		[]*model.Event,
+		error,
+	)
+	FindEventByStatusWithPendingRestore(
+		tx *gorm.DB,
+		status model.EventStatus,
+	) (
+		[]*model.Event,
+		error,
+	)
+	FindEventByExpiredLease(
+		tx *gorm.DB,
+		status model.EventStatus,
+		now time.Time,
+	) (
+		[]*model.Event,
+		error,
+	)
+	FindEventWithoutLease(tx *gorm.DB, status model.EventStatus) ([]*model.Event, error)
+	ClaimEvent(
+		tx *gorm.DB,
+		id uuid.UUID,
+		fromStatus model.EventStatus,
+		toStatus model.EventStatus,
+		owner string,
+		now time.Time,
+		leaseExpiredAt time.Time,
+	) (bool, error)
+	ClaimEventRestore(
+		tx *gorm.DB,
+		id uuid.UUID,
+		fromStatus model.EventStatus,
+		restoredStatus model.EventStatus,
+		owner string,
+		now time.Time,
+		leaseExpiredAt time.Time,
+	) (bool, error)
+	RenewEventLease(
+		tx *gorm.DB,
+		id uuid.UUID,
+		owner string,
+		leaseExpiredAt time.Time,
+	) (bool, error)
+	ReleaseEventLease(tx *gorm.DB, id uuid.UUID, owner string, leaseExpiredAt time.Time) error
+	UpdateLeasedEventStatus(
+		tx *gorm.DB,
+		id uuid.UUID,
+		owner string,
+		status model.EventStatus,
+		message string,
+	) (bool, error)
+	UpdateUnleasedEvent(
+		tx *gorm.DB,
+		data *model.Event,
+		fromStatuses []model.EventStatus,
+		now time.Time,
+	) (bool, error)
+	FindOverlappingEvent(
+		tx *gorm.DB,
+		clusterID uuid.UUID,
+		excludedID uuid.UUID,
+		statuses []model.EventStatus,
+		from time.Time,
+		to time.Time,
+	) ([]*model.Event, error)
 }
 
 type event struct {
@@ -81,8 +159,10 @@ func (e *event) InsertEvent(tx *gorm.DB, data *model.Event) error {
 	return tx.Create(data).Error
 }
 
+// SaveEvent never writes the status and lease, those only change through the conditional updates
+// so a stale event can't overwrite a worker
 func (e *event) SaveEvent(tx *gorm.DB, data *model.Event) error {
-	return tx.Save(data).Error
+	return tx.Omit("Status", "LeaseOwner", "LeaseExpiredAt").Save(data).Error
 }
 
 func (e *event) DeleteEvent(tx *gorm.DB, id uuid.UUID) error {
@@ -103,17 +183,7 @@ func (e *event) FinishWatchedEvent(tx *gorm.DB, now time.Time) error {
 	).Update("status", model.EventSuccess).Error
 }
 
-func (e *event) FindEventByWatchingAt(
-	tx *gorm.DB,
-	status model.EventStatus,
-	now time.Time,
-) (
-	[]*model.Event,
-	error,
-) {
-	var data []*model.Event
-	rows, err := tx.Raw(
-		`select 
+const eventWithClusterQuery = `select 
     e.id, 
     e.created_at, 
     e.updated_at, 
@@ -128,17 +198,30 @@ func (e *event) FindEventByWatchingAt(
     e.watching_at,
     c.name, 
     d.datacenter,
-    e.calculate_node_pool from events e 
+    e.calculate_node_pool,
+    e.force_hpa_apply,
+    e.node_pool_distribution,
+    e.primary_node_pool,
+    e.restored_status from events e 
     join clusters c on c.id = e.cluster_id and c.deleted_at is null
     join datacenters d on d.id = c.datacenter_id and d.deleted_at is null
-             where e.watching_at <= ? and e.status = ? and e.deleted_at is null`,
-		now.UTC(),
-		status,
-	).Rows()
-	defer rows.Close()
+    where `
+
+// findEventsWithCluster loads events matching the condition together with their cluster name and datacenter
+func (e *event) findEventsWithCluster(
+	tx *gorm.DB,
+	condition string,
+	values ...interface{},
+) (
+	[]*model.Event,
+	error,
+) {
+	rows, err := tx.Raw(eventWithClusterQuery+condition, values...).Rows()
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+	var data []*model.Event
 	for rows.Next() {
 		eventData := &model.Event{}
 		err = rows.Scan(
@@ -157,13 +240,56 @@ func (e *event) FindEventByWatchingAt(
 			&eventData.Cluster.Name,
 			&eventData.Cluster.Datacenter.Datacenter,
 			&eventData.CalculateNodePool,
+			&eventData.ForceHPAApply,
+			&eventData.NodePoolDistribution,
+			&eventData.PrimaryNodePool,
+			&eventData.RestoredStatus,
 		)
 		if err != nil {
 			return nil, err
 		}
 		data = append(data, eventData)
 	}
-	return data, nil
+	return data, rows.Err()
+}
+
+func (e *event) FindEventByWatchingAt(
+	tx *gorm.DB,
+	status model.EventStatus,
+	now time.Time,
+) (
+	[]*model.Event,
+	error,
+) {
+	return e.findEventsWithCluster(
+		tx,
+		`e.watching_at <= ? and e.status = ? and e.deleted_at is null`,
+		now.UTC(),
+		status,
+	)
+}
+
+func (e *event) FindEventByEndTime(
+	tx *gorm.DB,
+	status model.EventStatus,
+	now time.Time,
+) (
+	[]*model.Event,
+	error,
+) {
+	return e.findEventsWithCluster(
+		tx,
+		`e.end_time < ? and e.status = ? and e.deleted_at is null
+        and not exists (
+            select 1 from scheduled_hpa_steps s
+            join scheduled_hpa_configs h on h.id = s.scheduled_hpa_config_id and h.deleted_at is null
+            where h.event_id = e.id and s.phase = ? and s.status = ? and s.deleted_at is null
+        )`,
+		now.UTC(),
+		status,
+		model.HPAStepRampDown,
+		model.HPAUpdatePending,
+	)
 }
 
 func (e *event) FindEventByExecuteConfigAt(
@@ -174,59 +300,34 @@ func (e *event) FindEventByExecuteConfigAt(
 	[]*model.Event,
 	error,
 ) {
-	var data []*model.Event
-	rows, err := tx.Raw(
-		`select 
-    e.id, 
-    e.created_at, 
-    e.updated_at, 
-    e.deleted_at, 
-    e.name, 
-    e.start_time, 
-    e.end_time, 
-    e.cluster_id, 
-    e.status, 
-    e.message,
-    e.execute_config_at,
-    e.watching_at,
-    c.name, 
-    d.datacenter,
-    e.calculate_node_pool from events e 
-    join clusters c on c.id = e.cluster_id and c.deleted_at is null
-    join datacenters d on d.id = c.datacenter_id and d.deleted_at is null
-             where e.execute_config_at <= ? and e.status = ? and e.deleted_at is null`,
+	return e.findEventsWithCluster(
+		tx,
+		`e.execute_config_at <= ? and e.status = ? and e.deleted_at is null`,
 		now.UTC(),
 		status,
-	).Rows()
-	defer rows.Close()
-	if err != nil {
-		return nil, err
-	}
-	for rows.Next() {
-		eventData := &model.Event{}
-		err = rows.Scan(
-			&eventData.ID,
-			&eventData.CreatedAt,
-			&eventData.UpdatedAt,
-			&eventData.DeletedAt,
-			&eventData.Name,
-			&eventData.StartTime,
-			&eventData.EndTime,
-			&eventData.ClusterID,
-			&eventData.Status,
-			&eventData.Message,
-			&eventData.ExecuteConfigAt,
-			&eventData.WatchingAt,
-			&eventData.Cluster.Name,
-			&eventData.Cluster.Datacenter.Datacenter,
-			&eventData.CalculateNodePool,
-		)
-		if err != nil {
-			return nil, err
-		}
-		data = append(data, eventData)
-	}
-	return data, nil
+	)
+}
+
+func (e *event) FindEventWithDueHPAStep(
+	tx *gorm.DB,
+	statuses []model.EventStatus,
+	now time.Time,
+) (
+	[]*model.Event,
+	error,
+) {
+	return e.findEventsWithCluster(
+		tx,
+		`e.status in ? and e.deleted_at is null
+        and exists (
+            select 1 from scheduled_hpa_steps s
+            join scheduled_hpa_configs h on h.id = s.scheduled_hpa_config_id and h.deleted_at is null
+            where h.event_id = e.id and s.execute_at <= ? and s.status = ? and s.deleted_at is null
+        )`,
+		statuses,
+		now.UTC(),
+		model.HPAUpdatePending,
+	)
 }
 
 func (e *event) FindEventByStatusWithStarTimeBeforeMinuteAndClusterData(
@@ -238,60 +339,13 @@ func (e *event) FindEventByStatusWithStarTimeBeforeMinuteAndClusterData(
 	[]*model.Event,
 	error,
 ) {
-	var data []*model.Event
-	rows, err := tx.Raw(
-		`select 
-    e.id, 
-    e.created_at, 
-    e.updated_at, 
-    e.deleted_at, 
-    e.name, 
-    e.start_time, 
-    e.end_time, 
-    e.cluster_id, 
-    e.status, 
-    e.message,
-    e.execute_config_at,
-    e.watching_at,
-    c.name, 
-    d.datacenter,
-    e.calculate_node_pool from events e 
-    join clusters c on c.id = e.cluster_id and c.deleted_at is null
-    join datacenters d on d.id = c.datacenter_id and d.deleted_at is null
-             where e.start_time - ? < ? * interval '1 minutes' and e.status = ? and e.deleted_at is null`,
+	return e.findEventsWithCluster(
+		tx,
+		`e.start_time - ? < ? * interval '1 minutes' and e.status = ? and e.deleted_at is null`,
 		now.UTC(),
 		minute+1,
 		status,
-	).Rows()
-	defer rows.Close()
-	if err != nil {
-		return nil, err
-	}
-	for rows.Next() {
-		eventData := &model.Event{}
-		err = rows.Scan(
-			&eventData.ID,
-			&eventData.CreatedAt,
-			&eventData.UpdatedAt,
-			&eventData.DeletedAt,
-			&eventData.Name,
-			&eventData.StartTime,
-			&eventData.EndTime,
-			&eventData.ClusterID,
-			&eventData.Status,
-			&eventData.Message,
-			&eventData.ExecuteConfigAt,
-			&eventData.WatchingAt,
-			&eventData.Cluster.Name,
-			&eventData.Cluster.Datacenter.Datacenter,
-			&eventData.CalculateNodePool,
-		)
-		if err != nil {
-			return nil, err
-		}
-		data = append(data, eventData)
-	}
-	return data, nil
+	)
 }
 
 func (e *event) FindEventByStatusWithStarTimeBeforeMinute(
@@ -316,3 +370,219 @@ func (e *event) FindEventByStatusWithStarTimeBeforeMinute(
 	}
 	return data, nil
 }
+
+func (e *event) FindEventByStatusWithPendingRestore(
+	tx *gorm.DB,
+	status model.EventStatus,
+) (
+	[]*model.Event,
+	error,
+) {
+	return e.findEventsWithCluster(
+		tx,
+		`e.status = ? and e.deleted_at is null and (
+            exists (
+                select 1 from scheduled_hpa_configs s 
+                where s.event_id = e.id and s.deleted_at is null 
+                  and s.status = ? and s.restore_status = ?
+            ) or exists (
+                select 1 from scheduled_workload_configs w 
+                where w.event_id = e.id and w.deleted_at is null 
+                  and w.status = ? and w.restore_status = ?
+            ) or exists (
+                select 1 from updated_node_pool u 
+                where u.event_id = e.id and u.deleted_at is null 
+                  and u.autoscaling_modified and u.restore_status = ?
+            )
+        )`,
+		status,
+		model.HPAUpdateSuccess,
+		model.HPAUpdatePending,
+		model.WorkloadUpdateSuccess,
+		model.WorkloadUpdatePending,
+		model.NodePoolUpdatePending,
+	)
+}
+
+func (e *event) FindEventByExpiredLease(
+	tx *gorm.DB,
+	status model.EventStatus,
+	now time.Time,
+) (
+	[]*model.Event,
+	error,
+) {
+	return e.findEventsWithCluster(
+		tx,
+		`e.lease_expired_at < ? and e.status = ? and e.deleted_at is null`,
+		now.UTC(),
+		status,
+	)
+}
+
+func (e *event) ClaimEvent(
+	tx *gorm.DB,
+	id uuid.UUID,
+	fromStatus model.EventStatus,
+	toStatus model.EventStatus,
+	owner string,
+	now time.Time,
+	leaseExpiredAt time.Time,
+) (bool, error) {
+	return e.claimEvent(
+		tx,
+		id,
+		fromStatus,
+		now,
+		map[string]interface{}{
+			"status":           toStatus,
+			"lease_owner":      owner,
+			"lease_expired_at": leaseExpiredAt.UTC(),
+		},
+	)
+}
+
+// ClaimEventRestore moves the event to restoring and keeps the status it gets once restored
+func (e *event) ClaimEventRestore(
+	tx *gorm.DB,
+	id uuid.UUID,
+	fromStatus model.EventStatus,
+	restoredStatus model.EventStatus,
+	owner string,
+	now time.Time,
+	leaseExpiredAt time.Time,
+) (bool, error) {
+	return e.claimEvent(
+		tx,
+		id,
+		fromStatus,
+		now,
+		map[string]interface{}{
+			"status":           model.EventRestoring,
+			"restored_status":  restoredStatus,
+			"lease_owner":      owner,
+			"lease_expired_at": leaseExpiredAt.UTC(),
+		},
+	)
+}
+
+func (e *event) claimEvent(
+	tx *gorm.DB,
+	id uuid.UUID,
+	fromStatus model.EventStatus,
+	now time.Time,
+	values map[string]interface{},
+) (bool, error) {
+	tx = tx.Model(&model.Event{}).
+		Where(
+			"id = ? and status = ? and (lease_expired_at is null or lease_expired_at < ?)",
+			id,
+			fromStatus,
+			now.UTC(),
+		).
+		Updates(values)
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (e *event) RenewEventLease(
+	tx *gorm.DB,
+	id uuid.UUID,
+	owner string,
+	leaseExpiredAt time.Time,
+) (bool, error) {
+	tx = tx.Model(&model.Event{}).
+		Where("id = ? and lease_owner = ?", id, owner).
+		Update("lease_expired_at", leaseExpiredAt.UTC())
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (e *event) ReleaseEventLease(tx *gorm.DB, id uuid.UUID, owner string, leaseExpiredAt time.Time) error {
+	return tx.Model(&model.Event{}).
+		Where("id = ? and lease_owner = ?", id, owner).
+		Updates(
+			map[string]interface{}{
+				"lease_owner":      "",
+				"lease_expired_at": leaseExpiredAt.UTC(),
+			},
+		).Error
+}
+
+func (e *event) UpdateLeasedEventStatus(
+	tx *gorm.DB,
+	id uuid.UUID,
+	owner string,
+	status model.EventStatus,
+	message string,
+) (bool, error) {
+	tx = tx.Model(&model.Event{}).
+		Where("id = ? and lease_owner = ?", id, owner).
+		Updates(
+			map[string]interface{}{
+				"status":  status,
+				"message": message,
+			},
+		)
+	return tx.RowsAffected == 1, tx.Error
+}
+
+// UpdateUnleasedEvent only updates the status, message and schedule of an event still in one of the
+// given statuses and not held by a worker
+func (e *event) UpdateUnleasedEvent(
+	tx *gorm.DB,
+	data *model.Event,
+	fromStatuses []model.EventStatus,
+	now time.Time,
+) (bool, error) {
+	tx = tx.Model(&model.Event{}).
+		Where(
+			"id = ? and status in ? and (lease_expired_at is null or lease_expired_at < ?)",
+			data.ID,
+			fromStatuses,
+			now.UTC(),
+		).
+		Select("status", "message", "execute_config_at", "watching_at").
+		Updates(data)
+	return tx.RowsAffected == 1, tx.Error
+}
+
+func (e *event) FindEventWithoutLease(
+	tx *gorm.DB,
+	status model.EventStatus,
+) (
+	[]*model.Event,
+	error,
+) {
+	return e.findEventsWithCluster(
+		tx,
+		`e.lease_expired_at is null and e.status = ? and e.deleted_at is null`,
+		status,
+	)
+}
+
+// FindOverlappingEvent treats pending ramp down steps as part of the event window
+func (e *event) FindOverlappingEvent(
+	tx *gorm.DB,
+	clusterID uuid.UUID,
+	excludedID uuid.UUID,
+	statuses []model.EventStatus,
+	from time.Time,
+	to time.Time,
+) ([]*model.Event, error) {
+	var data []*model.Event
+	tx = tx.Model(&model.Event{}).
+		Where("cluster_id = ? and id <> ? and status in ?", clusterID, excludedID, statuses).
+		Where("execute_config_at <= ?", to.UTC()).
+		Where(
+			`end_time >= ? or exists (
+                 select 1 from scheduled_hpa_steps s
+                 join scheduled_hpa_configs h on h.id = s.scheduled_hpa_config_id and h.deleted_at is null
+                 where h.event_id = events.id and s.execute_at >= ? and s.status = ? and s.deleted_at is null
+             )`,
+			from.UTC(),
+			from.UTC(),
+			model.HPAUpdatePending,
+		).
+		Order("execute_config_at").
+		Find(&data)
+	return data, tx.Error
+}
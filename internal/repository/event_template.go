@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type EventTemplate interface {
+	GetEventTemplateByID(tx *gorm.DB, id uuid.UUID) (*model.EventTemplate, error)
+	GetEventTemplateByName(tx *gorm.DB, clusterID uuid.UUID, name string) (*model.EventTemplate, error)
+	ListEventTemplateByClusterID(tx *gorm.DB, id uuid.UUID) ([]*model.EventTemplate, error)
+	InsertEventTemplate(tx *gorm.DB, data *model.EventTemplate) error
+	DeleteEventTemplate(tx *gorm.DB, id uuid.UUID) error
+}
+
+type eventTemplate struct {
+}
+
+func newEventTemplate() EventTemplate {
+	return &eventTemplate{}
+}
+
+func (e *eventTemplate) GetEventTemplateByID(tx *gorm.DB, id uuid.UUID) (*model.EventTemplate, error) {
+	data := &model.EventTemplate{}
+	tx = tx.Model(data).First(data, id)
+	return data, tx.Error
+}
+
+func (e *eventTemplate) GetEventTemplateByName(
+	tx *gorm.DB,
+	clusterID uuid.UUID,
+	name string,
+) (*model.EventTemplate, error) {
+	data := &model.EventTemplate{}
+	tx = tx.Model(data).Where("cluster_id = ? and name = ?", clusterID, name).First(data)
+	return data, tx.Error
+}
+
+func (e *eventTemplate) ListEventTemplateByClusterID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) ([]*model.EventTemplate, error) {
+	var data []*model.EventTemplate
+	tx = tx.Model(&model.EventTemplate{}).Where("cluster_id = ?", id).Order("name").Find(&data)
+	return data, tx.Error
+}
+
+func (e *eventTemplate) InsertEventTemplate(tx *gorm.DB, data *model.EventTemplate) error {
+	return tx.Create(data).Error
+}
+
+func (e *eventTemplate) DeleteEventTemplate(tx *gorm.DB, id uuid.UUID) error {
+	return tx.Delete(&model.EventTemplate{}, id).Error
+}
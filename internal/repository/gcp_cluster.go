@@ -1,9 +1,11 @@
 package repository
 
 import (
+	compute "cloud.google.com/go/compute/apiv1"
 	container "cloud.google.com/go/container/apiv1"
 	"context"
 	"fmt"
+	computeEntity "google.golang.org/genproto/googleapis/cloud/compute/v1"
 	containerEntity "google.golang.org/genproto/googleapis/container/v1"
 )
 
@@ -31,6 +33,11 @@ type GCPCluster interface {
 		clusterClient *container.ClusterManagerClient,
 		project, location, operationName string,
 	) (*containerEntity.Operation, error)
+	GetMachineType(
+		ctx context.Context,
+		machineTypesClient *compute.MachineTypesClient,
+		project, zone, machineType string,
+	) (*computeEntity.MachineType, error)
 }
 
 type gcpCluster struct {
@@ -108,3 +115,17 @@ func (g *gcpCluster) GetOperation(
 		},
 	)
 }
+
+func (g *gcpCluster) GetMachineType(
+	ctx context.Context,
+	machineTypesClient *compute.MachineTypesClient,
+	project, zone, machineType string,
+) (*computeEntity.MachineType, error) {
+	return machineTypesClient.Get(
+		ctx, &computeEntity.GetMachineTypeRequest{
+			Project:     project,
+			Zone:        zone,
+			MachineType: machineType,
+		},
+	)
+}
@@ -7,20 +7,31 @@ import (
 )
 
 type Repositories struct {
-	Cluster            Cluster
-	Datacenter         Datacenter
-	Event              Event
-	ScheduledHPAConfig ScheduledHPAConfig
-	K8sHPA             K8sHPA
-	K8sNamespace       K8sNamespace
-	GCPCluster         GCPCluster
-	K8SDiscovery       K8SDiscovery
-	K8sDeployment      K8sDeployment
-	NodePoolStatus     NodePoolStatus
-	UpdatedNodePool    UpdatedNodePool
-	HPAStatus          HPAStatus
-	K8sNode            K8sNode
-	K8sDaemonSets      K8sDaemonSets
+	Cluster                 Cluster
+	Datacenter              Datacenter
+	Event                   Event
+	RecurringEvent          RecurringEvent
+	EventTemplate           EventTemplate
+	MachineTypeCapacity     MachineTypeCapacity
+	ScheduledHPAConfig      ScheduledHPAConfig
+	ScheduledHPAStep        ScheduledHPAStep
+	ScheduledWorkloadConfig ScheduledWorkloadConfig
+	K8sHPA                  K8sHPA
+	K8sNamespace            K8sNamespace
+	GCPCluster              GCPCluster
+	AWSCluster              AWSCluster
+	AzureCluster            AzureCluster
+	K8SDiscovery            K8SDiscovery
+	K8sDeployment           K8sDeployment
+	NodePoolStatus          NodePoolStatus
+	UpdatedNodePool         UpdatedNodePool
+	HPAStatus               HPAStatus
+	WorkloadStatus          WorkloadStatus
+	K8sNode                 K8sNode
+	K8sDaemonSets           K8sDaemonSets
+	K8sCapacity             K8sCapacity
+	K8sWorkload             K8sWorkload
+	K8sScaledObject         K8sScaledObject
 }
 
 func Migrate(db *gorm.DB) error {
@@ -28,10 +39,17 @@ func Migrate(db *gorm.DB) error {
 		&model.Datacenter{},
 		&model.Cluster{},
 		&model.Event{},
+		&model.RecurringEvent{},
+		&model.RecurringEventOccurrence{},
+		&model.EventTemplate{},
 		&model.ScheduledHPAConfig{},
+		&model.ScheduledHPAStep{},
 		&model.NodePoolStatus{},
 		&model.HPAStatus{},
+		&model.ScheduledWorkloadConfig{},
+		&model.WorkloadStatus{},
 		&model.UpdatedNodePool{},
+		&model.MachineTypeCapacity{},
 	}
 
 	err := db.AutoMigrate(
@@ -55,19 +73,30 @@ func Migrate(db *gorm.DB) error {
 
 func BuildRepositories(resources *config.KubeEPResources) *Repositories {
 	return &Repositories{
-		Cluster:            newCluster(),
-		Datacenter:         newDatacenter(resources.Redis),
-		Event:              newEvent(),
-		ScheduledHPAConfig: newScheduledHPAConfig(),
-		K8sHPA:             newK8sHPA(resources.Redis),
-		K8sNamespace:       newK8sNamespace(),
-		GCPCluster:         newGcpCluster(),
-		K8SDiscovery:       newK8sDiscovery(),
-		K8sDeployment:      newK8sDeployment(),
-		NodePoolStatus:     newNodePoolStatus(),
-		HPAStatus:          newHpaStatus(),
-		UpdatedNodePool:    newUpdatedNodePool(),
-		K8sNode:            newK8sNode(),
-		K8sDaemonSets:      newK8sDaemonSets(),
+		Cluster:                 newCluster(),
+		Datacenter:              newDatacenter(resources.Redis),
+		Event:                   newEvent(),
+		RecurringEvent:          newRecurringEvent(),
+		EventTemplate:           newEventTemplate(),
+		MachineTypeCapacity:     newMachineTypeCapacity(),
+		ScheduledHPAConfig:      newScheduledHPAConfig(),
+		ScheduledHPAStep:        newScheduledHPAStep(),
+		ScheduledWorkloadConfig: newScheduledWorkloadConfig(),
+		K8sHPA:                  newK8sHPA(resources.Redis),
+		K8sNamespace:            newK8sNamespace(),
+		GCPCluster:              newGcpCluster(),
+		AWSCluster:              newAwsCluster(),
+		AzureCluster:            newAzureCluster(),
+		K8SDiscovery:            newK8sDiscovery(),
+		K8sDeployment:           newK8sDeployment(),
+		NodePoolStatus:          newNodePoolStatus(),
+		HPAStatus:               newHpaStatus(),
+		WorkloadStatus:          newWorkloadStatus(),
+		UpdatedNodePool:         newUpdatedNodePool(),
+		K8sNode:                 newK8sNode(),
+		K8sDaemonSets:           newK8sDaemonSets(),
+		K8sCapacity:             newK8sCapacity(),
+		K8sWorkload:             newK8sWorkload(),
+		K8sScaledObject:         newK8sScaledObject(),
 	}
 }
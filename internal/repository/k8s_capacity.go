@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+type K8sCapacity interface {
+	ListMachineDeployments(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+	) (*unstructured.UnstructuredList, error)
+	PatchMachineDeployment(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		namespace, name string,
+		patch []byte,
+	) error
+	GetKarpenterNodePool(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		name string,
+	) (*unstructured.Unstructured, error)
+	PatchKarpenterNodePool(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		name string,
+		patch []byte,
+	) error
+}
+
+type k8sCapacity struct {
+}
+
+func newK8sCapacity() K8sCapacity {
+	return &k8sCapacity{}
+}
+
+func (k *k8sCapacity) ListMachineDeployments(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+) (*unstructured.UnstructuredList, error) {
+	raw, err := k8sClient.Discovery().RESTClient().
+		Get().
+		AbsPath(constant.ClusterAPIMachineDeploymentListPath).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	data := &unstructured.UnstructuredList{}
+	err = data.UnmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (k *k8sCapacity) PatchMachineDeployment(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	namespace, name string,
+	patch []byte,
+) error {
+	return k8sClient.Discovery().RESTClient().
+		Patch(types.MergePatchType).
+		AbsPath(fmt.Sprintf(constant.ClusterAPIMachineDeploymentPath, namespace, name)).
+		Body(patch).
+		Do(ctx).
+		Error()
+}
+
+func (k *k8sCapacity) GetKarpenterNodePool(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	name string,
+) (*unstructured.Unstructured, error) {
+	raw, err := k8sClient.Discovery().RESTClient().
+		Get().
+		AbsPath(fmt.Sprintf(constant.KarpenterNodePoolPath, name)).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	data := &unstructured.Unstructured{}
+	err = data.UnmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (k *k8sCapacity) PatchKarpenterNodePool(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	name string,
+	patch []byte,
+) error {
+	return k8sClient.Discovery().RESTClient().
+		Patch(types.MergePatchType).
+		AbsPath(fmt.Sprintf(constant.KarpenterNodePoolPath, name)).
+		Body(patch).
+		Do(ctx).
+		Error()
+}
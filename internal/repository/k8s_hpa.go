@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
@@ -9,10 +10,12 @@ import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
 	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
 	v1Autoscale "k8s.io/api/autoscaling/v1"
+	v2Autoscale "k8s.io/api/autoscaling/v2"
 	"k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/api/autoscaling/v2beta2"
 	v1Core "k8s.io/api/core/v1"
 	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"time"
 )
@@ -36,26 +39,29 @@ type K8sHPA interface {
 		namespace v1Core.Namespace,
 		clusterID uuid.UUID,
 	) ([]v2beta1.HorizontalPodAutoscaler, error)
-	UpdateV2beta1HPA(
+	ApplyV2beta1HPA(
 		ctx context.Context,
 		client kubernetes.Interface,
-		namespace string,
+		namespace, name string,
 		clusterID uuid.UUID,
-		hpa *v2beta1.HorizontalPodAutoscaler,
+		applyConfig []byte,
+		force bool,
 	) (*v2beta1.HorizontalPodAutoscaler, error)
-	UpdateV1HPA(
+	ApplyV1HPA(
 		ctx context.Context,
 		client kubernetes.Interface,
-		namespace string,
+		namespace, name string,
 		clusterID uuid.UUID,
-		hpa *v1Autoscale.HorizontalPodAutoscaler,
+		applyConfig []byte,
+		force bool,
 	) (*v1Autoscale.HorizontalPodAutoscaler, error)
-	UpdateV2beta2HPA(
+	ApplyV2beta2HPA(
 		ctx context.Context,
 		client kubernetes.Interface,
-		namespace string,
+		namespace, name string,
 		clusterID uuid.UUID,
-		hpa *v2beta2.HorizontalPodAutoscaler,
+		applyConfig []byte,
+		force bool,
 	) (*v2beta2.HorizontalPodAutoscaler, error)
 	GetV1HPA(
 		ctx context.Context,
@@ -78,6 +84,32 @@ type K8sHPA interface {
 		namespace string,
 		clusterID uuid.UUID,
 	) (*v2beta2.HorizontalPodAutoscaler, error)
+	GetAllV2HPA(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace v1Core.Namespace,
+		clusterID uuid.UUID,
+	) ([]v2Autoscale.HorizontalPodAutoscaler, error)
+	ApplyV2HPA(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace, name string,
+		clusterID uuid.UUID,
+		applyConfig []byte,
+		force bool,
+	) (*v2Autoscale.HorizontalPodAutoscaler, error)
+	GetV2HPA(
+		ctx context.Context,
+		client kubernetes.Interface,
+		name string,
+		namespace string,
+		clusterID uuid.UUID,
+	) (*v2Autoscale.HorizontalPodAutoscaler, error)
+	ReleaseHPAFieldManager(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace, name string,
+	) error
 }
 
 type k8sHPA struct {
@@ -226,21 +258,27 @@ func (h *k8sHPA) GetAllV2beta1HPA(
 	return data.Items, nil
 }
 
-func (h *k8sHPA) UpdateV1HPA(
+func (h *k8sHPA) ApplyV1HPA(
 	ctx context.Context,
 	client kubernetes.Interface,
-	namespace string,
+	namespace, name string,
 	clusterID uuid.UUID,
-	hpa *v1Autoscale.HorizontalPodAutoscaler,
+	applyConfig []byte,
+	force bool,
 ) (*v1Autoscale.HorizontalPodAutoscaler, error) {
 	key := fmt.Sprintf("hpa_v1_list_cluster_%s_ns_%s", clusterID, namespace)
 	data, err := client.
 		AutoscalingV1().
 		HorizontalPodAutoscalers(namespace).
-		Update(
+		Patch(
 			ctx,
-			hpa,
-			v1Option.UpdateOptions{FieldManager: constant.K8sHPAUpdateFieldManager},
+			name,
+			types.ApplyPatchType,
+			applyConfig,
+			v1Option.PatchOptions{
+				FieldManager: constant.K8sHPAUpdateFieldManager,
+				Force:        &force,
+			},
 		)
 	if err != nil {
 		return nil, err
@@ -251,22 +289,26 @@ func (h *k8sHPA) UpdateV1HPA(
 	return data, nil
 }
 
-func (h *k8sHPA) UpdateV2beta2HPA(
+func (h *k8sHPA) ApplyV2beta2HPA(
 	ctx context.Context,
 	client kubernetes.Interface,
-	namespace string,
+	namespace, name string,
 	clusterID uuid.UUID,
-	hpa *v2beta2.HorizontalPodAutoscaler,
+	applyConfig []byte,
+	force bool,
 ) (*v2beta2.HorizontalPodAutoscaler, error) {
 	key := fmt.Sprintf("hpa_v2_beta_2_list_cluster_%s_ns_%s", clusterID, namespace)
 	data, err := client.
 		AutoscalingV2beta2().
 		HorizontalPodAutoscalers(namespace).
-		Update(
+		Patch(
 			ctx,
-			hpa,
-			v1Option.UpdateOptions{
+			name,
+			types.ApplyPatchType,
+			applyConfig,
+			v1Option.PatchOptions{
 				FieldManager: constant.K8sHPAUpdateFieldManager,
+				Force:        &force,
 			},
 		)
 	if err != nil {
@@ -278,22 +320,26 @@ func (h *k8sHPA) UpdateV2beta2HPA(
 	return data, nil
 }
 
-func (h *k8sHPA) UpdateV2beta1HPA(
+func (h *k8sHPA) ApplyV2beta1HPA(
 	ctx context.Context,
 	client kubernetes.Interface,
-	namespace string,
+	namespace, name string,
 	clusterID uuid.UUID,
-	hpa *v2beta1.HorizontalPodAutoscaler,
+	applyConfig []byte,
+	force bool,
 ) (*v2beta1.HorizontalPodAutoscaler, error) {
 	key := fmt.Sprintf("hpa_v2_beta_1_list_cluster_%s_ns_%s", clusterID, namespace)
 	data, err := client.
 		AutoscalingV2beta1().
 		HorizontalPodAutoscalers(namespace).
-		Update(
+		Patch(
 			ctx,
-			hpa,
-			v1Option.UpdateOptions{
+			name,
+			types.ApplyPatchType,
+			applyConfig,
+			v1Option.PatchOptions{
 				FieldManager: constant.K8sHPAUpdateFieldManager,
+				Force:        &force,
 			},
 		)
 	if err != nil {
@@ -427,3 +473,170 @@ func (h *k8sHPA) GetV2beta2HPA(
 	}
 	return data, nil
 }
+
+func (h *k8sHPA) GetAllV2HPA(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace v1Core.Namespace,
+	clusterID uuid.UUID,
+) ([]v2Autoscale.HorizontalPodAutoscaler, error) {
+	key := fmt.Sprintf("hpa_v2_list_cluster_%s_ns_%s", clusterID, namespace.Name)
+	if redisResponse := h.redisClient.Get(
+		ctx,
+		key,
+	); redisResponse.Err() != nil {
+		var HPAList v2Autoscale.HorizontalPodAutoscalerList
+		b, err := redisResponse.Bytes()
+		if err == nil {
+			if string(b) == errorConstant.HPAListError {
+				return nil, errors.New(errorConstant.HPAListError)
+			}
+			if err = HPAList.Unmarshal(b); err == nil {
+				return HPAList.Items, nil
+			}
+		}
+	}
+	data, err := client.
+		AutoscalingV2().
+		HorizontalPodAutoscalers(namespace.Name).
+		List(
+			ctx,
+			v1Option.ListOptions{},
+		)
+	if err != nil {
+		_ = h.redisClient.Set(
+			ctx,
+			key,
+			errorConstant.HPAListError,
+			HPACacheTime,
+		).Err()
+		return nil, err
+	}
+	if b, err := data.Marshal(); err == nil {
+		_ = h.redisClient.Set(ctx, key, b, HPACacheTime).Err()
+	}
+	return data.Items, nil
+}
+
+func (h *k8sHPA) ApplyV2HPA(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name string,
+	clusterID uuid.UUID,
+	applyConfig []byte,
+	force bool,
+) (*v2Autoscale.HorizontalPodAutoscaler, error) {
+	key := fmt.Sprintf("hpa_v2_list_cluster_%s_ns_%s", clusterID, namespace)
+	data, err := client.
+		AutoscalingV2().
+		HorizontalPodAutoscalers(namespace).
+		Patch(
+			ctx,
+			name,
+			types.ApplyPatchType,
+			applyConfig,
+			v1Option.PatchOptions{
+				FieldManager: constant.K8sHPAUpdateFieldManager,
+				Force:        &force,
+			},
+		)
+	if err != nil {
+		return nil, err
+	}
+	if b, err := data.Marshal(); err == nil {
+		_ = h.redisClient.Set(ctx, key, b, HPACacheTime).Err()
+	}
+	return data, nil
+}
+
+func (h *k8sHPA) GetV2HPA(
+	ctx context.Context,
+	client kubernetes.Interface,
+	name string,
+	namespace string,
+	clusterID uuid.UUID,
+) (*v2Autoscale.HorizontalPodAutoscaler, error) {
+	key := fmt.Sprintf("hpa_v2_cluster_%s_ns_%s_name_%s", clusterID, namespace, name)
+	if redisResponse := h.redisClient.Get(
+		ctx,
+		key,
+	); redisResponse.Err() != nil {
+		var hpa *v2Autoscale.HorizontalPodAutoscaler
+		b, err := redisResponse.Bytes()
+		if err == nil {
+			if string(b) == errorConstant.HPAError {
+				return nil, errors.New(string(b))
+			}
+			if err = hpa.Unmarshal(b); err == nil {
+				return hpa, nil
+			}
+		}
+	}
+	data, err := client.
+		AutoscalingV2().
+		HorizontalPodAutoscalers(namespace).
+		Get(
+			ctx,
+			name,
+			v1Option.GetOptions{},
+		)
+	if err != nil {
+		_ = h.redisClient.Set(ctx, key, errorConstant.HPAError, HPACacheTime).Err()
+		return nil, err
+	}
+	if b, err := data.Marshal(); err == nil {
+		_ = h.redisClient.Set(ctx, key, b, HPACacheTime).Err()
+	}
+	return data, nil
+}
+
+// ReleaseHPAFieldManager removes the kubeEP managed fields entry, the fields keep their values without an owner.
+// Managed fields are shared by every API version, so the v1 API is used for all HPAs.
+func (h *k8sHPA) ReleaseHPAFieldManager(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name string,
+) error {
+	hpaClient := client.AutoscalingV1().HorizontalPodAutoscalers(namespace)
+	data, err := hpaClient.Get(ctx, name, v1Option.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	managedFields := data.GetManagedFields()
+	var patch []map[string]interface{}
+	// Remove from the last entry so the earlier indexes stay valid
+	for idx := len(managedFields) - 1; idx >= 0; idx-- {
+		entry := managedFields[idx]
+		if entry.Manager != constant.K8sHPAUpdateFieldManager ||
+			entry.Operation != v1Option.ManagedFieldsOperationApply {
+			continue
+		}
+		path := fmt.Sprintf("/metadata/managedFields/%d", idx)
+		patch = append(
+			patch,
+			map[string]interface{}{"op": "test", "path": path + "/manager", "value": entry.Manager},
+			map[string]interface{}{"op": "remove", "path": path},
+		)
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	// An empty managed fields list is ignored by the API server, a single empty entry resets it instead
+	if len(patch)/2 == len(managedFields) {
+		patch = []map[string]interface{}{
+			{
+				"op":    "replace",
+				"path":  "/metadata/managedFields",
+				"value": []map[string]interface{}{{}},
+			},
+		}
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = hpaClient.Patch(ctx, name, types.JSONPatchType, patchBytes, v1Option.PatchOptions{})
+	return err
+}
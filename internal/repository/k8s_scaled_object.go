@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+type K8sScaledObject interface {
+	GetAllScaledObject(
+		ctx context.Context,
+		client kubernetes.Interface,
+	) (*unstructured.UnstructuredList, error)
+	PatchScaledObject(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace, name string,
+		patch []byte,
+	) (*unstructured.Unstructured, error)
+}
+
+type k8sScaledObject struct {
+}
+
+func newK8sScaledObject() K8sScaledObject {
+	return &k8sScaledObject{}
+}
+
+func (k *k8sScaledObject) GetAllScaledObject(
+	ctx context.Context,
+	client kubernetes.Interface,
+) (*unstructured.UnstructuredList, error) {
+	raw, err := client.Discovery().RESTClient().
+		Get().
+		AbsPath(fmt.Sprintf("/apis/%s/scaledobjects", constant.KedaV1Alpha1)).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	data := &unstructured.UnstructuredList{}
+	err = data.UnmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (k *k8sScaledObject) PatchScaledObject(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name string,
+	patch []byte,
+) (*unstructured.Unstructured, error) {
+	raw, err := client.Discovery().RESTClient().
+		Patch(types.MergePatchType).
+		AbsPath(
+			fmt.Sprintf(
+				"/apis/%s/namespaces/%s/scaledobjects/%s",
+				constant.KedaV1Alpha1,
+				namespace,
+				name,
+			),
+		).
+		Body(patch).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	data := &unstructured.Unstructured{}
+	err = data.UnmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	v1 "k8s.io/api/apps/v1"
+	v1Autoscaling "k8s.io/api/autoscaling/v1"
+	v1Core "k8s.io/api/core/v1"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+)
+
+type K8sWorkload interface {
+	GetStatefulSet(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace, name string,
+		option ...v1Option.GetOptions,
+	) (*v1.StatefulSet, error)
+	GetReplicaSet(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace, name string,
+		option ...v1Option.GetOptions,
+	) (*v1.ReplicaSet, error)
+	GetResourceName(client kubernetes.Interface, apiVersion, kind string) (string, error)
+	GetObject(
+		ctx context.Context,
+		client kubernetes.Interface,
+		apiVersion, resource, namespace, name string,
+	) (*unstructured.Unstructured, error)
+	GetScale(
+		ctx context.Context,
+		client kubernetes.Interface,
+		apiVersion, resource, namespace, name string,
+	) (*v1Autoscaling.Scale, error)
+	UpdateScale(
+		ctx context.Context,
+		client kubernetes.Interface,
+		apiVersion, resource, namespace, name string,
+		replicas int32,
+	) (*v1Autoscaling.Scale, error)
+	GetPodList(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace string,
+		option ...v1Option.ListOptions,
+	) (*v1Core.PodList, error)
+}
+
+type k8sWorkload struct {
+}
+
+func newK8sWorkload() K8sWorkload {
+	return &k8sWorkload{}
+}
+
+func (w *k8sWorkload) GetStatefulSet(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name string,
+	option ...v1Option.GetOptions,
+) (*v1.StatefulSet, error) {
+	reqOption := v1Option.GetOptions{}
+	if len(option) > 0 {
+		reqOption = option[0]
+	}
+	return client.AppsV1().StatefulSets(namespace).Get(ctx, name, reqOption)
+}
+
+func (w *k8sWorkload) GetReplicaSet(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name string,
+	option ...v1Option.GetOptions,
+) (*v1.ReplicaSet, error) {
+	reqOption := v1Option.GetOptions{}
+	if len(option) > 0 {
+		reqOption = option[0]
+	}
+	return client.AppsV1().ReplicaSets(namespace).Get(ctx, name, reqOption)
+}
+
+// GetResourceName returns an empty string when the kind is not served by the api version
+func (w *k8sWorkload) GetResourceName(
+	client kubernetes.Interface,
+	apiVersion, kind string,
+) (string, error) {
+	resources, err := client.Discovery().ServerResourcesForGroupVersion(apiVersion)
+	if err != nil {
+		return "", err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == kind && !strings.Contains(resource.Name, "/") {
+			return resource.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func (w *k8sWorkload) getObjectPath(apiVersion, resource, namespace, name string) string {
+	// Core group resources are served under /api, every other group under /apis
+	prefix := "/apis"
+	if !strings.Contains(apiVersion, "/") {
+		prefix = "/api"
+	}
+	return fmt.Sprintf("%s/%s/namespaces/%s/%s/%s", prefix, apiVersion, namespace, resource, name)
+}
+
+func (w *k8sWorkload) GetObject(
+	ctx context.Context,
+	client kubernetes.Interface,
+	apiVersion, resource, namespace, name string,
+) (*unstructured.Unstructured, error) {
+	raw, err := client.Discovery().RESTClient().
+		Get().
+		AbsPath(w.getObjectPath(apiVersion, resource, namespace, name)).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	data := &unstructured.Unstructured{}
+	err = data.UnmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (w *k8sWorkload) GetScale(
+	ctx context.Context,
+	client kubernetes.Interface,
+	apiVersion, resource, namespace, name string,
+) (*v1Autoscaling.Scale, error) {
+	raw, err := client.Discovery().RESTClient().
+		Get().
+		AbsPath(w.getObjectPath(apiVersion, resource, namespace, name), "scale").
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	data := &v1Autoscaling.Scale{}
+	err = json.Unmarshal(raw, data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (w *k8sWorkload) UpdateScale(
+	ctx context.Context,
+	client kubernetes.Interface,
+	apiVersion, resource, namespace, name string,
+	replicas int32,
+) (*v1Autoscaling.Scale, error) {
+	patch, err := json.Marshal(
+		map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := client.Discovery().RESTClient().
+		Patch(types.MergePatchType).
+		AbsPath(w.getObjectPath(apiVersion, resource, namespace, name), "scale").
+		Body(patch).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	data := &v1Autoscaling.Scale{}
+	err = json.Unmarshal(raw, data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (w *k8sWorkload) GetPodList(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace string,
+	option ...v1Option.ListOptions,
+) (*v1Core.PodList, error) {
+	reqOption := v1Option.ListOptions{}
+	if len(option) > 0 {
+		reqOption = option[0]
+	}
+	return client.CoreV1().Pods(namespace).List(ctx, reqOption)
+}
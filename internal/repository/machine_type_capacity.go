@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MachineTypeCapacity interface {
+	GetMachineTypeCapacity(
+		tx *gorm.DB,
+		datacenter model.DatacenterProvider,
+		machineType string,
+	) (*model.MachineTypeCapacity, error)
+	InsertMachineTypeCapacity(tx *gorm.DB, data *model.MachineTypeCapacity) error
+}
+
+type machineTypeCapacity struct {
+}
+
+func newMachineTypeCapacity() MachineTypeCapacity {
+	return &machineTypeCapacity{}
+}
+
+func (m *machineTypeCapacity) GetMachineTypeCapacity(
+	tx *gorm.DB,
+	datacenter model.DatacenterProvider,
+	machineType string,
+) (*model.MachineTypeCapacity, error) {
+	data := &model.MachineTypeCapacity{}
+	tx = tx.Model(data).
+		Where("datacenter = ? and machine_type = ?", datacenter, machineType).
+		First(data)
+	return data, tx.Error
+}
+
+func (m *machineTypeCapacity) InsertMachineTypeCapacity(
+	tx *gorm.DB,
+	data *model.MachineTypeCapacity,
+) error {
+	return tx.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "datacenter"}, {Name: "machine_type"}},
+			DoNothing: true,
+		},
+	).Create(data).Error
+}
@@ -7,7 +7,10 @@ import (
 type DatacenterProvider string
 
 const (
-	GCP DatacenterProvider = "GCP"
+	GCP        DatacenterProvider = "GCP"
+	AWS        DatacenterProvider = "AWS"
+	Azure      DatacenterProvider = "AZURE"
+	Kubeconfig DatacenterProvider = "KUBECONFIG"
 )
 
 type Datacenter struct {
@@ -8,26 +8,45 @@ import (
 type EventStatus string
 
 const (
-	EventFailed    EventStatus = "FAILED"
-	EventSuccess   EventStatus = "SUCCESS"
-	EventExecuting EventStatus = "EXECUTING"
-	EventPrescaled EventStatus = "PRESCALED"
-	EventWatching  EventStatus = "WATCHING"
-	EventPending   EventStatus = "PENDING"
+	EventFailed        EventStatus = "FAILED"
+	EventSuccess       EventStatus = "SUCCESS"
+	EventExecuting     EventStatus = "EXECUTING"
+	EventPrescaled     EventStatus = "PRESCALED"
+	EventWatching      EventStatus = "WATCHING"
+	EventPending       EventStatus = "PENDING"
+	EventRestoring     EventStatus = "RESTORING"
+	EventRestoreFailed EventStatus = "RESTORE_FAILED"
+	EventCancelled     EventStatus = "CANCELLED"
+)
+
+// NodePoolDistribution decides how the demand of a workload matching several node pools is split between them
+type NodePoolDistribution string
+
+const (
+	NodePoolDistributionProportional      NodePoolDistribution = "PROPORTIONAL"
+	NodePoolDistributionPreferredAffinity NodePoolDistribution = "PREFERRED_AFFINITY"
+	NodePoolDistributionPrimaryPool       NodePoolDistribution = "PRIMARY_POOL"
 )
 
 type Event struct {
 	BaseModel
-	Name              string
-	StartTime         time.Time
-	EndTime           time.Time
-	ClusterID         gormDatatype.UUID
-	Status            EventStatus `gorm:"default:PENDING"`
-	Message           string
-	CalculateNodePool bool
-	Cluster           Cluster `gorm:"ForeignKey:ClusterID;constraint:OnDelete:CASCADE"`
-	ExecuteConfigAt   time.Time
-	WatchingAt        time.Time
+	Name                 string
+	StartTime            time.Time
+	EndTime              time.Time
+	ClusterID            gormDatatype.UUID
+	Status               EventStatus `gorm:"default:PENDING"`
+	Message              string
+	CalculateNodePool    bool
+	ForceHPAApply        bool                 `gorm:"column:force_hpa_apply"`
+	NodePoolDistribution NodePoolDistribution `gorm:"default:PROPORTIONAL"`
+	PrimaryNodePool      string
+	Cluster              Cluster `gorm:"ForeignKey:ClusterID;constraint:OnDelete:CASCADE"`
+	ExecuteConfigAt      time.Time
+	WatchingAt           time.Time
+	LeaseOwner           string
+	LeaseExpiredAt       *time.Time
+	// RestoredStatus is the status a restoring event gets once restored
+	RestoredStatus EventStatus
 }
 
 func (e *Event) TableName() string {
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+)
+
+type EventTemplate struct {
+	BaseModel
+	Name                    string `gorm:"uniqueIndex:idx_event_template_name"`
+	CalculateNodePool       bool
+	ForceHPAApply           bool `gorm:"column:force_hpa_apply"`
+	ModifiedHPAConfigs      gormDatatype.JSON
+	ModifiedWorkloadConfigs gormDatatype.JSON
+	ClusterID               gormDatatype.UUID `gorm:"uniqueIndex:idx_event_template_name"`
+	Cluster                 Cluster           `gorm:"ForeignKey:ClusterID;constraint:OnDelete:CASCADE"`
+}
+
+func (e *EventTemplate) TableName() string {
+	return "event_templates"
+}
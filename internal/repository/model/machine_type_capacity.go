@@ -0,0 +1,13 @@
+package model
+
+type MachineTypeCapacity struct {
+	BaseModel
+	Datacenter  DatacenterProvider `gorm:"uniqueIndex:idx_machine_type_capacity"`
+	MachineType string             `gorm:"uniqueIndex:idx_machine_type_capacity"`
+	GuestCPUs   int32              `gorm:"column:guest_cpus"`
+	MemoryMB    int32              `gorm:"column:memory_mb"`
+}
+
+func (m *MachineTypeCapacity) TableName() string {
+	return "machine_type_capacities"
+}
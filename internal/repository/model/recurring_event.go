@@ -0,0 +1,45 @@
+package model
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"time"
+)
+
+type RecurringEvent struct {
+	BaseModel
+	Name                       string
+	CronExpression             string
+	Timezone                   string
+	DurationMinutes            int32
+	ExecuteConfigOffsetMinutes int32
+	WatchingOffsetMinutes      int32
+	CalculateNodePool          bool
+	ForceHPAApply              bool                 `gorm:"column:force_hpa_apply"`
+	NodePoolDistribution       NodePoolDistribution `gorm:"default:PROPORTIONAL"`
+	PrimaryNodePool            string
+	MergeConflicts             bool
+	ModifiedHPAConfigs         gormDatatype.JSON
+	ModifiedWorkloadConfigs    gormDatatype.JSON
+	ClusterID                  gormDatatype.UUID
+	Cluster                    Cluster `gorm:"ForeignKey:ClusterID;constraint:OnDelete:CASCADE"`
+}
+
+func (r *RecurringEvent) TableName() string {
+	return "recurring_events"
+}
+
+type RecurringEventOccurrence struct {
+	BaseModel
+	RecurringEventID        gormDatatype.UUID `gorm:"uniqueIndex:idx_recurring_event_occurrence"`
+	RecurringEvent          RecurringEvent    `gorm:"ForeignKey:RecurringEventID;constraint:OnDelete:CASCADE"`
+	StartTime               time.Time         `gorm:"uniqueIndex:idx_recurring_event_occurrence"`
+	Skipped                 bool
+	ModifiedHPAConfigs      gormDatatype.JSON
+	ModifiedWorkloadConfigs gormDatatype.JSON
+	EventID                 *gormDatatype.UUID
+	Event                   *Event `gorm:"ForeignKey:EventID;constraint:OnDelete:SET NULL"`
+}
+
+func (r *RecurringEventOccurrence) TableName() string {
+	return "recurring_event_occurrences"
+}
@@ -10,18 +10,29 @@ const (
 	HPAUpdateFailed  HPAUpdateStatus = "FAILED"
 	HPAUpdateSuccess HPAUpdateStatus = "SUCCESS"
 	HPAUpdatePending HPAUpdateStatus = "PENDING"
+	HPAUpdateSkipped HPAUpdateStatus = "SKIPPED"
 )
 
 type ScheduledHPAConfig struct {
 	BaseModel
-	Name      string
-	MinPods   *int32
-	MaxPods   int32
-	Namespace string
-	Status    HPAUpdateStatus `gorm:"default:PENDING"`
-	Message   string
-	EventID   gormDatatype.UUID
-	Event     Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+	Kind             string `gorm:"default:HorizontalPodAutoscaler"`
+	Name             string
+	MinPods          *int32
+	MaxPods          int32
+	OriginalMinPods  *int32
+	OriginalMaxPods  *int32
+	Behavior         gormDatatype.JSON
+	MetricTargets    gormDatatype.JSON
+	OriginalBehavior gormDatatype.JSON
+	OriginalMetrics  gormDatatype.JSON
+	Namespace        string
+	Status           HPAUpdateStatus `gorm:"default:PENDING"`
+	Message          string
+	RestoreStatus    HPAUpdateStatus `gorm:"default:PENDING"`
+	RestoreMessage   string
+	EventID          gormDatatype.UUID
+	Event            Event              `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+	Steps            []ScheduledHPAStep `gorm:"ForeignKey:ScheduledHPAConfigID"`
 }
 
 func (s *ScheduledHPAConfig) TableName() string {
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"time"
+)
+
+type HPAStepPhase string
+
+const (
+	HPAStepRampUp   HPAStepPhase = "RAMP_UP"
+	HPAStepRampDown HPAStepPhase = "RAMP_DOWN"
+)
+
+type ScheduledHPAStep struct {
+	BaseModel
+	Phase                HPAStepPhase
+	ExecuteAt            time.Time
+	MinPods              *int32
+	MaxPods              int32
+	Status               HPAUpdateStatus `gorm:"default:PENDING"`
+	Message              string
+	ScheduledHPAConfigID gormDatatype.UUID
+	ScheduledHPAConfig   ScheduledHPAConfig `gorm:"ForeignKey:ScheduledHPAConfigID;constraint:OnDelete:CASCADE"`
+}
+
+func (s *ScheduledHPAStep) TableName() string {
+	return "scheduled_hpa_steps"
+}
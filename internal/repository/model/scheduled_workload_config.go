@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+)
+
+type WorkloadUpdateStatus string
+
+const (
+	WorkloadUpdateFailed  WorkloadUpdateStatus = "FAILED"
+	WorkloadUpdateSuccess WorkloadUpdateStatus = "SUCCESS"
+	WorkloadUpdatePending WorkloadUpdateStatus = "PENDING"
+	WorkloadUpdateSkipped WorkloadUpdateStatus = "SKIPPED"
+)
+
+type ScheduledWorkloadConfig struct {
+	BaseModel
+	Kind             string
+	Name             string
+	Namespace        string
+	Replicas         int32
+	OriginalReplicas *int32
+	Status           WorkloadUpdateStatus `gorm:"default:PENDING"`
+	Message          string
+	RestoreStatus    WorkloadUpdateStatus `gorm:"default:PENDING"`
+	RestoreMessage   string
+	EventID          gormDatatype.UUID
+	Event            Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+}
+
+func (s *ScheduledWorkloadConfig) TableName() string {
+	return "scheduled_workload_configs"
+}
@@ -2,12 +2,31 @@ package model
 
 import gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
 
+type NodePoolUpdateStatus string
+
+const (
+	NodePoolUpdateFailed  NodePoolUpdateStatus = "FAILED"
+	NodePoolUpdateSuccess NodePoolUpdateStatus = "SUCCESS"
+	NodePoolUpdatePending NodePoolUpdateStatus = "PENDING"
+	NodePoolUpdateSkipped NodePoolUpdateStatus = "SKIPPED"
+)
+
 type UpdatedNodePool struct {
 	BaseModel
-	NodePoolName string
-	MaxNode      int32
-	EventID      gormDatatype.UUID
-	Event        Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
+	NodePoolName               string
+	MaxNode                    int32
+	OriginalMinNode            int32
+	OriginalMaxNode            int32
+	OriginalAutoscalingEnabled bool
+	OriginalAutoprovisioned    bool
+	OriginalTotalNodeLimit     bool
+	OriginalLocationPolicy     int32
+	AutoscalingModified        bool
+	DistributionStrategy       NodePoolDistribution
+	RestoreStatus              NodePoolUpdateStatus `gorm:"default:PENDING"`
+	RestoreMessage             string
+	EventID                    gormDatatype.UUID
+	Event                      Event `gorm:"ForeignKey:EventID;constraint:OnDelete:CASCADE"`
 }
 
 func (UpdatedNodePool) TableName() string {
@@ -0,0 +1,43 @@
+package model
+
+import (
+	gormDatatype "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"gorm.io/gorm"
+	"time"
+)
+
+type WorkloadStatus struct {
+	CreatedAt                 time.Time         `gorm:"primaryKey;default:now()"`
+	ScheduledWorkloadConfigID gormDatatype.UUID `gorm:"primaryKey"`
+	Replicas                  int32
+	AvailableReplicas         int32
+	ReadyReplicas             int32
+	UnavailableReplicas       int32
+	ScheduledWorkloadConfig   ScheduledWorkloadConfig `gorm:"ForeignKey:ScheduledWorkloadConfigID;constraint:OnDelete:CASCADE"`
+}
+
+func (WorkloadStatus) TableName() string {
+	return "workload_status"
+}
+
+func (w *WorkloadStatus) AdditionalMigration(db *gorm.DB) error {
+	tableName := w.TableName()
+	var exist bool
+	row := db.Raw(
+		"select exists(select * from timescaledb_information.hypertables where hypertable_name = ?)",
+		tableName,
+	).Row()
+	if err := row.Err(); err != nil {
+		return err
+	}
+	if err := row.Scan(&exist); err != nil {
+		return err
+	}
+	if !exist {
+		return db.Exec(
+			`select create_hypertable(?,'created_at', 'scheduled_workload_config_id', '4')`,
+			tableName,
+		).Error
+	}
+	return nil
+}
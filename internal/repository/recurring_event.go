@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type RecurringEvent interface {
+	GetRecurringEventByID(tx *gorm.DB, id uuid.UUID) (*model.RecurringEvent, error)
+	ListRecurringEventByClusterID(tx *gorm.DB, id uuid.UUID) ([]*model.RecurringEvent, error)
+	ListAllRecurringEvent(tx *gorm.DB) ([]*model.RecurringEvent, error)
+	InsertRecurringEvent(tx *gorm.DB, data *model.RecurringEvent) error
+	DeleteRecurringEvent(tx *gorm.DB, id uuid.UUID) error
+	GetOccurrenceForUpdate(
+		tx *gorm.DB,
+		recurringEventID uuid.UUID,
+		startTime time.Time,
+	) (*model.RecurringEventOccurrence, error)
+	ListOccurrenceAfter(
+		tx *gorm.DB,
+		recurringEventID uuid.UUID,
+		startTime time.Time,
+	) ([]*model.RecurringEventOccurrence, error)
+	InsertOccurrenceIfNotExist(tx *gorm.DB, data *model.RecurringEventOccurrence) (bool, error)
+	SaveOccurrence(tx *gorm.DB, data *model.RecurringEventOccurrence) error
+}
+
+type recurringEvent struct {
+}
+
+func newRecurringEvent() RecurringEvent {
+	return &recurringEvent{}
+}
+
+func (r *recurringEvent) GetRecurringEventByID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*model.RecurringEvent, error) {
+	data := &model.RecurringEvent{}
+	tx = tx.Model(data).First(data, id)
+	return data, tx.Error
+}
+
+func (r *recurringEvent) ListRecurringEventByClusterID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) ([]*model.RecurringEvent, error) {
+	var data []*model.RecurringEvent
+	tx = tx.Model(&model.RecurringEvent{}).Where("cluster_id = ?", id).Find(&data)
+	return data, tx.Error
+}
+
+func (r *recurringEvent) ListAllRecurringEvent(tx *gorm.DB) ([]*model.RecurringEvent, error) {
+	var data []*model.RecurringEvent
+	tx = tx.Model(&model.RecurringEvent{}).Find(&data)
+	return data, tx.Error
+}
+
+func (r *recurringEvent) InsertRecurringEvent(tx *gorm.DB, data *model.RecurringEvent) error {
+	return tx.Create(data).Error
+}
+
+func (r *recurringEvent) DeleteRecurringEvent(tx *gorm.DB, id uuid.UUID) error {
+	return tx.Delete(&model.RecurringEvent{}, id).Error
+}
+
+func (r *recurringEvent) GetOccurrenceForUpdate(
+	tx *gorm.DB,
+	recurringEventID uuid.UUID,
+	startTime time.Time,
+) (*model.RecurringEventOccurrence, error) {
+	data := &model.RecurringEventOccurrence{}
+	tx = tx.Model(data).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("recurring_event_id = ? and start_time = ?", recurringEventID, startTime.UTC()).
+		First(data)
+	return data, tx.Error
+}
+
+func (r *recurringEvent) ListOccurrenceAfter(
+	tx *gorm.DB,
+	recurringEventID uuid.UUID,
+	startTime time.Time,
+) ([]*model.RecurringEventOccurrence, error) {
+	var data []*model.RecurringEventOccurrence
+	tx = tx.Model(&model.RecurringEventOccurrence{}).
+		Where("recurring_event_id = ? and start_time > ?", recurringEventID, startTime.UTC()).
+		Order("start_time").
+		Find(&data)
+	return data, tx.Error
+}
+
+// InsertOccurrenceIfNotExist reports false when another worker already holds the occurrence
+func (r *recurringEvent) InsertOccurrenceIfNotExist(
+	tx *gorm.DB,
+	data *model.RecurringEventOccurrence,
+) (bool, error) {
+	tx = tx.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "recurring_event_id"}, {Name: "start_time"}},
+			DoNothing: true,
+		},
+	).Create(data)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+func (r *recurringEvent) SaveOccurrence(tx *gorm.DB, data *model.RecurringEventOccurrence) error {
+	return tx.Save(data).Error
+}
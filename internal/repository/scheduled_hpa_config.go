@@ -46,7 +46,14 @@ func (s *scheduledHPAConfig) ListScheduledHPAConfigByEventID(
 	id uuid.UUID,
 ) ([]*model.ScheduledHPAConfig, error) {
 	var data []*model.ScheduledHPAConfig
-	tx = tx.Model(&model.ScheduledHPAConfig{}).Where("event_id = ?", id).Find(&data)
+	tx = tx.Model(&model.ScheduledHPAConfig{}).
+		Preload(
+			"Steps", func(tx *gorm.DB) *gorm.DB {
+				return tx.Order("execute_at")
+			},
+		).
+		Where("event_id = ?", id).
+		Find(&data)
 	return data, tx.Error
 }
 
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type ScheduledHPAStep interface {
+	GetScheduledHPAStepByID(tx *gorm.DB, id uuid.UUID) (*model.ScheduledHPAStep, error)
+	SaveScheduledHPAStep(tx *gorm.DB, data *model.ScheduledHPAStep) error
+	UpdateScheduledHPAStepStatusByEventID(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+		fromStatus model.HPAUpdateStatus,
+		toStatus model.HPAUpdateStatus,
+		message string,
+	) error
+}
+
+type scheduledHPAStep struct {
+}
+
+func newScheduledHPAStep() ScheduledHPAStep {
+	return &scheduledHPAStep{}
+}
+
+func (s *scheduledHPAStep) GetScheduledHPAStepByID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*model.ScheduledHPAStep, error) {
+	data := &model.ScheduledHPAStep{}
+	tx = tx.Model(data).First(data, id)
+	return data, tx.Error
+}
+
+func (s *scheduledHPAStep) SaveScheduledHPAStep(
+	tx *gorm.DB,
+	data *model.ScheduledHPAStep,
+) error {
+	return tx.Save(data).Error
+}
+
+func (s *scheduledHPAStep) UpdateScheduledHPAStepStatusByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	fromStatus model.HPAUpdateStatus,
+	toStatus model.HPAUpdateStatus,
+	message string,
+) error {
+	return tx.Model(&model.ScheduledHPAStep{}).
+		Where(
+			`status = ? and scheduled_hpa_config_id in (
+    select id from scheduled_hpa_configs where event_id = ? and deleted_at is null)`,
+			fromStatus,
+			eventID,
+		).
+		Updates(map[string]interface{}{"status": toStatus, "message": message}).Error
+}
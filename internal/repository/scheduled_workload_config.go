@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type ScheduledWorkloadConfig interface {
+	GetScheduledWorkloadConfigByID(tx *gorm.DB, id uuid.UUID) (*model.ScheduledWorkloadConfig, error)
+	ListScheduledWorkloadConfigByEventID(tx *gorm.DB, id uuid.UUID) ([]*model.ScheduledWorkloadConfig, error)
+	InsertScheduledWorkloadConfig(tx *gorm.DB, data *model.ScheduledWorkloadConfig) error
+	InsertBatchScheduledWorkloadConfig(
+		tx *gorm.DB,
+		data []*model.ScheduledWorkloadConfig,
+	) error
+	DeletePermanentAllWorkloadConfigByEventID(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+	) error
+	DeleteAllWorkloadConfigByEventID(tx *gorm.DB, eventID uuid.UUID) error
+	SaveScheduledWorkloadConfig(
+		tx *gorm.DB,
+		data *model.ScheduledWorkloadConfig,
+	) error
+}
+
+type scheduledWorkloadConfig struct {
+}
+
+func newScheduledWorkloadConfig() ScheduledWorkloadConfig {
+	return &scheduledWorkloadConfig{}
+}
+
+func (s *scheduledWorkloadConfig) GetScheduledWorkloadConfigByID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*model.ScheduledWorkloadConfig, error) {
+	data := &model.ScheduledWorkloadConfig{}
+	tx = tx.Model(data).First(data, id)
+	return data, tx.Error
+}
+
+func (s *scheduledWorkloadConfig) ListScheduledWorkloadConfigByEventID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) ([]*model.ScheduledWorkloadConfig, error) {
+	var data []*model.ScheduledWorkloadConfig
+	tx = tx.Model(&model.ScheduledWorkloadConfig{}).Where("event_id = ?", id).Find(&data)
+	return data, tx.Error
+}
+
+func (s *scheduledWorkloadConfig) InsertScheduledWorkloadConfig(
+	tx *gorm.DB,
+	data *model.ScheduledWorkloadConfig,
+) error {
+	return tx.Create(data).Error
+}
+
+func (s *scheduledWorkloadConfig) InsertBatchScheduledWorkloadConfig(
+	tx *gorm.DB,
+	data []*model.ScheduledWorkloadConfig,
+) error {
+	return tx.Create(data).Error
+}
+
+func (s *scheduledWorkloadConfig) DeletePermanentAllWorkloadConfigByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) error {
+	return tx.Unscoped().Where("event_id = ?", eventID).Delete(&model.ScheduledWorkloadConfig{}).Error
+}
+
+func (s *scheduledWorkloadConfig) DeleteAllWorkloadConfigByEventID(tx *gorm.DB, eventID uuid.UUID) error {
+	return tx.Delete(&model.ScheduledWorkloadConfig{}, "event_id = ?", eventID).Error
+}
+
+func (s *scheduledWorkloadConfig) SaveScheduledWorkloadConfig(
+	tx *gorm.DB,
+	data *model.ScheduledWorkloadConfig,
+) error {
+	return tx.Save(data).Error
+}
@@ -11,6 +11,8 @@ type UpdatedNodePool interface {
 		tx *gorm.DB,
 		eventID uuid.UUID,
 	) ([]*model.UpdatedNodePool, error)
+	GetUpdatedNodePoolByID(tx *gorm.DB, id uuid.UUID) (*model.UpdatedNodePool, error)
+	SaveUpdatedNodePool(tx *gorm.DB, data *model.UpdatedNodePool) error
 }
 
 type updatedNodePool struct {
@@ -28,3 +30,16 @@ func (u *updatedNodePool) GetAllUpdatedNodePoolByEventID(
 	err := tx.Model(&model.UpdatedNodePool{}).Where("event_id = ?", eventID).Find(&output).Error
 	return output, err
 }
+
+func (u *updatedNodePool) GetUpdatedNodePoolByID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*model.UpdatedNodePool, error) {
+	data := &model.UpdatedNodePool{}
+	tx = tx.Model(data).First(data, id)
+	return data, tx.Error
+}
+
+func (u *updatedNodePool) SaveUpdatedNodePool(tx *gorm.DB, data *model.UpdatedNodePool) error {
+	return tx.Save(data).Error
+}
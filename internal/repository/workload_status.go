@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type WorkloadStatus interface {
+	GetAllWorkloadStatusByScheduledWorkloadConfigID(
+		tx *gorm.DB,
+		scheduledWorkloadConfigID uuid.UUID,
+	) ([]*model.WorkloadStatus, error)
+}
+
+type workloadStatus struct {
+}
+
+func newWorkloadStatus() WorkloadStatus {
+	return &workloadStatus{}
+}
+
+func (w *workloadStatus) GetAllWorkloadStatusByScheduledWorkloadConfigID(
+	tx *gorm.DB,
+	scheduledWorkloadConfigID uuid.UUID,
+) ([]*model.WorkloadStatus, error) {
+	var data []*model.WorkloadStatus
+	err := tx.Model(&model.WorkloadStatus{}).Where(
+		"scheduled_workload_config_id = ?",
+		scheduledWorkloadConfigID,
+	).Find(&data).Error
+	return data, err
+}
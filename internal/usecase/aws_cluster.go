@@ -0,0 +1,281 @@
+package useCase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	awsCustomAuth "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/auth/aws_custom"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/client"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"strings"
+)
+
+type AWSCluster interface {
+	RegisterAWSCredentials(credentialsName string, awsConfig aws.Config)
+	GetEKSClusterClient(awsConfig aws.Config) repository.EKSClient
+	GetAllClustersInAWSRegion(
+		ctx context.Context,
+		region string,
+		clusterClient repository.EKSClient,
+	) ([]*UCEntity.AWSClusterData, error)
+	RegisterClusters(
+		tx *gorm.DB,
+		datacenterID uuid.UUID,
+		listCluster []*UCEntity.AWSClusterData,
+	) error
+	GetKubernetesClusterClient(
+		credentialsName string,
+		clusterData *UCEntity.ClusterData,
+	) (*kubernetes.Clientset, error)
+	GetAllNodeGroupsInEKSCluster(
+		ctx context.Context,
+		clusterClient repository.EKSClient,
+		clusterName string,
+	) ([]*UCEntity.AWSNodeGroupData, error)
+	GetNodesFromEKSNodeGroup(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		nodeGroupName string,
+	) (*UCEntity.K8sNodeListData, error)
+	UpdateNodeGroupScalingConfig(
+		ctx context.Context,
+		clusterClient repository.EKSClient,
+		clusterName, nodeGroupName string,
+		scalingConfig *types.NodegroupScalingConfig,
+	) (*UCEntity.AWSClusterUpdateData, error)
+	GetNodeGroupUpdate(
+		ctx context.Context,
+		clusterClient repository.EKSClient,
+		clusterName, nodeGroupName, updateID string,
+	) (*UCEntity.AWSClusterUpdateData, error)
+}
+
+type awsCluster struct {
+	validatorInst  *validator.Validate
+	clusterRepo    repository.Cluster
+	awsClusterRepo repository.AWSCluster
+	k8sNodeRepo    repository.K8sNode
+}
+
+func newAWSCluster(
+	validatorInst *validator.Validate,
+	clusterRepo repository.Cluster,
+	awsClusterRepo repository.AWSCluster,
+	k8sNodeRepo repository.K8sNode,
+) AWSCluster {
+	return &awsCluster{
+		validatorInst:  validatorInst,
+		clusterRepo:    clusterRepo,
+		awsClusterRepo: awsClusterRepo,
+		k8sNodeRepo:    k8sNodeRepo,
+	}
+}
+
+func (c *awsCluster) RegisterAWSCredentials(credentialsName string, awsConfig aws.Config) {
+	awsCustomAuth.RegisterAWSCredentials(credentialsName, awsConfig)
+}
+
+func (c *awsCluster) GetEKSClusterClient(awsConfig aws.Config) repository.EKSClient {
+	return eks.NewFromConfig(awsConfig)
+}
+
+func (c *awsCluster) GetAllClustersInAWSRegion(
+	ctx context.Context,
+	region string,
+	clusterClient repository.EKSClient,
+) ([]*UCEntity.AWSClusterData, error) {
+	clusterNames, err := c.awsClusterRepo.GetAllClusterName(ctx, clusterClient)
+	if err != nil {
+		return nil, err
+	}
+	var clusterData []*UCEntity.AWSClusterData
+	for _, clusterName := range clusterNames {
+		cluster, err := c.awsClusterRepo.GetCluster(ctx, clusterClient, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		var certificate string
+		if cluster.CertificateAuthority != nil {
+			certificate = aws.ToString(cluster.CertificateAuthority.Data)
+		}
+		clusterData = append(
+			clusterData, &UCEntity.AWSClusterData{
+				ClusterData: UCEntity.ClusterData{
+					Name: fmt.Sprintf(
+						"eks_%s_%s",
+						region,
+						aws.ToString(cluster.Name),
+					),
+					Certificate:    certificate,
+					ServerEndpoint: aws.ToString(cluster.Endpoint),
+					Datacenter: UCEntity.DatacenterDetailedData{
+						Datacenter: model.AWS,
+					},
+				},
+				Region: region,
+			},
+		)
+	}
+	return clusterData, nil
+}
+
+func (c *awsCluster) RegisterClusters(
+	tx *gorm.DB,
+	datacenterID uuid.UUID,
+	listCluster []*UCEntity.AWSClusterData,
+) error {
+	var clusters []*model.Cluster
+	for _, cluster := range listCluster {
+		metadata := UCEntity.AWSClusterMetaData{Region: cluster.Region}
+		metadataByte, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		clusterModel := &model.Cluster{
+			Name:                cluster.Name,
+			ServerEndpoint:      cluster.ServerEndpoint,
+			Certificate:         cluster.Certificate,
+			LatestHPAAPIVersion: cluster.LatestHPAAPIVersion,
+		}
+		clusterModel.DatacenterID.SetUUID(datacenterID)
+		clusterModel.Metadata.SetRawMessage(metadataByte)
+		clusters = append(clusters, clusterModel)
+	}
+
+	err := c.clusterRepo.InsertClusterBatch(tx, clusters)
+	if err != nil {
+		return err
+	}
+
+	for idx, cluster := range clusters {
+		listCluster[idx].ID = cluster.ID.GetUUID()
+	}
+
+	return nil
+}
+
+func (c *awsCluster) GetKubernetesClusterClient(
+	credentialsName string,
+	clusterData *UCEntity.ClusterData,
+) (*kubernetes.Clientset, error) {
+	if clusterData.Datacenter.Datacenter != model.AWS {
+		return nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+
+	// Parse AWS Cluster Name
+	clusterMetadata := strings.SplitN(clusterData.Name, "_", 3)
+
+	credentials := &k8sClient.Credentials{
+		Certificate:    clusterData.Certificate,
+		Name:           clusterData.Name,
+		ServerEndpoint: clusterData.ServerEndpoint,
+		AuthProviderConfig: &api.AuthProviderConfig{
+			Name: awsCustomAuth.AuthName,
+			Config: map[string]string{
+				awsCustomAuth.CredentialsNameConfigKey: credentialsName,
+				awsCustomAuth.ClusterNameConfigKey:     clusterMetadata[2],
+			},
+		},
+	}
+
+	return k8sClient.GetClient(credentials)
+}
+
+func (c *awsCluster) GetAllNodeGroupsInEKSCluster(
+	ctx context.Context,
+	clusterClient repository.EKSClient,
+	clusterName string,
+) ([]*UCEntity.AWSNodeGroupData, error) {
+	nodeGroupNames, err := c.awsClusterRepo.GetAllNodeGroupName(ctx, clusterClient, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var nodeGroups []*UCEntity.AWSNodeGroupData
+	for _, nodeGroupName := range nodeGroupNames {
+		nodeGroup, err := c.awsClusterRepo.GetNodeGroup(
+			ctx,
+			clusterClient,
+			clusterName,
+			nodeGroupName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		nodeGroups = append(nodeGroups, &UCEntity.AWSNodeGroupData{NodeGroupObject: nodeGroup})
+	}
+	return nodeGroups, nil
+}
+
+func (c *awsCluster) GetNodesFromEKSNodeGroup(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodeGroupName string,
+) (*UCEntity.K8sNodeListData, error) {
+	data, err := c.k8sNodeRepo.GetNodeList(
+		ctx, k8sClient, v1Option.ListOptions{
+			LabelSelector: fmt.Sprintf(
+				"%s=%s",
+				constant.EKSNodeGroupLabel,
+				nodeGroupName,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Items) == 0 {
+		return nil, errors.New(errorConstant.NoExistingNode)
+	}
+	return &UCEntity.K8sNodeListData{NodeListObject: data}, nil
+}
+
+func (c *awsCluster) UpdateNodeGroupScalingConfig(
+	ctx context.Context,
+	clusterClient repository.EKSClient,
+	clusterName, nodeGroupName string,
+	scalingConfig *types.NodegroupScalingConfig,
+) (*UCEntity.AWSClusterUpdateData, error) {
+	update, err := c.awsClusterRepo.UpdateNodeGroupScalingConfig(
+		ctx,
+		clusterClient,
+		clusterName,
+		nodeGroupName,
+		scalingConfig,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.AWSClusterUpdateData{UpdateData: update}, nil
+}
+
+func (c *awsCluster) GetNodeGroupUpdate(
+	ctx context.Context,
+	clusterClient repository.EKSClient,
+	clusterName, nodeGroupName, updateID string,
+) (*UCEntity.AWSClusterUpdateData, error) {
+	update, err := c.awsClusterRepo.GetNodeGroupUpdate(
+		ctx,
+		clusterClient,
+		clusterName,
+		nodeGroupName,
+		updateID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.AWSClusterUpdateData{UpdateData: update}, nil
+}
@@ -0,0 +1,208 @@
+package useCase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awsCredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AWSDatacenter interface {
+	SaveDatacenterDetailedData(tx *gorm.DB, data *UCEntity.DatacenterDetailedData) (
+		uuid.UUID,
+		error,
+	)
+	SaveDatacenter(
+		tx *gorm.DB,
+		data UCEntity.DatacenterData,
+		credentials *UCEntity.AWSCredentials,
+	) (uuid.UUID, error)
+	ParseCredentials(data UCEntity.DatacenterData) (*UCEntity.AWSCredentials, error)
+	GetAWSConfig(ctx context.Context, data UCEntity.DatacenterData) (aws.Config, error)
+	SaveTemporaryDatacenter(
+		ctx context.Context,
+		data UCEntity.DatacenterData,
+		credentials *UCEntity.AWSCredentials,
+	) (uuid.UUID, error)
+	GetTemporaryDatacenterData(ctx context.Context, id uuid.UUID) (
+		*UCEntity.DatacenterDetailedData,
+		error,
+	)
+	GetDatacenterData(tx *gorm.DB, id uuid.UUID) (*UCEntity.DatacenterDetailedData, error)
+}
+
+type awsDatacenter struct {
+	datacenterRepo repository.Datacenter
+	validatorInst  *validator.Validate
+}
+
+func newAWSDatacenter(
+	datacenterRepo repository.Datacenter,
+	validatorInst *validator.Validate,
+) AWSDatacenter {
+	return &awsDatacenter{
+		datacenterRepo: datacenterRepo,
+		validatorInst:  validatorInst,
+	}
+}
+
+func (d *awsDatacenter) ParseCredentials(data UCEntity.DatacenterData) (
+	*UCEntity.AWSCredentials,
+	error,
+) {
+	credentials := &UCEntity.AWSCredentials{}
+	err := json.Unmarshal(data.Credentials, credentials)
+	if err != nil {
+		return nil, err
+	}
+	err = d.validatorInst.Struct(credentials)
+	if err != nil {
+		return nil, errors.New(errorConstant.AWSCredentialsInvalid)
+	}
+	return credentials, nil
+}
+
+func (d *awsDatacenter) SaveTemporaryDatacenter(
+	ctx context.Context,
+	data UCEntity.DatacenterData,
+	credentials *UCEntity.AWSCredentials,
+) (uuid.UUID, error) {
+	metaData := &UCEntity.AWSDatacenterMetaData{
+		Region:      *credentials.Region,
+		AccessKeyID: *credentials.AccessKeyID,
+	}
+	metaDataByte, err := json.Marshal(metaData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	datacenterModel := &model.Datacenter{
+		Name:       data.Name,
+		Datacenter: model.AWS,
+	}
+	datacenterModel.Credentials.SetRawMessage(data.Credentials)
+	datacenterModel.Metadata.SetRawMessage(metaDataByte)
+	err = d.datacenterRepo.InsertTemporaryDatacenter(ctx, datacenterModel, time.Hour)
+	return datacenterModel.ID.GetUUID(), err
+}
+
+func (d *awsDatacenter) GetTemporaryDatacenterData(
+	ctx context.Context,
+	id uuid.UUID,
+) (*UCEntity.DatacenterDetailedData, error) {
+	data, err := d.datacenterRepo.GetTemporaryDatacenterByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.DatacenterDetailedData{
+		ID:          data.ID.GetUUID(),
+		Name:        data.Name,
+		Credentials: data.Credentials.GetRawMessage(),
+		Metadata:    data.Metadata.GetRawMessage(),
+		Datacenter:  data.Datacenter,
+	}, nil
+}
+
+func (d *awsDatacenter) GetDatacenterData(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*UCEntity.DatacenterDetailedData, error) {
+	data, err := d.datacenterRepo.GetDatacenterByID(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.DatacenterDetailedData{
+		ID:          data.ID.GetUUID(),
+		Name:        data.Name,
+		Credentials: data.Credentials.GetRawMessage(),
+		Metadata:    data.Metadata.GetRawMessage(),
+		Datacenter:  data.Datacenter,
+	}, nil
+}
+
+func (d *awsDatacenter) SaveDatacenterDetailedData(
+	tx *gorm.DB,
+	data *UCEntity.DatacenterDetailedData,
+) (uuid.UUID, error) {
+	datacenterData := &model.Datacenter{
+		Name:       data.Name,
+		Datacenter: data.Datacenter,
+	}
+	datacenterData.ID.SetUUID(data.ID)
+	datacenterData.Credentials.SetRawMessage(data.Credentials)
+	datacenterData.Metadata.SetRawMessage(data.Metadata)
+	err := d.datacenterRepo.InsertDatacenter(tx, datacenterData)
+	return datacenterData.ID.GetUUID(), err
+}
+
+func (d *awsDatacenter) SaveDatacenter(
+	tx *gorm.DB,
+	data UCEntity.DatacenterData,
+	credentials *UCEntity.AWSCredentials,
+) (uuid.UUID, error) {
+	metaData := &UCEntity.AWSDatacenterMetaData{
+		Region:      *credentials.Region,
+		AccessKeyID: *credentials.AccessKeyID,
+	}
+	metaDataByte, err := json.Marshal(metaData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	datacenterModel := model.Datacenter{
+		Name:       data.Name,
+		Datacenter: model.AWS,
+	}
+	datacenterModel.Credentials.SetRawMessage(data.Credentials)
+	datacenterModel.Metadata.SetRawMessage(metaDataByte)
+	err = d.datacenterRepo.InsertDatacenter(tx, &datacenterModel)
+	return uuid.UUID(datacenterModel.ID), err
+}
+
+func (d *awsDatacenter) GetAWSConfig(
+	ctx context.Context,
+	data UCEntity.DatacenterData,
+) (aws.Config, error) {
+	credentials, err := d.ParseCredentials(data)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	options := []func(*config.LoadOptions) error{
+		config.WithRegion(*credentials.Region),
+		config.WithCredentialsProvider(
+			awsCredentials.NewStaticCredentialsProvider(
+				*credentials.AccessKeyID,
+				*credentials.SecretAccessKey,
+				credentials.SessionToken,
+			),
+		),
+	}
+	// A custom endpoint points every AWS API to a single compatible server, e.g. a local fake
+	if credentials.Endpoint != "" {
+		endpoint := credentials.Endpoint
+		options = append(
+			options, config.WithEndpointResolverWithOptions(
+				aws.EndpointResolverWithOptionsFunc(
+					func(_, region string, _ ...interface{}) (aws.Endpoint, error) {
+						return aws.Endpoint{
+							URL:               endpoint,
+							SigningRegion:     region,
+							HostnameImmutable: true,
+						}, nil
+					},
+				),
+			),
+		)
+	}
+
+	return config.LoadDefaultConfig(ctx, options...)
+}
@@ -0,0 +1,110 @@
+package useCase
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+)
+
+type AWSEvent interface {
+	CalculateAWSEventPlan(
+		ctx context.Context,
+		tx *gorm.DB,
+		kubernetesClient kubernetes.Interface,
+		clusterClient repository.EKSClient,
+		clusterData *UCEntity.ClusterData,
+		event *UCEntity.Event,
+	) (*UCEntity.AWSEventPlan, error)
+}
+
+type awsEvent struct {
+	eventPlanner
+	awsClusterUC AWSCluster
+}
+
+func newAWSEvent(
+	clusterUC Cluster,
+	awsClusterUC AWSCluster,
+	scheduledHPAConfigUC ScheduledHPAConfig,
+	scheduledWorkloadConfigUC ScheduledWorkloadConfig,
+) AWSEvent {
+	return &awsEvent{
+		eventPlanner: eventPlanner{
+			clusterUC:                 clusterUC,
+			scheduledHPAConfigUC:      scheduledHPAConfigUC,
+			scheduledWorkloadConfigUC: scheduledWorkloadConfigUC,
+		},
+		awsClusterUC: awsClusterUC,
+	}
+}
+
+func (a *awsEvent) CalculateAWSEventPlan(
+	ctx context.Context,
+	tx *gorm.DB,
+	kubernetesClient kubernetes.Interface,
+	clusterClient repository.EKSClient,
+	clusterData *UCEntity.ClusterData,
+	e *UCEntity.Event,
+) (*UCEntity.AWSEventPlan, error) {
+	eventPlan, unselectedK8sHPAs, err := a.selectEventHPAs(ctx, tx, kubernetesClient, clusterData, e)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UCEntity.AWSEventPlan{EventPlan: *eventPlan}
+	if len(plan.SelectedHPAs) == 0 && len(plan.SelectedWorkloads) == 0 {
+		return plan, nil
+	}
+
+	// Parse AWS Cluster Name
+	clusterMetadata := strings.SplitN(clusterData.Name, "_", 3)
+	plan.Region = clusterMetadata[1]
+	plan.ClusterName = clusterMetadata[2]
+
+	// Get EKS Managed Node Groups
+	nodeGroups, err := a.awsClusterUC.GetAllNodeGroupsInEKSCluster(
+		ctx,
+		clusterClient,
+		plan.ClusterName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodePools []*nodePoolSource
+	for _, nodeGroupData := range nodeGroups {
+		nodeGroup := nodeGroupData.NodeGroupObject
+		nodeGroupName := aws.ToString(nodeGroup.NodegroupName)
+		nodePoolPlan := &UCEntity.AWSNodePoolPlan{
+			NodePoolPlan: UCEntity.NodePoolPlan{
+				NodePoolName: nodeGroupName,
+			},
+			NodeGroupObject: nodeGroup,
+		}
+		if nodeGroup.ScalingConfig != nil {
+			nodePoolPlan.CurrentMaxNode = aws.ToInt32(nodeGroup.ScalingConfig.MaxSize)
+		}
+		plan.NodePools = append(plan.NodePools, nodePoolPlan)
+
+		// EKS doesn't expose max pods per node group, use the node allocatable pods instead
+		nodePools = append(
+			nodePools, &nodePoolSource{
+				plan: &nodePoolPlan.NodePoolPlan,
+				getNodes: func(ctx context.Context) (*UCEntity.K8sNodeListData, error) {
+					return a.awsClusterUC.GetNodesFromEKSNodeGroup(ctx, kubernetesClient, nodeGroupName)
+				},
+			},
+		)
+	}
+
+	err = a.calculateNodePools(ctx, kubernetesClient, e, &plan.EventPlan, unselectedK8sHPAs, nodePools)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,331 @@
+package useCase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/client"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type AzureCluster interface {
+	GetManagedClustersClient(
+		subscriptionID string,
+		credential azcore.TokenCredential,
+	) (*armcontainerservice.ManagedClustersClient, error)
+	GetAgentPoolsClient(
+		subscriptionID string,
+		credential azcore.TokenCredential,
+	) (*armcontainerservice.AgentPoolsClient, error)
+	GetAllClustersInAzureSubscription(
+		ctx context.Context,
+		subscriptionID string,
+		clusterClient *armcontainerservice.ManagedClustersClient,
+	) ([]*UCEntity.AzureClusterData, error)
+	RegisterClusters(
+		tx *gorm.DB,
+		datacenterID uuid.UUID,
+		listCluster []*UCEntity.AzureClusterData,
+	) error
+	GetAzureClusterMetaData(clusterData *UCEntity.ClusterData) (
+		*UCEntity.AzureClusterMetaData,
+		error,
+	)
+	GetKubernetesClusterClient(
+		ctx context.Context,
+		clusterClient *armcontainerservice.ManagedClustersClient,
+		clusterData *UCEntity.ClusterData,
+	) (*kubernetes.Clientset, error)
+	GetAllAgentPoolsInAKSCluster(
+		ctx context.Context,
+		agentPoolClient *armcontainerservice.AgentPoolsClient,
+		resourceGroup, clusterName string,
+	) ([]*UCEntity.AzureAgentPoolData, error)
+	GetAgentPool(
+		ctx context.Context,
+		agentPoolClient *armcontainerservice.AgentPoolsClient,
+		resourceGroup, clusterName, agentPoolName string,
+	) (*UCEntity.AzureAgentPoolData, error)
+	GetNodesFromAKSAgentPool(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		agentPoolName string,
+	) (*UCEntity.K8sNodeListData, error)
+	UpdateAgentPool(
+		ctx context.Context,
+		agentPoolClient *armcontainerservice.AgentPoolsClient,
+		resourceGroup, clusterName, agentPoolName string,
+		agentPool *armcontainerservice.AgentPool,
+	) (*UCEntity.AzureAgentPoolOperationData, error)
+}
+
+type azureCluster struct {
+	validatorInst    *validator.Validate
+	clusterRepo      repository.Cluster
+	azureClusterRepo repository.AzureCluster
+	k8sNodeRepo      repository.K8sNode
+}
+
+func newAzureCluster(
+	validatorInst *validator.Validate,
+	clusterRepo repository.Cluster,
+	azureClusterRepo repository.AzureCluster,
+	k8sNodeRepo repository.K8sNode,
+) AzureCluster {
+	return &azureCluster{
+		validatorInst:    validatorInst,
+		clusterRepo:      clusterRepo,
+		azureClusterRepo: azureClusterRepo,
+		k8sNodeRepo:      k8sNodeRepo,
+	}
+}
+
+func (c *azureCluster) GetManagedClustersClient(
+	subscriptionID string,
+	credential azcore.TokenCredential,
+) (*armcontainerservice.ManagedClustersClient, error) {
+	return armcontainerservice.NewManagedClustersClient(subscriptionID, credential, nil)
+}
+
+func (c *azureCluster) GetAgentPoolsClient(
+	subscriptionID string,
+	credential azcore.TokenCredential,
+) (*armcontainerservice.AgentPoolsClient, error) {
+	return armcontainerservice.NewAgentPoolsClient(subscriptionID, credential, nil)
+}
+
+func (c *azureCluster) GetAllClustersInAzureSubscription(
+	ctx context.Context,
+	subscriptionID string,
+	clusterClient *armcontainerservice.ManagedClustersClient,
+) ([]*UCEntity.AzureClusterData, error) {
+	clusters, err := c.azureClusterRepo.GetAllCluster(ctx, clusterClient)
+	if err != nil {
+		return nil, err
+	}
+	var clusterData []*UCEntity.AzureClusterData
+	for _, cluster := range clusters {
+		resourceID, err := arm.ParseResourceID(*cluster.ID)
+		if err != nil {
+			return nil, err
+		}
+		var serverEndpoint string
+		if cluster.Properties != nil {
+			fqdn := cluster.Properties.Fqdn
+			if fqdn == nil {
+				fqdn = cluster.Properties.PrivateFQDN
+			}
+			if fqdn != nil {
+				serverEndpoint = fmt.Sprintf("https://%s", *fqdn)
+			}
+		}
+		clusterData = append(
+			clusterData, &UCEntity.AzureClusterData{
+				ClusterData: UCEntity.ClusterData{
+					Name: fmt.Sprintf(
+						"aks_%s_%s_%s",
+						subscriptionID,
+						resourceID.ResourceGroupName,
+						*cluster.Name,
+					),
+					ServerEndpoint: serverEndpoint,
+					Datacenter: UCEntity.DatacenterDetailedData{
+						Datacenter: model.Azure,
+					},
+				},
+				ResourceGroup: resourceID.ResourceGroupName,
+				ClusterName:   *cluster.Name,
+				Location:      *cluster.Location,
+			},
+		)
+	}
+	return clusterData, nil
+}
+
+func (c *azureCluster) RegisterClusters(
+	tx *gorm.DB,
+	datacenterID uuid.UUID,
+	listCluster []*UCEntity.AzureClusterData,
+) error {
+	var clusters []*model.Cluster
+	for _, cluster := range listCluster {
+		metadata := UCEntity.AzureClusterMetaData{
+			ResourceGroup: cluster.ResourceGroup,
+			ClusterName:   cluster.ClusterName,
+			Location:      cluster.Location,
+		}
+		metadataByte, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		clusterModel := &model.Cluster{
+			Name:                cluster.Name,
+			ServerEndpoint:      cluster.ServerEndpoint,
+			Certificate:         cluster.Certificate,
+			LatestHPAAPIVersion: cluster.LatestHPAAPIVersion,
+		}
+		clusterModel.DatacenterID.SetUUID(datacenterID)
+		clusterModel.Metadata.SetRawMessage(metadataByte)
+		clusters = append(clusters, clusterModel)
+	}
+
+	err := c.clusterRepo.InsertClusterBatch(tx, clusters)
+	if err != nil {
+		return err
+	}
+
+	for idx, cluster := range clusters {
+		listCluster[idx].ID = cluster.ID.GetUUID()
+	}
+
+	return nil
+}
+
+func (c *azureCluster) GetAzureClusterMetaData(clusterData *UCEntity.ClusterData) (
+	*UCEntity.AzureClusterMetaData,
+	error,
+) {
+	if clusterData.Datacenter.Datacenter != model.Azure {
+		return nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+	metadata := &UCEntity.AzureClusterMetaData{}
+	err := json.Unmarshal(clusterData.Metadata, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (c *azureCluster) GetKubernetesClusterClient(
+	ctx context.Context,
+	clusterClient *armcontainerservice.ManagedClustersClient,
+	clusterData *UCEntity.ClusterData,
+) (*kubernetes.Clientset, error) {
+	metadata, err := c.GetAzureClusterMetaData(clusterData)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigs, err := c.azureClusterRepo.GetClusterAdminCredentials(
+		ctx,
+		clusterClient,
+		metadata.ResourceGroup,
+		metadata.ClusterName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(kubeconfigs) == 0 {
+		return nil, errors.New(errorConstant.KubeconfigNotFound)
+	}
+	kubeconfig := kubeconfigs[0].Value
+
+	// Keep the stored endpoint and certificate in sync with the fetched kubeconfig
+	config, err := clientcmd.Load(kubeconfig)
+	if err == nil {
+		for _, cluster := range config.Clusters {
+			clusterData.ServerEndpoint = cluster.Server
+			clusterData.Certificate = base64.StdEncoding.EncodeToString(cluster.CertificateAuthorityData)
+			break
+		}
+	}
+
+	return k8sClient.GetClientFromKubeconfig(kubeconfig, "")
+}
+
+func (c *azureCluster) GetAllAgentPoolsInAKSCluster(
+	ctx context.Context,
+	agentPoolClient *armcontainerservice.AgentPoolsClient,
+	resourceGroup, clusterName string,
+) ([]*UCEntity.AzureAgentPoolData, error) {
+	agentPools, err := c.azureClusterRepo.GetAllAgentPool(
+		ctx,
+		agentPoolClient,
+		resourceGroup,
+		clusterName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var agentPoolData []*UCEntity.AzureAgentPoolData
+	for _, agentPool := range agentPools {
+		agentPoolData = append(agentPoolData, &UCEntity.AzureAgentPoolData{AgentPoolObject: agentPool})
+	}
+	return agentPoolData, nil
+}
+
+func (c *azureCluster) GetAgentPool(
+	ctx context.Context,
+	agentPoolClient *armcontainerservice.AgentPoolsClient,
+	resourceGroup, clusterName, agentPoolName string,
+) (*UCEntity.AzureAgentPoolData, error) {
+	agentPool, err := c.azureClusterRepo.GetAgentPool(
+		ctx,
+		agentPoolClient,
+		resourceGroup,
+		clusterName,
+		agentPoolName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.AzureAgentPoolData{AgentPoolObject: agentPool}, nil
+}
+
+func (c *azureCluster) GetNodesFromAKSAgentPool(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	agentPoolName string,
+) (*UCEntity.K8sNodeListData, error) {
+	data, err := c.k8sNodeRepo.GetNodeList(
+		ctx, k8sClient, v1Option.ListOptions{
+			LabelSelector: fmt.Sprintf(
+				"%s=%s",
+				constant.AKSAgentPoolLabel,
+				agentPoolName,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Items) == 0 {
+		return nil, errors.New(errorConstant.NoExistingNode)
+	}
+	return &UCEntity.K8sNodeListData{NodeListObject: data}, nil
+}
+
+func (c *azureCluster) UpdateAgentPool(
+	ctx context.Context,
+	agentPoolClient *armcontainerservice.AgentPoolsClient,
+	resourceGroup, clusterName, agentPoolName string,
+	agentPool *armcontainerservice.AgentPool,
+) (*UCEntity.AzureAgentPoolOperationData, error) {
+	poller, err := c.azureClusterRepo.UpdateAgentPool(
+		ctx,
+		agentPoolClient,
+		resourceGroup,
+		clusterName,
+		agentPoolName,
+		agentPool,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.AzureAgentPoolOperationData{Poller: poller}, nil
+}
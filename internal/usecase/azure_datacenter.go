@@ -0,0 +1,187 @@
+package useCase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AzureDatacenter interface {
+	SaveDatacenterDetailedData(tx *gorm.DB, data *UCEntity.DatacenterDetailedData) (
+		uuid.UUID,
+		error,
+	)
+	SaveDatacenter(
+		tx *gorm.DB,
+		data UCEntity.DatacenterData,
+		SPCredentials *UCEntity.AzureServicePrincipalCredentials,
+	) (uuid.UUID, error)
+	ParseServicePrincipal(data UCEntity.DatacenterData) (
+		*UCEntity.AzureServicePrincipalCredentials,
+		error,
+	)
+	GetAzureCredential(data UCEntity.DatacenterData) (azcore.TokenCredential, error)
+	SaveTemporaryDatacenter(
+		ctx context.Context,
+		data UCEntity.DatacenterData,
+		SPCredentials *UCEntity.AzureServicePrincipalCredentials,
+	) (uuid.UUID, error)
+	GetTemporaryDatacenterData(ctx context.Context, id uuid.UUID) (
+		*UCEntity.DatacenterDetailedData,
+		error,
+	)
+	GetDatacenterData(tx *gorm.DB, id uuid.UUID) (*UCEntity.DatacenterDetailedData, error)
+}
+
+type azureDatacenter struct {
+	datacenterRepo repository.Datacenter
+	validatorInst  *validator.Validate
+}
+
+func newAzureDatacenter(
+	datacenterRepo repository.Datacenter,
+	validatorInst *validator.Validate,
+) AzureDatacenter {
+	return &azureDatacenter{
+		datacenterRepo: datacenterRepo,
+		validatorInst:  validatorInst,
+	}
+}
+
+func (d *azureDatacenter) ParseServicePrincipal(data UCEntity.DatacenterData) (
+	*UCEntity.AzureServicePrincipalCredentials,
+	error,
+) {
+	SPCredentials := &UCEntity.AzureServicePrincipalCredentials{}
+	err := json.Unmarshal(data.Credentials, SPCredentials)
+	if err != nil {
+		return nil, err
+	}
+	err = d.validatorInst.Struct(SPCredentials)
+	if err != nil {
+		return nil, errors.New(errorConstant.ServicePrincipalInvalid)
+	}
+	return SPCredentials, nil
+}
+
+func (d *azureDatacenter) SaveTemporaryDatacenter(
+	ctx context.Context,
+	data UCEntity.DatacenterData,
+	SPCredentials *UCEntity.AzureServicePrincipalCredentials,
+) (uuid.UUID, error) {
+	metaData := &UCEntity.AzureDatacenterMetaData{
+		TenantID:       *SPCredentials.TenantID,
+		ClientID:       *SPCredentials.ClientID,
+		SubscriptionID: *SPCredentials.SubscriptionID,
+	}
+	metaDataByte, err := json.Marshal(metaData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	datacenterModel := &model.Datacenter{
+		Name:       data.Name,
+		Datacenter: model.Azure,
+	}
+	datacenterModel.Credentials.SetRawMessage(data.Credentials)
+	datacenterModel.Metadata.SetRawMessage(metaDataByte)
+	err = d.datacenterRepo.InsertTemporaryDatacenter(ctx, datacenterModel, time.Hour)
+	return datacenterModel.ID.GetUUID(), err
+}
+
+func (d *azureDatacenter) GetTemporaryDatacenterData(
+	ctx context.Context,
+	id uuid.UUID,
+) (*UCEntity.DatacenterDetailedData, error) {
+	data, err := d.datacenterRepo.GetTemporaryDatacenterByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.DatacenterDetailedData{
+		ID:          data.ID.GetUUID(),
+		Name:        data.Name,
+		Credentials: data.Credentials.GetRawMessage(),
+		Metadata:    data.Metadata.GetRawMessage(),
+		Datacenter:  data.Datacenter,
+	}, nil
+}
+
+func (d *azureDatacenter) GetDatacenterData(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*UCEntity.DatacenterDetailedData, error) {
+	data, err := d.datacenterRepo.GetDatacenterByID(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.DatacenterDetailedData{
+		ID:          data.ID.GetUUID(),
+		Name:        data.Name,
+		Credentials: data.Credentials.GetRawMessage(),
+		Metadata:    data.Metadata.GetRawMessage(),
+		Datacenter:  data.Datacenter,
+	}, nil
+}
+
+func (d *azureDatacenter) SaveDatacenterDetailedData(
+	tx *gorm.DB,
+	data *UCEntity.DatacenterDetailedData,
+) (uuid.UUID, error) {
+	datacenterData := &model.Datacenter{
+		Name:       data.Name,
+		Datacenter: data.Datacenter,
+	}
+	datacenterData.ID.SetUUID(data.ID)
+	datacenterData.Credentials.SetRawMessage(data.Credentials)
+	datacenterData.Metadata.SetRawMessage(data.Metadata)
+	err := d.datacenterRepo.InsertDatacenter(tx, datacenterData)
+	return datacenterData.ID.GetUUID(), err
+}
+
+func (d *azureDatacenter) SaveDatacenter(
+	tx *gorm.DB,
+	data UCEntity.DatacenterData,
+	SPCredentials *UCEntity.AzureServicePrincipalCredentials,
+) (uuid.UUID, error) {
+	metaData := &UCEntity.AzureDatacenterMetaData{
+		TenantID:       *SPCredentials.TenantID,
+		ClientID:       *SPCredentials.ClientID,
+		SubscriptionID: *SPCredentials.SubscriptionID,
+	}
+	metaDataByte, err := json.Marshal(metaData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	datacenterModel := model.Datacenter{
+		Name:       data.Name,
+		Datacenter: model.Azure,
+	}
+	datacenterModel.Credentials.SetRawMessage(data.Credentials)
+	datacenterModel.Metadata.SetRawMessage(metaDataByte)
+	err = d.datacenterRepo.InsertDatacenter(tx, &datacenterModel)
+	return uuid.UUID(datacenterModel.ID), err
+}
+
+func (d *azureDatacenter) GetAzureCredential(
+	data UCEntity.DatacenterData,
+) (azcore.TokenCredential, error) {
+	SPCredentials, err := d.ParseServicePrincipal(data)
+	if err != nil {
+		return nil, err
+	}
+	return azidentity.NewClientSecretCredential(
+		*SPCredentials.TenantID,
+		*SPCredentials.ClientID,
+		*SPCredentials.ClientSecret,
+		nil,
+	)
+}
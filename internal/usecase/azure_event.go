@@ -0,0 +1,117 @@
+package useCase
+
+import (
+	"context"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+)
+
+type AzureEvent interface {
+	CalculateAzureEventPlan(
+		ctx context.Context,
+		tx *gorm.DB,
+		kubernetesClient kubernetes.Interface,
+		agentPoolClient *armcontainerservice.AgentPoolsClient,
+		clusterData *UCEntity.ClusterData,
+		event *UCEntity.Event,
+	) (*UCEntity.AzureEventPlan, error)
+}
+
+type azureEvent struct {
+	eventPlanner
+	azureClusterUC AzureCluster
+}
+
+func newAzureEvent(
+	clusterUC Cluster,
+	azureClusterUC AzureCluster,
+	scheduledHPAConfigUC ScheduledHPAConfig,
+	scheduledWorkloadConfigUC ScheduledWorkloadConfig,
+) AzureEvent {
+	return &azureEvent{
+		eventPlanner: eventPlanner{
+			clusterUC:                 clusterUC,
+			scheduledHPAConfigUC:      scheduledHPAConfigUC,
+			scheduledWorkloadConfigUC: scheduledWorkloadConfigUC,
+		},
+		azureClusterUC: azureClusterUC,
+	}
+}
+
+func (a *azureEvent) CalculateAzureEventPlan(
+	ctx context.Context,
+	tx *gorm.DB,
+	kubernetesClient kubernetes.Interface,
+	agentPoolClient *armcontainerservice.AgentPoolsClient,
+	clusterData *UCEntity.ClusterData,
+	e *UCEntity.Event,
+) (*UCEntity.AzureEventPlan, error) {
+	eventPlan, unselectedK8sHPAs, err := a.selectEventHPAs(ctx, tx, kubernetesClient, clusterData, e)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UCEntity.AzureEventPlan{EventPlan: *eventPlan}
+	if len(plan.SelectedHPAs) == 0 && len(plan.SelectedWorkloads) == 0 {
+		return plan, nil
+	}
+
+	clusterMetadata, err := a.azureClusterUC.GetAzureClusterMetaData(clusterData)
+	if err != nil {
+		return nil, err
+	}
+	plan.ResourceGroup = clusterMetadata.ResourceGroup
+	plan.ClusterName = clusterMetadata.ClusterName
+
+	// Get AKS Agent Pools
+	agentPools, err := a.azureClusterUC.GetAllAgentPoolsInAKSCluster(
+		ctx,
+		agentPoolClient,
+		plan.ResourceGroup,
+		plan.ClusterName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodePools []*nodePoolSource
+	for _, agentPoolData := range agentPools {
+		agentPool := agentPoolData.AgentPoolObject
+		agentPoolName := *agentPool.Name
+		nodePoolPlan := &UCEntity.AzureNodePoolPlan{
+			NodePoolPlan: UCEntity.NodePoolPlan{
+				NodePoolName: agentPoolName,
+			},
+			AgentPoolObject: agentPool,
+		}
+		var maxPodsPerNode int64
+		if properties := agentPool.Properties; properties != nil {
+			if properties.MaxCount != nil {
+				nodePoolPlan.CurrentMaxNode = *properties.MaxCount
+			}
+			if properties.MaxPods != nil {
+				maxPodsPerNode = int64(*properties.MaxPods)
+			}
+		}
+		plan.NodePools = append(plan.NodePools, nodePoolPlan)
+
+		nodePools = append(
+			nodePools, &nodePoolSource{
+				plan:           &nodePoolPlan.NodePoolPlan,
+				maxPodsPerNode: maxPodsPerNode,
+				getNodes: func(ctx context.Context) (*UCEntity.K8sNodeListData, error) {
+					return a.azureClusterUC.GetNodesFromAKSAgentPool(ctx, kubernetesClient, agentPoolName)
+				},
+			},
+		)
+	}
+
+	err = a.calculateNodePools(ctx, kubernetesClient, e, &plan.EventPlan, unselectedK8sHPAs, nodePools)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
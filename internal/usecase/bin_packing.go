@@ -0,0 +1,224 @@
+package useCase
+
+import (
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"math"
+	"sort"
+)
+
+// binPackingEpsilon absorbs float rounding of summed resource quantities
+const binPackingEpsilon = 1e-9
+
+type binPackingResult struct {
+	nodeCount         int32
+	packingEfficiency float64
+	unschedulablePods int64
+}
+
+type zoneBinPackingResult struct {
+	binPackingResult
+	zoneNodeCounts   map[string]int32
+	maxZoneNodeCount int32
+}
+
+type simulatedNode struct {
+	cpu    float64
+	memory float64
+	pods   int64
+}
+
+func newSimulatedNode(nodeShape UCEntity.NodePoolResourceData) simulatedNode {
+	return simulatedNode{
+		cpu:    nodeShape.AvailableCPU,
+		memory: nodeShape.AvailableMemory,
+		pods:   nodeShape.AvailablePods,
+	}
+}
+
+func (n *simulatedNode) fits(group UCEntity.PodResourceGroup) bool {
+	return n.pods >= 1 &&
+		n.cpu+binPackingEpsilon >= group.CPU &&
+		n.memory+binPackingEpsilon >= group.Memory
+}
+
+func (n *simulatedNode) place(group UCEntity.PodResourceGroup) {
+	n.cpu -= group.CPU
+	n.memory -= group.Memory
+	n.pods -= 1
+}
+
+func resourceShare(requested, capacity float64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return requested / capacity
+}
+
+// simulateFirstFitDecreasing packs the pods onto nodes shaped like the node pool available resources.
+// Pods are sorted by their dominant resource share and placed on the first node that fits them,
+// pods not fitting an empty node are reported as unschedulable.
+// The packing efficiency is the utilization of the most used resource across the packed nodes.
+func simulateFirstFitDecreasing(
+	nodeShape UCEntity.NodePoolResourceData,
+	podGroups []UCEntity.PodResourceGroup,
+) binPackingResult {
+	emptyNode := newSimulatedNode(nodeShape)
+
+	result := binPackingResult{}
+	var schedulableGroups []UCEntity.PodResourceGroup
+	for _, group := range podGroups {
+		if group.Count <= 0 {
+			continue
+		}
+		if !emptyNode.fits(group) {
+			result.unschedulablePods += group.Count
+			continue
+		}
+		schedulableGroups = append(schedulableGroups, group)
+	}
+
+	dominantShare := func(group UCEntity.PodResourceGroup) float64 {
+		return math.Max(
+			resourceShare(group.CPU, nodeShape.AvailableCPU),
+			resourceShare(group.Memory, nodeShape.AvailableMemory),
+		)
+	}
+	sort.SliceStable(
+		schedulableGroups, func(i, j int) bool {
+			return dominantShare(schedulableGroups[i]) > dominantShare(schedulableGroups[j])
+		},
+	)
+
+	var nodes []*simulatedNode
+	usedCPU := float64(0)
+	usedMemory := float64(0)
+	usedPods := int64(0)
+	for _, group := range schedulableGroups {
+		// Nodes before the last placement could not fit the same pod shape, skip them
+		firstCandidate := 0
+		for i := int64(0); i < group.Count; i++ {
+			placed := false
+			for idx := firstCandidate; idx < len(nodes); idx++ {
+				if nodes[idx].fits(group) {
+					nodes[idx].place(group)
+					firstCandidate = idx
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				node := emptyNode
+				node.place(group)
+				nodes = append(nodes, &node)
+				firstCandidate = len(nodes) - 1
+			}
+		}
+		usedCPU += group.CPU * float64(group.Count)
+		usedMemory += group.Memory * float64(group.Count)
+		usedPods += group.Count
+	}
+
+	result.nodeCount = int32(len(nodes))
+	if len(nodes) > 0 {
+		totalNodes := float64(len(nodes))
+		result.packingEfficiency = math.Max(
+			resourceShare(usedCPU, nodeShape.AvailableCPU*totalNodes),
+			math.Max(
+				resourceShare(usedMemory, nodeShape.AvailableMemory*totalNodes),
+				resourceShare(float64(usedPods), float64(nodeShape.AvailablePods)*totalNodes),
+			),
+		)
+	}
+
+	return result
+}
+
+// getNeededNode returns the nodes to add on top of the node pool max node,
+// the max node is never lowered when the packed pods need less nodes
+func getNeededNode(currentMaxNode, requiredMaxNode int32) int32 {
+	if requiredMaxNode > currentMaxNode {
+		return requiredMaxNode - currentMaxNode
+	}
+	return 0
+}
+
+func hasZoneSpreadPodGroup(podGroups []UCEntity.PodResourceGroup) bool {
+	for _, group := range podGroups {
+		if group.ZoneSpread && group.Count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPodGroupsByZone splits the pods between the node pool zones. Pods spread across zones take their balanced share
+// in every zone, other pods are split evenly with the remainders rotating between zones.
+func splitPodGroupsByZone(
+	podGroups []UCEntity.PodResourceGroup,
+	zones []string,
+) map[string][]UCEntity.PodResourceGroup {
+	zonePodGroups := map[string][]UCEntity.PodResourceGroup{}
+	zoneCount := int64(len(zones))
+	if zoneCount == 0 {
+		return zonePodGroups
+	}
+	offset := int64(0)
+	for _, group := range podGroups {
+		if group.Count <= 0 {
+			continue
+		}
+		remainder := group.Count % zoneCount
+		for idx, zone := range zones {
+			zoneGroup := group
+			if group.ZoneSpread {
+				zoneGroup.Count = (group.Count + zoneCount - 1) / zoneCount
+			} else {
+				zoneGroup.Count = group.Count / zoneCount
+				if (int64(idx)-offset+zoneCount)%zoneCount < remainder {
+					zoneGroup.Count += 1
+				}
+			}
+			if zoneGroup.Count > 0 {
+				zonePodGroups[zone] = append(zonePodGroups[zone], zoneGroup)
+			}
+		}
+		if !group.ZoneSpread {
+			offset = (offset + remainder) % zoneCount
+		}
+	}
+	return zonePodGroups
+}
+
+// simulateZoneFirstFitDecreasing packs the pods of every zone of the node pool separately,
+// the packing efficiency is the average of the zones weighted by their node count
+func simulateZoneFirstFitDecreasing(
+	nodeShape UCEntity.NodePoolResourceData,
+	podGroups []UCEntity.PodResourceGroup,
+	zones []string,
+) zoneBinPackingResult {
+	result := zoneBinPackingResult{zoneNodeCounts: map[string]int32{}}
+
+	emptyNode := newSimulatedNode(nodeShape)
+	for _, group := range podGroups {
+		if group.Count > 0 && !emptyNode.fits(group) {
+			result.unschedulablePods += group.Count
+		}
+	}
+
+	zonePodGroups := splitPodGroupsByZone(podGroups, zones)
+	weightedEfficiency := float64(0)
+	for _, zone := range zones {
+		zoneRes := simulateFirstFitDecreasing(nodeShape, zonePodGroups[zone])
+		result.zoneNodeCounts[zone] = zoneRes.nodeCount
+		result.nodeCount += zoneRes.nodeCount
+		weightedEfficiency += zoneRes.packingEfficiency * float64(zoneRes.nodeCount)
+		if zoneRes.nodeCount > result.maxZoneNodeCount {
+			result.maxZoneNodeCount = zoneRes.nodeCount
+		}
+	}
+	if result.nodeCount > 0 {
+		result.packingEfficiency = weightedEfficiency / float64(result.nodeCount)
+	}
+
+	return result
+}
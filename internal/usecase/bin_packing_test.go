@@ -0,0 +1,271 @@
+package useCase
+
+import (
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSimulateFirstFitDecreasing(t *testing.T) {
+	nodeShape := UCEntity.NodePoolResourceData{
+		AvailableCPU:    4,
+		AvailableMemory: 16,
+		AvailablePods:   110,
+	}
+	testCases := []struct {
+		name              string
+		nodeShape         UCEntity.NodePoolResourceData
+		podGroups         []UCEntity.PodResourceGroup
+		nodeCount         int32
+		packingEfficiency float64
+		unschedulablePods int64
+	}{
+		{
+			name:      "no pods",
+			nodeShape: nodeShape,
+		},
+		{
+			name:      "empty pod groups are ignored",
+			nodeShape: nodeShape,
+			podGroups: []UCEntity.PodResourceGroup{{CPU: 1, Memory: 1, Count: 0}},
+		},
+		{
+			name:              "pods larger than a node are unschedulable",
+			nodeShape:         nodeShape,
+			podGroups:         []UCEntity.PodResourceGroup{{CPU: 5, Memory: 1, Count: 3}},
+			unschedulablePods: 3,
+		},
+		{
+			name:      "pods larger than a node don't take nodes",
+			nodeShape: nodeShape,
+			podGroups: []UCEntity.PodResourceGroup{
+				{CPU: 1, Memory: 20, Count: 2},
+				{CPU: 2, Memory: 8, Count: 2},
+			},
+			nodeCount:         1,
+			packingEfficiency: 1,
+			unschedulablePods: 2,
+		},
+		{
+			name:      "pods on a node without free pods are unschedulable",
+			nodeShape: UCEntity.NodePoolResourceData{AvailableCPU: 4, AvailableMemory: 16},
+			podGroups: []UCEntity.PodResourceGroup{
+				{CPU: 1, Memory: 1, Count: 2},
+			},
+			unschedulablePods: 2,
+		},
+		{
+			name:              "zero request pods are bounded by the pods per node",
+			nodeShape:         UCEntity.NodePoolResourceData{AvailableCPU: 4, AvailableMemory: 16, AvailablePods: 2},
+			podGroups:         []UCEntity.PodResourceGroup{{Count: 5}},
+			nodeCount:         3,
+			packingEfficiency: 5.0 / 6.0,
+		},
+		{
+			name:              "zero request pods share a single node",
+			nodeShape:         nodeShape,
+			podGroups:         []UCEntity.PodResourceGroup{{Count: 5}},
+			nodeCount:         1,
+			packingEfficiency: 5.0 / 110.0,
+		},
+		{
+			name:              "fragmented pods take a node each",
+			nodeShape:         nodeShape,
+			podGroups:         []UCEntity.PodResourceGroup{{CPU: 2.5, Memory: 1, Count: 4}},
+			nodeCount:         4,
+			packingEfficiency: 10.0 / 16.0,
+		},
+		{
+			name:      "small pods fill the headroom of existing nodes",
+			nodeShape: nodeShape,
+			podGroups: []UCEntity.PodResourceGroup{
+				{CPU: 3.5, Memory: 4, Count: 2},
+				{CPU: 0.5, Memory: 4, Count: 2},
+			},
+			nodeCount:         2,
+			packingEfficiency: 1,
+		},
+		{
+			name:      "larger pods are placed first",
+			nodeShape: nodeShape,
+			podGroups: []UCEntity.PodResourceGroup{
+				{CPU: 1, Memory: 1, Count: 4},
+				{CPU: 3, Memory: 1, Count: 4},
+			},
+			nodeCount:         4,
+			packingEfficiency: 1,
+		},
+		{
+			name:              "memory bound pods",
+			nodeShape:         nodeShape,
+			podGroups:         []UCEntity.PodResourceGroup{{CPU: 0.1, Memory: 6, Count: 4}},
+			nodeCount:         2,
+			packingEfficiency: 24.0 / 32.0,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				res := simulateFirstFitDecreasing(testCase.nodeShape, testCase.podGroups)
+				if res.nodeCount != testCase.nodeCount {
+					t.Fatalf("expected %d nodes, got %d", testCase.nodeCount, res.nodeCount)
+				}
+				if math.Abs(res.packingEfficiency-testCase.packingEfficiency) > binPackingEpsilon {
+					t.Fatalf(
+						"expected %f packing efficiency, got %f",
+						testCase.packingEfficiency,
+						res.packingEfficiency,
+					)
+				}
+				if res.unschedulablePods != testCase.unschedulablePods {
+					t.Fatalf(
+						"expected %d unschedulable pods, got %d",
+						testCase.unschedulablePods,
+						res.unschedulablePods,
+					)
+				}
+			},
+		)
+	}
+}
+
+func TestSimulateZoneFirstFitDecreasing(t *testing.T) {
+	nodeShape := UCEntity.NodePoolResourceData{
+		AvailableCPU:    4,
+		AvailableMemory: 16,
+		AvailablePods:   110,
+	}
+	zones := []string{"zone-a", "zone-b"}
+	podGroups := []UCEntity.PodResourceGroup{
+		{CPU: 2.5, Memory: 1, Count: 3},
+		{CPU: 5, Memory: 1, Count: 4},
+	}
+
+	res := simulateZoneFirstFitDecreasing(nodeShape, podGroups, zones)
+	if res.nodeCount != 3 {
+		t.Fatalf("expected 3 nodes, got %d", res.nodeCount)
+	}
+	if res.zoneNodeCounts["zone-a"] != 2 || res.zoneNodeCounts["zone-b"] != 1 {
+		t.Fatalf("expected 2 nodes on zone-a and 1 node on zone-b, got %v", res.zoneNodeCounts)
+	}
+	if res.maxZoneNodeCount != 2 {
+		t.Fatalf("expected 2 max zone nodes, got %d", res.maxZoneNodeCount)
+	}
+	if res.unschedulablePods != 4 {
+		t.Fatalf("expected 4 unschedulable pods, got %d", res.unschedulablePods)
+	}
+}
+
+func TestGetNeededNode(t *testing.T) {
+	testCases := []struct {
+		name            string
+		currentMaxNode  int32
+		requiredMaxNode int32
+		neededNode      int32
+	}{
+		{name: "more nodes required", currentMaxNode: 3, requiredMaxNode: 5, neededNode: 2},
+		{name: "same nodes required", currentMaxNode: 3, requiredMaxNode: 3, neededNode: 0},
+		{name: "never below the current max node", currentMaxNode: 10, requiredMaxNode: 2, neededNode: 0},
+		{name: "no pods", currentMaxNode: 3, requiredMaxNode: 0, neededNode: 0},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				neededNode := getNeededNode(testCase.currentMaxNode, testCase.requiredMaxNode)
+				if neededNode != testCase.neededNode {
+					t.Fatalf("expected %d needed nodes, got %d", testCase.neededNode, neededNode)
+				}
+			},
+		)
+	}
+}
+
+func TestSplitPodGroupsByZone(t *testing.T) {
+	zones := []string{"zone-a", "zone-b", "zone-c"}
+	testCases := []struct {
+		name      string
+		podGroups []UCEntity.PodResourceGroup
+		zones     []string
+		expected  map[string][]UCEntity.PodResourceGroup
+	}{
+		{
+			name:      "no zones",
+			podGroups: []UCEntity.PodResourceGroup{{CPU: 1, Memory: 1, Count: 3}},
+			expected:  map[string][]UCEntity.PodResourceGroup{},
+		},
+		{
+			name:      "spread pods take the ceiled share in every zone",
+			podGroups: []UCEntity.PodResourceGroup{{CPU: 1, Memory: 1, Count: 5, ZoneSpread: true}},
+			zones:     zones,
+			expected: map[string][]UCEntity.PodResourceGroup{
+				"zone-a": {{CPU: 1, Memory: 1, Count: 2, ZoneSpread: true}},
+				"zone-b": {{CPU: 1, Memory: 1, Count: 2, ZoneSpread: true}},
+				"zone-c": {{CPU: 1, Memory: 1, Count: 2, ZoneSpread: true}},
+			},
+		},
+		{
+			name:      "spread pods evenly divided between zones",
+			podGroups: []UCEntity.PodResourceGroup{{CPU: 1, Memory: 1, Count: 6, ZoneSpread: true}},
+			zones:     zones,
+			expected: map[string][]UCEntity.PodResourceGroup{
+				"zone-a": {{CPU: 1, Memory: 1, Count: 2, ZoneSpread: true}},
+				"zone-b": {{CPU: 1, Memory: 1, Count: 2, ZoneSpread: true}},
+				"zone-c": {{CPU: 1, Memory: 1, Count: 2, ZoneSpread: true}},
+			},
+		},
+		{
+			name:      "spread pods fewer than the zones",
+			podGroups: []UCEntity.PodResourceGroup{{CPU: 1, Memory: 1, Count: 1, ZoneSpread: true}},
+			zones:     zones,
+			expected: map[string][]UCEntity.PodResourceGroup{
+				"zone-a": {{CPU: 1, Memory: 1, Count: 1, ZoneSpread: true}},
+				"zone-b": {{CPU: 1, Memory: 1, Count: 1, ZoneSpread: true}},
+				"zone-c": {{CPU: 1, Memory: 1, Count: 1, ZoneSpread: true}},
+			},
+		},
+		{
+			name: "remainders rotate between zones",
+			podGroups: []UCEntity.PodResourceGroup{
+				{CPU: 1, Memory: 1, Count: 5},
+				{CPU: 2, Memory: 2, Count: 4},
+			},
+			zones: zones,
+			expected: map[string][]UCEntity.PodResourceGroup{
+				"zone-a": {{CPU: 1, Memory: 1, Count: 2}, {CPU: 2, Memory: 2, Count: 1}},
+				"zone-b": {{CPU: 1, Memory: 1, Count: 2}, {CPU: 2, Memory: 2, Count: 1}},
+				"zone-c": {{CPU: 1, Memory: 1, Count: 1}, {CPU: 2, Memory: 2, Count: 2}},
+			},
+		},
+		{
+			name: "spread pods don't move the remainder rotation",
+			podGroups: []UCEntity.PodResourceGroup{
+				{CPU: 1, Memory: 1, Count: 1},
+				{CPU: 2, Memory: 2, Count: 2, ZoneSpread: true},
+				{CPU: 3, Memory: 3, Count: 1},
+			},
+			zones: zones,
+			expected: map[string][]UCEntity.PodResourceGroup{
+				"zone-a": {{CPU: 1, Memory: 1, Count: 1}, {CPU: 2, Memory: 2, Count: 1, ZoneSpread: true}},
+				"zone-b": {{CPU: 2, Memory: 2, Count: 1, ZoneSpread: true}, {CPU: 3, Memory: 3, Count: 1}},
+				"zone-c": {{CPU: 2, Memory: 2, Count: 1, ZoneSpread: true}},
+			},
+		},
+		{
+			name:      "empty pod groups are skipped",
+			podGroups: []UCEntity.PodResourceGroup{{CPU: 1, Memory: 1, Count: 0, ZoneSpread: true}},
+			zones:     zones,
+			expected:  map[string][]UCEntity.PodResourceGroup{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				res := splitPodGroupsByZone(testCase.podGroups, testCase.zones)
+				if !reflect.DeepEqual(res, testCase.expected) {
+					t.Fatalf("expected %v, got %v", testCase.expected, res)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,341 @@
+package useCase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	v1Core "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"strconv"
+)
+
+// CapacityBackend resizes node pools of clusters that have no cloud node pool API
+type CapacityBackend interface {
+	GetNodePoolLabel() string
+	GetNodePoolCapacity(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		nodePoolName string,
+		nodeCount int32,
+	) (*UCEntity.NodePoolCapacityData, error)
+	UpdateNodePoolMaxNode(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		nodePoolName string,
+		currentMaxNode, maxNode int32,
+	) error
+	RestoreNodePool(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		updatedNodePool *UCEntity.UpdatedNodePoolData,
+	) error
+}
+
+func newCapacityBackends(
+	k8sCapacityRepo repository.K8sCapacity,
+	k8sNodeRepo repository.K8sNode,
+) map[constant.CapacityBackend]CapacityBackend {
+	return map[constant.CapacityBackend]CapacityBackend{
+		constant.CapacityBackendClusterAutoscaler: &clusterAutoscalerBackend{
+			k8sCapacityRepo: k8sCapacityRepo,
+		},
+		constant.CapacityBackendKarpenter: &karpenterBackend{
+			k8sCapacityRepo: k8sCapacityRepo,
+			k8sNodeRepo:     k8sNodeRepo,
+		},
+	}
+}
+
+func buildAnnotationsPatch(annotations map[string]interface{}) ([]byte, error) {
+	return json.Marshal(
+		map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": annotations,
+			},
+		},
+	)
+}
+
+type clusterAutoscalerBackend struct {
+	k8sCapacityRepo repository.K8sCapacity
+}
+
+func (b *clusterAutoscalerBackend) GetNodePoolLabel() string {
+	return constant.ClusterAPIDeploymentLabel
+}
+
+func (b *clusterAutoscalerBackend) getMachineDeployment(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	name string,
+) (*unstructured.Unstructured, error) {
+	machineDeployments, err := b.k8sCapacityRepo.ListMachineDeployments(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	for _, machineDeployment := range machineDeployments.Items {
+		if machineDeployment.GetName() == name {
+			return &machineDeployment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (b *clusterAutoscalerBackend) GetNodePoolCapacity(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodePoolName string,
+	nodeCount int32,
+) (*UCEntity.NodePoolCapacityData, error) {
+	capacity := &UCEntity.NodePoolCapacityData{MaxNode: nodeCount}
+	machineDeployment, err := b.getMachineDeployment(ctx, k8sClient, nodePoolName)
+	if err != nil {
+		return nil, err
+	}
+	if machineDeployment == nil {
+		return capacity, nil
+	}
+
+	annotations := machineDeployment.GetAnnotations()
+	minSize, minOk := annotations[constant.ClusterAutoscalerMinSizeAnnotation]
+	maxSize, maxOk := annotations[constant.ClusterAutoscalerMaxSizeAnnotation]
+	if !minOk || !maxOk {
+		return capacity, nil
+	}
+	minNode, err := strconv.ParseInt(minSize, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	maxNode, err := strconv.ParseInt(maxSize, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.NodePoolCapacityData{
+		MinNode:            int32(minNode),
+		MaxNode:            int32(maxNode),
+		AutoscalingEnabled: true,
+	}, nil
+}
+
+func (b *clusterAutoscalerBackend) patchSize(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodePoolName string,
+	annotations map[string]interface{},
+) error {
+	machineDeployment, err := b.getMachineDeployment(ctx, k8sClient, nodePoolName)
+	if err != nil {
+		return err
+	}
+	if machineDeployment == nil {
+		return fmt.Errorf(errorConstant.MachineDeploymentNotFound, nodePoolName)
+	}
+	if _, ok := machineDeployment.GetAnnotations()[constant.ClusterAutoscalerMaxSizeAnnotation]; !ok {
+		return fmt.Errorf(errorConstant.NodePoolSizeAnnotationsMissing, nodePoolName)
+	}
+	patch, err := buildAnnotationsPatch(annotations)
+	if err != nil {
+		return err
+	}
+	return b.k8sCapacityRepo.PatchMachineDeployment(
+		ctx,
+		k8sClient,
+		machineDeployment.GetNamespace(),
+		machineDeployment.GetName(),
+		patch,
+	)
+}
+
+func (b *clusterAutoscalerBackend) UpdateNodePoolMaxNode(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodePoolName string,
+	currentMaxNode, maxNode int32,
+) error {
+	return b.patchSize(
+		ctx, k8sClient, nodePoolName, map[string]interface{}{
+			constant.ClusterAutoscalerMaxSizeAnnotation: strconv.Itoa(int(maxNode)),
+		},
+	)
+}
+
+func (b *clusterAutoscalerBackend) RestoreNodePool(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	updatedNodePool *UCEntity.UpdatedNodePoolData,
+) error {
+	return b.patchSize(
+		ctx, k8sClient, updatedNodePool.NodePoolName, map[string]interface{}{
+			constant.ClusterAutoscalerMinSizeAnnotation: strconv.Itoa(int(updatedNodePool.OriginalMinNode)),
+			constant.ClusterAutoscalerMaxSizeAnnotation: strconv.Itoa(int(updatedNodePool.OriginalMaxNode)),
+		},
+	)
+}
+
+type karpenterBackend struct {
+	k8sCapacityRepo repository.K8sCapacity
+	k8sNodeRepo     repository.K8sNode
+}
+
+func (b *karpenterBackend) GetNodePoolLabel() string {
+	return constant.KarpenterNodePoolLabel
+}
+
+func (b *karpenterBackend) GetNodePoolCapacity(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodePoolName string,
+	nodeCount int32,
+) (*UCEntity.NodePoolCapacityData, error) {
+	// Karpenter has no node count bound, the current size stands in for the maximum
+	capacity := &UCEntity.NodePoolCapacityData{MaxNode: nodeCount}
+	_, err := b.k8sCapacityRepo.GetKarpenterNodePool(ctx, k8sClient, nodePoolName)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return capacity, nil
+		}
+		return nil, err
+	}
+	capacity.AutoscalingEnabled = true
+	return capacity, nil
+}
+
+func (b *karpenterBackend) getLimits(
+	nodePool *unstructured.Unstructured,
+) (map[string]interface{}, error) {
+	// Keep computing from the original limits when a previous attempt already raised them
+	if originalLimits, ok := nodePool.GetAnnotations()[constant.OriginalLimitsAnnotation]; ok {
+		limits := map[string]interface{}{}
+		err := json.Unmarshal([]byte(originalLimits), &limits)
+		return limits, err
+	}
+	limits, _, err := unstructured.NestedMap(nodePool.Object, "spec", "limits")
+	return limits, err
+}
+
+func (b *karpenterBackend) UpdateNodePoolMaxNode(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodePoolName string,
+	currentMaxNode, maxNode int32,
+) error {
+	extraNode := int64(maxNode - currentMaxNode)
+	if extraNode <= 0 {
+		return nil
+	}
+	nodePool, err := b.k8sCapacityRepo.GetKarpenterNodePool(ctx, k8sClient, nodePoolName)
+	if err != nil {
+		return err
+	}
+	limits, err := b.getLimits(nodePool)
+	if err != nil {
+		return err
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+
+	nodes, err := b.k8sNodeRepo.GetNodeList(
+		ctx, k8sClient, v1Option.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", constant.KarpenterNodePoolLabel, nodePoolName),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(nodes.Items) == 0 {
+		return errors.New(errorConstant.NoExistingNode)
+	}
+	allocatable := nodes.Items[0].Status.Allocatable
+
+	newLimits := map[string]interface{}{}
+	for name, value := range limits {
+		quantity, err := resource.ParseQuantity(fmt.Sprint(value))
+		if err != nil {
+			return err
+		}
+		if name == constant.KarpenterNodesLimit {
+			quantity.Add(*resource.NewQuantity(extraNode, resource.DecimalSI))
+		} else if nodeQuantity, ok := allocatable[v1Core.ResourceName(name)]; ok {
+			quantity.Add(
+				*resource.NewMilliQuantity(nodeQuantity.MilliValue()*extraNode, nodeQuantity.Format),
+			)
+		}
+		newLimits[name] = quantity.String()
+	}
+
+	originalLimits, err := json.Marshal(limits)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(
+		map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					constant.OriginalLimitsAnnotation: string(originalLimits),
+				},
+			},
+			"spec": map[string]interface{}{
+				"limits": newLimits,
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return b.k8sCapacityRepo.PatchKarpenterNodePool(ctx, k8sClient, nodePoolName, patch)
+}
+
+func (b *karpenterBackend) RestoreNodePool(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	updatedNodePool *UCEntity.UpdatedNodePoolData,
+) error {
+	nodePoolName := updatedNodePool.NodePoolName
+	nodePool, err := b.k8sCapacityRepo.GetKarpenterNodePool(ctx, k8sClient, nodePoolName)
+	if err != nil {
+		return err
+	}
+	if _, ok := nodePool.GetAnnotations()[constant.OriginalLimitsAnnotation]; !ok {
+		return nil
+	}
+	originalLimits, err := b.getLimits(nodePool)
+	if err != nil {
+		return err
+	}
+	currentLimits, _, err := unstructured.NestedMap(nodePool.Object, "spec", "limits")
+	if err != nil {
+		return err
+	}
+	for name := range currentLimits {
+		if _, ok := originalLimits[name]; !ok {
+			originalLimits[name] = nil
+		}
+	}
+
+	patch, err := json.Marshal(
+		map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					constant.OriginalLimitsAnnotation: nil,
+				},
+			},
+			"spec": map[string]interface{}{
+				"limits": originalLimits,
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return b.k8sCapacityRepo.PatchKarpenterNodePool(ctx, k8sClient, nodePoolName, patch)
+}
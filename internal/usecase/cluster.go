@@ -2,6 +2,7 @@ package useCase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -9,15 +10,21 @@ import (
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
 	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
 	v1Apps "k8s.io/api/apps/v1"
 	v1hpa "k8s.io/api/autoscaling/v1"
+	v2hpa "k8s.io/api/autoscaling/v2"
 	"k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/api/autoscaling/v2beta2"
 	v1Core "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"sync"
 )
@@ -41,6 +48,11 @@ type Cluster interface {
 		constant.HPAVersion,
 		error,
 	)
+	RefreshLatestHPAAPIVersion(
+		tx *gorm.DB,
+		k8sClient kubernetes.Interface,
+		clusterData *UCEntity.ClusterData,
+	) error
 	GetAllK8sHPAObjectInCluster(
 		ctx context.Context,
 		client kubernetes.Interface,
@@ -51,20 +63,52 @@ type Cluster interface {
 		ctx context.Context,
 		client kubernetes.Interface,
 		clusterID uuid.UUID,
-		hpaObjectList []interface{},
+		hpaPlans []*UCEntity.HPAPlan,
+		force bool,
+	) (map[string]error, error)
+	UpdateHPAK8sObject(
+		ctx context.Context,
+		client kubernetes.Interface,
+		clusterID uuid.UUID,
+		hpaObject interface{},
+		modifiedHPAConfig *UCEntity.EventModifiedHPAConfigData,
+		force bool,
+	) error
+	ReleaseHPAK8sObject(ctx context.Context, client kubernetes.Interface, hpaObject interface{}) error
+	GetHPABehaviorAndMetrics(hpaObject interface{}) (behavior, metrics json.RawMessage, err error)
+	OverrideHPABehaviorAndMetrics(
+		hpaObject interface{},
+		modifiedHPAConfig *UCEntity.EventModifiedHPAConfigData,
+	) error
+	RestoreHPABehaviorAndMetrics(
+		hpaObject interface{},
+		modifiedHPAConfig *UCEntity.EventModifiedHPAConfigData,
 	) error
 	ResolveScaleTargetRef(
 		ctx context.Context,
 		client kubernetes.Interface,
 		scaleTargetRef interface{},
 		namespace string,
-	) (res interface{}, err error)
+	) (*UCEntity.ScaleTargetData, error)
 	ResolveScaleTargetRefByDeploymentsMap(
+		ctx context.Context,
+		client kubernetes.Interface,
 		scaleTargetRef interface{},
 		namespace string,
 		deploymentsMap map[string]v1Apps.Deployment,
 		deleteKey ...bool,
-	) (res *v1Apps.Deployment, err error)
+	) (*UCEntity.ScaleTargetData, error)
+	GetWorkloadReplicas(
+		ctx context.Context,
+		client kubernetes.Interface,
+		kind, name, namespace string,
+	) (int32, error)
+	ScaleWorkload(
+		ctx context.Context,
+		client kubernetes.Interface,
+		kind, name, namespace string,
+		replicas int32,
+	) error
 	GetAllDeployments(
 		ctx context.Context,
 		client kubernetes.Interface,
@@ -75,16 +119,23 @@ type Cluster interface {
 		client kubernetes.Interface,
 		namespace string,
 	) (*UCEntity.K8sDaemonSetListData, error)
+	GetAllPods(
+		ctx context.Context,
+		client kubernetes.Interface,
+		namespace string,
+	) (*UCEntity.K8sPodListData, error)
 }
 
 type cluster struct {
-	validatorInst  *validator.Validate
-	clusterRepo    repository.Cluster
-	hpaRepo        repository.K8sHPA
-	namespaceRepo  repository.K8sNamespace
-	deploymentRepo repository.K8sDeployment
-	discoveryRepo  repository.K8SDiscovery
-	daemonSetRepo  repository.K8sDaemonSets
+	validatorInst    *validator.Validate
+	clusterRepo      repository.Cluster
+	hpaRepo          repository.K8sHPA
+	namespaceRepo    repository.K8sNamespace
+	deploymentRepo   repository.K8sDeployment
+	discoveryRepo    repository.K8SDiscovery
+	daemonSetRepo    repository.K8sDaemonSets
+	workloadRepo     repository.K8sWorkload
+	scaledObjectRepo repository.K8sScaledObject
 }
 
 func newCluster(
@@ -95,15 +146,19 @@ func newCluster(
 	discoveryRepo repository.K8SDiscovery,
 	deploymentRepo repository.K8sDeployment,
 	daemonSetRepo repository.K8sDaemonSets,
+	workloadRepo repository.K8sWorkload,
+	scaledObjectRepo repository.K8sScaledObject,
 ) Cluster {
 	return &cluster{
-		validatorInst:  validatorInst,
-		clusterRepo:    clusterRepo,
-		hpaRepo:        hpaRepo,
-		namespaceRepo:  namespaceRepo,
-		discoveryRepo:  discoveryRepo,
-		deploymentRepo: deploymentRepo,
-		daemonSetRepo:  daemonSetRepo,
+		validatorInst:    validatorInst,
+		clusterRepo:      clusterRepo,
+		hpaRepo:          hpaRepo,
+		namespaceRepo:    namespaceRepo,
+		discoveryRepo:    discoveryRepo,
+		deploymentRepo:   deploymentRepo,
+		daemonSetRepo:    daemonSetRepo,
+		scaledObjectRepo: scaledObjectRepo,
+		workloadRepo:     workloadRepo,
 	}
 }
 
@@ -124,11 +179,44 @@ func (c *cluster) GetLatestHPAAPIVersion(
 			break
 		}
 	}
+	// autoscaling/v2 replaces the beta versions, which are removed from recent clusters
+	for _, version := range autoscalingAPIGroup.Versions {
+		if version.GroupVersion == constant.AutoscalingV2 {
+			return constant.AutoscalingV2, nil
+		}
+	}
 	versionCount := len(autoscalingAPIGroup.Versions)
+	if versionCount == 0 {
+		return "", errors.New(errorConstant.HPAVersionUnknown)
+	}
 	latestVersion := autoscalingAPIGroup.Versions[versionCount-1]
 	return latestVersion.GroupVersion, nil
 }
 
+// RefreshLatestHPAAPIVersion re-discovers the HPA API version, clusters upgraded after registration
+// may have removed the stored version
+func (c *cluster) RefreshLatestHPAAPIVersion(
+	tx *gorm.DB,
+	k8sClient kubernetes.Interface,
+	clusterData *UCEntity.ClusterData,
+) error {
+	latestHPAAPIVersion, err := c.GetLatestHPAAPIVersion(k8sClient)
+	if err != nil {
+		return err
+	}
+	if latestHPAAPIVersion == clusterData.LatestHPAAPIVersion {
+		return nil
+	}
+	clusterModel := &model.Cluster{LatestHPAAPIVersion: latestHPAAPIVersion}
+	clusterModel.ID.SetUUID(clusterData.ID)
+	err = c.clusterRepo.UpdateClusterLatestHPAAPIVersion(tx, clusterModel)
+	if err != nil {
+		return err
+	}
+	clusterData.LatestHPAAPIVersion = latestHPAAPIVersion
+	return nil
+}
+
 func (c *cluster) GetAllClustersInLocalByDatacenterID(
 	tx *gorm.DB,
 	datacenterID uuid.UUID,
@@ -191,6 +279,7 @@ func (c *cluster) GetClusterAndDatacenterDataByClusterID(
 		Name:           data.Name,
 		Certificate:    data.Certificate,
 		ServerEndpoint: data.ServerEndpoint,
+		Metadata:       data.Metadata.GetRawMessage(),
 		Datacenter: UCEntity.DatacenterDetailedData{
 			ID:          datacenterModelData.ID.GetUUID(),
 			Name:        datacenterModelData.Name,
@@ -231,6 +320,8 @@ func (c *cluster) GetAllHPAInCluster(
 					response, err = c.hpaRepo.GetAllV2beta1HPA(ctx, client, ns, clusterID)
 				case constant.AutoscalingV2Beta2:
 					response, err = c.hpaRepo.GetAllV2beta2HPA(ctx, client, ns, clusterID)
+				case constant.AutoscalingV2:
+					response, err = c.hpaRepo.GetAllV2HPA(ctx, client, ns, clusterID)
 				default:
 					return errors.New(errorConstant.HPAVersionUnknown)
 				}
@@ -250,13 +341,24 @@ func (c *cluster) GetAllHPAInCluster(
 		return nil, err
 	}
 
+	// HPAs owned by KEDA are reverted by its operator, their ScaledObjects are listed instead
+	kedaHPAReplicas := map[string]int32{}
 	for _, ns := range namespaces {
 		v := HPAs[ns.Name]
 		switch chosenHPAs := v.(type) {
 		case []v1hpa.HorizontalPodAutoscaler:
 			for _, hpa := range chosenHPAs {
+				if _, ok := hpa.Labels[constant.KedaScaledObjectLabel]; ok {
+					kedaHPAReplicas[fmt.Sprintf(
+						constant.NameNSKeyFormat,
+						hpa.Name,
+						ns.Name,
+					)] = hpa.Status.CurrentReplicas
+					continue
+				}
 				output = append(
 					output, UCEntity.SimpleHPAData{
+						Kind:            constant.HorizontalPodAutoscaler,
 						Name:            hpa.Name,
 						Namespace:       ns.Name,
 						MinReplicas:     hpa.Spec.MinReplicas,
@@ -271,8 +373,17 @@ func (c *cluster) GetAllHPAInCluster(
 			}
 		case []v2beta1.HorizontalPodAutoscaler:
 			for _, hpa := range chosenHPAs {
+				if _, ok := hpa.Labels[constant.KedaScaledObjectLabel]; ok {
+					kedaHPAReplicas[fmt.Sprintf(
+						constant.NameNSKeyFormat,
+						hpa.Name,
+						ns.Name,
+					)] = hpa.Status.CurrentReplicas
+					continue
+				}
 				output = append(
 					output, UCEntity.SimpleHPAData{
+						Kind:            constant.HorizontalPodAutoscaler,
 						Name:            hpa.Name,
 						Namespace:       ns.Name,
 						MinReplicas:     hpa.Spec.MinReplicas,
@@ -287,8 +398,17 @@ func (c *cluster) GetAllHPAInCluster(
 			}
 		case []v2beta2.HorizontalPodAutoscaler:
 			for _, hpa := range chosenHPAs {
+				if _, ok := hpa.Labels[constant.KedaScaledObjectLabel]; ok {
+					kedaHPAReplicas[fmt.Sprintf(
+						constant.NameNSKeyFormat,
+						hpa.Name,
+						ns.Name,
+					)] = hpa.Status.CurrentReplicas
+					continue
+				}
 				output = append(
 					output, UCEntity.SimpleHPAData{
+						Kind:            constant.HorizontalPodAutoscaler,
 						Name:            hpa.Name,
 						Namespace:       ns.Name,
 						MinReplicas:     hpa.Spec.MinReplicas,
@@ -301,7 +421,125 @@ func (c *cluster) GetAllHPAInCluster(
 					},
 				)
 			}
+		case []v2hpa.HorizontalPodAutoscaler:
+			for _, hpa := range chosenHPAs {
+				if _, ok := hpa.Labels[constant.KedaScaledObjectLabel]; ok {
+					kedaHPAReplicas[fmt.Sprintf(
+						constant.NameNSKeyFormat,
+						hpa.Name,
+						ns.Name,
+					)] = hpa.Status.CurrentReplicas
+					continue
+				}
+				output = append(
+					output, UCEntity.SimpleHPAData{
+						Kind:            constant.HorizontalPodAutoscaler,
+						Name:            hpa.Name,
+						Namespace:       ns.Name,
+						MinReplicas:     hpa.Spec.MinReplicas,
+						MaxReplicas:     hpa.Spec.MaxReplicas,
+						CurrentReplicas: hpa.Status.CurrentReplicas,
+						ScaleTargetRef: UCEntity.HPAScaleTargetRef{
+							Name: hpa.Spec.ScaleTargetRef.Name,
+							Kind: hpa.Spec.ScaleTargetRef.Kind,
+						},
+					},
+				)
+			}
+		}
+	}
+
+	scaledObjects, err := c.getAllScaledObjects(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, scaledObject := range scaledObjects {
+		output = append(
+			output, UCEntity.SimpleHPAData{
+				Kind:        constant.ScaledObject,
+				Name:        scaledObject.Name,
+				Namespace:   scaledObject.Namespace,
+				MinReplicas: scaledObject.MinReplicaCount,
+				MaxReplicas: scaledObject.MaxReplicaCount,
+				CurrentReplicas: kedaHPAReplicas[fmt.Sprintf(
+					constant.NameNSKeyFormat,
+					scaledObject.HPAName,
+					scaledObject.Namespace,
+				)],
+				ScaleTargetRef: UCEntity.HPAScaleTargetRef{
+					Name: scaledObject.ScaleTargetRef.Name,
+					Kind: scaledObject.ScaleTargetRef.Kind,
+				},
+			},
+		)
+	}
+	return output, nil
+}
+
+// getAllScaledObjects returns no ScaledObject when KEDA is not installed in the cluster
+func (c *cluster) getAllScaledObjects(
+	ctx context.Context,
+	client kubernetes.Interface,
+) ([]UCEntity.ScaledObject, error) {
+	data, err := c.scaledObjectRepo.GetAllScaledObject(ctx, client)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
 		}
+		return nil, err
+	}
+
+	var output []UCEntity.ScaledObject
+	for _, item := range data.Items {
+		scaledObject := UCEntity.ScaledObject{
+			Name:            item.GetName(),
+			Namespace:       item.GetNamespace(),
+			MaxReplicaCount: constant.KedaDefaultMaxReplicaCount,
+			ScaleTargetRef: v1hpa.CrossVersionObjectReference{
+				APIVersion: constant.AppsV1,
+				Kind:       constant.Deployment,
+			},
+			HPAName: fmt.Sprintf(constant.KedaHPANameFormat, item.GetName()),
+		}
+
+		minReplicaCount, found, err := unstructured.NestedInt64(item.Object, "spec", "minReplicaCount")
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			replicas := int32(minReplicaCount)
+			scaledObject.MinReplicaCount = &replicas
+		}
+
+		maxReplicaCount, found, err := unstructured.NestedInt64(item.Object, "spec", "maxReplicaCount")
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			scaledObject.MaxReplicaCount = int32(maxReplicaCount)
+		}
+
+		scaleTargetRef, _, err := unstructured.NestedStringMap(item.Object, "spec", "scaleTargetRef")
+		if err != nil {
+			return nil, err
+		}
+		scaledObject.ScaleTargetRef.Name = scaleTargetRef["name"]
+		if apiVersion := scaleTargetRef["apiVersion"]; apiVersion != "" {
+			scaledObject.ScaleTargetRef.APIVersion = apiVersion
+		}
+		if kind := scaleTargetRef["kind"]; kind != "" {
+			scaledObject.ScaleTargetRef.Kind = kind
+		}
+
+		hpaName, _, err := unstructured.NestedString(item.Object, "status", "hpaName")
+		if err != nil {
+			return nil, err
+		}
+		if hpaName != "" {
+			scaledObject.HPAName = hpaName
+		}
+
+		output = append(output, scaledObject)
 	}
 	return output, nil
 }
@@ -380,6 +618,25 @@ func (c *cluster) GetAllK8sHPAObjectInCluster(
 						)
 					}
 					lock.Unlock()
+				case constant.AutoscalingV2:
+					response, err := c.hpaRepo.GetAllV2HPA(ctxEg, client, ns, clusterID)
+					if err != nil {
+						if ctxEg.Err() != nil {
+							return nil
+						}
+						return err
+					}
+					lock.Lock()
+					for _, hO := range response {
+						output = append(
+							output,
+							UCEntity.K8sHPAObjectData{
+								Version:   constant.AutoscalingV2,
+								HPAObject: hO,
+							},
+						)
+					}
+					lock.Unlock()
 				default:
 					return errors.New(errorConstant.HPAVersionUnknown)
 				}
@@ -391,6 +648,20 @@ func (c *cluster) GetAllK8sHPAObjectInCluster(
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+
+	scaledObjects, err := c.getAllScaledObjects(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for i := range scaledObjects {
+		output = append(
+			output,
+			UCEntity.K8sHPAObjectData{
+				Version:   constant.KedaV1Alpha1,
+				HPAObject: scaledObjects[i],
+			},
+		)
+	}
 	return output, nil
 }
 
@@ -453,6 +724,20 @@ func (c *cluster) GetAllK8sHPAObjectInList(
 							}
 							return err
 						}
+					case constant.AutoscalingV2:
+						object, err = c.hpaRepo.GetV2HPA(
+							ctxEg,
+							client,
+							h.Name,
+							h.Namespace,
+							clusterID,
+						)
+						if err != nil {
+							if ctxEg.Err() != nil {
+								return nil
+							}
+							return err
+						}
 					}
 					lock.Lock()
 					hpaObjectList[i] = object
@@ -471,152 +756,662 @@ func (c *cluster) GetAllK8sHPAObjectInList(
 	return hpaObjectList, nil
 }
 
+// UpdateHPAK8sObjectBatch returns HPAs rejected by a field manager conflict keyed by name and
+// namespace, other errors fail the whole batch
 func (c *cluster) UpdateHPAK8sObjectBatch(
 	ctx context.Context,
 	client kubernetes.Interface,
 	clusterID uuid.UUID,
-	hpaObjectList []interface{},
-) error {
+	hpaPlans []*UCEntity.HPAPlan,
+	force bool,
+) (map[string]error, error) {
+	var lock sync.Mutex
+	conflicts := map[string]error{}
 	errGroup, ctxEg := errgroup.WithContext(ctx)
-	for _, hpaObject := range hpaObjectList {
+	for _, hpaPlan := range hpaPlans {
+		// Keep HPAs already updated by a previous attempt of a retried event
+		if hpaPlan.ModifiedHPAConfig.Status == model.HPAUpdateSuccess {
+			continue
+		}
 		errGroup.Go(
-			func(hO interface{}) func() error {
+			func(h *UCEntity.HPAPlan) func() error {
 				return func() error {
-					switch h := hO.(type) {
-					case *v1hpa.HorizontalPodAutoscaler:
-						h.ResourceVersion = ""
-						_, err := c.hpaRepo.UpdateV1HPA(ctxEg, client, h.Namespace, clusterID, h)
-						if err != nil {
-							if ctxEg.Err() != nil {
-								return nil
-							}
-						}
-						return err
-					case *v2beta1.HorizontalPodAutoscaler:
-						h.ResourceVersion = ""
-						_, err := c.hpaRepo.UpdateV2beta1HPA(
-							ctxEg,
-							client,
-							h.Namespace,
-							clusterID,
-							h,
-						)
-						if err != nil {
-							if ctxEg.Err() != nil {
-								return nil
-							}
+					err := c.UpdateHPAK8sObject(
+						ctxEg,
+						client,
+						clusterID,
+						h.HPAObject,
+						h.ModifiedHPAConfig,
+						force,
+					)
+					if err != nil {
+						if ctxEg.Err() != nil {
+							return nil
 						}
-						return err
-					case *v2beta2.HorizontalPodAutoscaler:
-						h.ResourceVersion = ""
-						_, err := c.hpaRepo.UpdateV2beta2HPA(
-							ctxEg,
-							client,
-							h.Namespace,
-							clusterID,
-							h,
-						)
-						if err != nil {
-							if ctxEg.Err() != nil {
-								return nil
-							}
+						if k8sErrors.IsConflict(err) {
+							lock.Lock()
+							conflicts[fmt.Sprintf(
+								constant.NameNSKeyFormat,
+								util.GetHPAObjectName(h.ModifiedHPAConfig.Kind, h.ModifiedHPAConfig.Name),
+								h.ModifiedHPAConfig.Namespace,
+							)] = err
+							lock.Unlock()
+							return nil
 						}
-						return err
-					default:
-						return errors.New("unknown type")
 					}
+					return err
 				}
-			}(hpaObject),
+			}(hpaPlan),
 		)
 	}
-	return errGroup.Wait()
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
 }
 
-func (c *cluster) ResolveScaleTargetRefByDeploymentsMap(
-	scaleTargetRef interface{},
-	namespace string,
-	deploymentsMap map[string]v1Apps.Deployment,
-	deleteKey ...bool,
-) (res *v1Apps.Deployment, err error) {
-	var apiVersion, kind, name string
+func (c *cluster) buildHPAApplyConfig(
+	apiVersion constant.HPAVersion,
+	name, namespace string,
+	minReplicas *int32,
+	maxReplicas int32,
+	overriddenSpec map[string]interface{},
+) ([]byte, error) {
+	// Only the replica bounds and overridden fields are owned, every other field stays with its
+	// current manager
+	spec := map[string]interface{}{
+		"maxReplicas": maxReplicas,
+	}
+	if minReplicas != nil {
+		spec["minReplicas"] = *minReplicas
+	}
+	for field, value := range overriddenSpec {
+		spec[field] = value
+	}
+	return json.Marshal(
+		map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       constant.HorizontalPodAutoscaler,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": spec,
+		},
+	)
+}
 
-	switch ref := scaleTargetRef.(type) {
-	case v1hpa.CrossVersionObjectReference:
-		apiVersion = ref.APIVersion
-		kind = ref.Kind
-		name = ref.Name
-	case v2beta1.CrossVersionObjectReference:
-		apiVersion = ref.APIVersion
-		kind = ref.Kind
-		name = ref.Name
-	case v2beta2.CrossVersionObjectReference:
-		apiVersion = ref.APIVersion
-		kind = ref.Kind
-		name = ref.Name
+func (c *cluster) UpdateHPAK8sObject(
+	ctx context.Context,
+	client kubernetes.Interface,
+	clusterID uuid.UUID,
+	hpaObject interface{},
+	modifiedHPAConfig *UCEntity.EventModifiedHPAConfigData,
+	force bool,
+) error {
+	overriddenSpec := map[string]interface{}{}
+	overrideMetrics := len(modifiedHPAConfig.MetricTargets) > 0
+	switch h := hpaObject.(type) {
+	case *v1hpa.HorizontalPodAutoscaler:
+		if overrideMetrics && h.Spec.TargetCPUUtilizationPercentage != nil {
+			overriddenSpec["targetCPUUtilizationPercentage"] = *h.Spec.TargetCPUUtilizationPercentage
+		}
+		applyConfig, err := c.buildHPAApplyConfig(
+			constant.AutoscalingV1,
+			h.Name,
+			h.Namespace,
+			h.Spec.MinReplicas,
+			h.Spec.MaxReplicas,
+			overriddenSpec,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = c.hpaRepo.ApplyV1HPA(ctx, client, h.Namespace, h.Name, clusterID, applyConfig, force)
+		return err
+	case *v2beta1.HorizontalPodAutoscaler:
+		if overrideMetrics && len(h.Spec.Metrics) > 0 {
+			overriddenSpec["metrics"] = h.Spec.Metrics
+		}
+		applyConfig, err := c.buildHPAApplyConfig(
+			constant.AutoscalingV2Beta1,
+			h.Name,
+			h.Namespace,
+			h.Spec.MinReplicas,
+			h.Spec.MaxReplicas,
+			overriddenSpec,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = c.hpaRepo.ApplyV2beta1HPA(ctx, client, h.Namespace, h.Name, clusterID, applyConfig, force)
+		return err
+	case *v2beta2.HorizontalPodAutoscaler:
+		if overrideMetrics && len(h.Spec.Metrics) > 0 {
+			overriddenSpec["metrics"] = h.Spec.Metrics
+		}
+		if modifiedHPAConfig.Behavior != nil && h.Spec.Behavior != nil {
+			overriddenSpec["behavior"] = h.Spec.Behavior
+		}
+		applyConfig, err := c.buildHPAApplyConfig(
+			constant.AutoscalingV2Beta2,
+			h.Name,
+			h.Namespace,
+			h.Spec.MinReplicas,
+			h.Spec.MaxReplicas,
+			overriddenSpec,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = c.hpaRepo.ApplyV2beta2HPA(ctx, client, h.Namespace, h.Name, clusterID, applyConfig, force)
+		return err
+	case *v2hpa.HorizontalPodAutoscaler:
+		if overrideMetrics && len(h.Spec.Metrics) > 0 {
+			overriddenSpec["metrics"] = h.Spec.Metrics
+		}
+		if modifiedHPAConfig.Behavior != nil && h.Spec.Behavior != nil {
+			overriddenSpec["behavior"] = h.Spec.Behavior
+		}
+		applyConfig, err := c.buildHPAApplyConfig(
+			constant.AutoscalingV2,
+			h.Name,
+			h.Namespace,
+			h.Spec.MinReplicas,
+			h.Spec.MaxReplicas,
+			overriddenSpec,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = c.hpaRepo.ApplyV2HPA(ctx, client, h.Namespace, h.Name, clusterID, applyConfig, force)
+		return err
+	case *UCEntity.ScaledObject:
+		// A null minReplicaCount removes the field so KEDA falls back to its default
+		patch, err := json.Marshal(
+			map[string]interface{}{
+				"spec": map[string]interface{}{
+					"minReplicaCount": h.MinReplicaCount,
+					"maxReplicaCount": h.MaxReplicaCount,
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		_, err = c.scaledObjectRepo.PatchScaledObject(ctx, client, h.Namespace, h.Name, patch)
+		return err
 	default:
-		return nil, errors.New(errorConstant.HPAVersionUnknown)
+		return errors.New("unknown type")
 	}
+}
 
-	switch apiVersion {
-	case constant.AppsV1:
+// ReleaseHPAK8sObject gives up the ownership of the restored HPA fields,
+// so the HPA owners can apply them again without conflicting with kubeEP
+func (c *cluster) ReleaseHPAK8sObject(
+	ctx context.Context,
+	client kubernetes.Interface,
+	hpaObject interface{},
+) error {
+	var name, namespace string
+	switch h := hpaObject.(type) {
+	case *v1hpa.HorizontalPodAutoscaler:
+		name, namespace = h.Name, h.Namespace
+	case *v2beta1.HorizontalPodAutoscaler:
+		name, namespace = h.Name, h.Namespace
+	case *v2beta2.HorizontalPodAutoscaler:
+		name, namespace = h.Name, h.Namespace
+	case *v2hpa.HorizontalPodAutoscaler:
+		name, namespace = h.Name, h.Namespace
+	case *UCEntity.ScaledObject:
+		// Scaled objects are merge patched, no field is owned
+		return nil
 	default:
-		return nil, errors.New(errorConstant.TargetRefResolveError)
+		return errors.New("unknown type")
 	}
+	return c.hpaRepo.ReleaseHPAFieldManager(ctx, client, namespace, name)
+}
 
-	switch kind {
-	case constant.Deployment:
-		key := fmt.Sprintf(constant.NameNSKeyFormat, name, namespace)
-		res, ok := deploymentsMap[key]
-		if !ok {
-			return nil, errors.New(errorConstant.DeploymentNotFound)
+func (c *cluster) GetHPABehaviorAndMetrics(hpaObject interface{}) (
+	behavior, metrics json.RawMessage,
+	err error,
+) {
+	switch h := hpaObject.(type) {
+	case *v1hpa.HorizontalPodAutoscaler:
+		metrics, err = json.Marshal(h.Spec.TargetCPUUtilizationPercentage)
+	case *v2beta1.HorizontalPodAutoscaler:
+		metrics, err = json.Marshal(h.Spec.Metrics)
+	case *v2beta2.HorizontalPodAutoscaler:
+		behavior, err = json.Marshal(h.Spec.Behavior)
+		if err != nil {
+			return nil, nil, err
 		}
-		if len(deleteKey) > 0 {
-			delete(deploymentsMap, key)
+		metrics, err = json.Marshal(h.Spec.Metrics)
+	case *v2hpa.HorizontalPodAutoscaler:
+		behavior, err = json.Marshal(h.Spec.Behavior)
+		if err != nil {
+			return nil, nil, err
 		}
-		return &res, nil
+		metrics, err = json.Marshal(h.Spec.Metrics)
+	case *UCEntity.ScaledObject:
+		return nil, nil, nil
 	default:
-		return nil, errors.New(errorConstant.TargetRefResolveError)
+		return nil, nil, errors.New(errorConstant.HPAVersionUnknown)
 	}
+	return
 }
 
-func (c *cluster) ResolveScaleTargetRef(
-	ctx context.Context,
-	client kubernetes.Interface,
-	scaleTargetRef interface{},
-	namespace string,
-) (res interface{}, err error) {
-	var apiVersion, kind, name string
+func (c *cluster) OverrideHPABehaviorAndMetrics(
+	hpaObject interface{},
+	modifiedHPAConfig *UCEntity.EventModifiedHPAConfigData,
+) error {
+	metricTargets := modifiedHPAConfig.MetricTargets
+	switch h := hpaObject.(type) {
+	case *v1hpa.HorizontalPodAutoscaler:
+		if modifiedHPAConfig.Behavior != nil {
+			return fmt.Errorf(errorConstant.HPABehaviorUnsupported, h.Name, constant.AutoscalingV1)
+		}
+		for _, metricTarget := range metricTargets {
+			if metricTarget.ResourceName != v1Core.ResourceCPU.String() {
+				return fmt.Errorf(errorConstant.HPAMetricNotFound, h.Name, metricTarget.ResourceName)
+			}
+			utilization := metricTarget.AverageUtilization
+			h.Spec.TargetCPUUtilizationPercentage = &utilization
+		}
+	case *v2beta1.HorizontalPodAutoscaler:
+		if modifiedHPAConfig.Behavior != nil {
+			return fmt.Errorf(errorConstant.HPABehaviorUnsupported, h.Name, constant.AutoscalingV2Beta1)
+		}
+		for _, metricTarget := range metricTargets {
+			found := false
+			for _, metric := range h.Spec.Metrics {
+				utilization := metricTarget.AverageUtilization
+				switch {
+				case metric.Resource != nil && metric.Resource.Name.String() == metricTarget.ResourceName:
+					metric.Resource.TargetAverageUtilization = &utilization
+					metric.Resource.TargetAverageValue = nil
+				case metric.ContainerResource != nil && metric.ContainerResource.Name.String() == metricTarget.ResourceName:
+					metric.ContainerResource.TargetAverageUtilization = &utilization
+					metric.ContainerResource.TargetAverageValue = nil
+				default:
+					continue
+				}
+				found = true
+			}
+			if !found {
+				return fmt.Errorf(errorConstant.HPAMetricNotFound, h.Name, metricTarget.ResourceName)
+			}
+		}
+	case *v2beta2.HorizontalPodAutoscaler:
+		if modifiedHPAConfig.Behavior != nil {
+			behavior := &v2beta2.HorizontalPodAutoscalerBehavior{}
+			err := json.Unmarshal(modifiedHPAConfig.Behavior, behavior)
+			if err != nil {
+				return err
+			}
+			h.Spec.Behavior = behavior
+		}
+		for _, metricTarget := range metricTargets {
+			found := false
+			for _, metric := range h.Spec.Metrics {
+				var target *v2beta2.MetricTarget
+				switch {
+				case metric.Resource != nil && metric.Resource.Name.String() == metricTarget.ResourceName:
+					target = &metric.Resource.Target
+				case metric.ContainerResource != nil && metric.ContainerResource.Name.String() == metricTarget.ResourceName:
+					target = &metric.ContainerResource.Target
+				default:
+					continue
+				}
+				utilization := metricTarget.AverageUtilization
+				*target = v2beta2.MetricTarget{
+					Type:               v2beta2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				}
+				found = true
+			}
+			if !found {
+				return fmt.Errorf(errorConstant.HPAMetricNotFound, h.Name, metricTarget.ResourceName)
+			}
+		}
+	case *v2hpa.HorizontalPodAutoscaler:
+		if modifiedHPAConfig.Behavior != nil {
+			behavior := &v2hpa.HorizontalPodAutoscalerBehavior{}
+			err := json.Unmarshal(modifiedHPAConfig.Behavior, behavior)
+			if err != nil {
+				return err
+			}
+			h.Spec.Behavior = behavior
+		}
+		for _, metricTarget := range metricTargets {
+			found := false
+			for _, metric := range h.Spec.Metrics {
+				var target *v2hpa.MetricTarget
+				switch {
+				case metric.Resource != nil && metric.Resource.Name.String() == metricTarget.ResourceName:
+					target = &metric.Resource.Target
+				case metric.ContainerResource != nil && metric.ContainerResource.Name.String() == metricTarget.ResourceName:
+					target = &metric.ContainerResource.Target
+				default:
+					continue
+				}
+				utilization := metricTarget.AverageUtilization
+				*target = v2hpa.MetricTarget{
+					Type:               v2hpa.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				}
+				found = true
+			}
+			if !found {
+				return fmt.Errorf(errorConstant.HPAMetricNotFound, h.Name, metricTarget.ResourceName)
+			}
+		}
+	case *UCEntity.ScaledObject:
+		if modifiedHPAConfig.Behavior != nil || len(metricTargets) > 0 {
+			return fmt.Errorf(errorConstant.ScaledObjectOverrideUnsupported, h.Name)
+		}
+	default:
+		return errors.New(errorConstant.HPAVersionUnknown)
+	}
+	return nil
+}
+
+// RestoreHPABehaviorAndMetrics only restores the fields overridden by the scheduled config
+func (c *cluster) RestoreHPABehaviorAndMetrics(
+	hpaObject interface{},
+	modifiedHPAConfig *UCEntity.EventModifiedHPAConfigData,
+) error {
+	restoreBehavior := modifiedHPAConfig.Behavior != nil && len(modifiedHPAConfig.OriginalBehavior) > 0
+	restoreMetrics := len(modifiedHPAConfig.MetricTargets) > 0 && len(modifiedHPAConfig.OriginalMetrics) > 0
+	var err error
+	switch h := hpaObject.(type) {
+	case *v1hpa.HorizontalPodAutoscaler:
+		if restoreMetrics {
+			h.Spec.TargetCPUUtilizationPercentage = nil
+			err = json.Unmarshal(modifiedHPAConfig.OriginalMetrics, &h.Spec.TargetCPUUtilizationPercentage)
+		}
+	case *v2beta1.HorizontalPodAutoscaler:
+		if restoreMetrics {
+			h.Spec.Metrics = nil
+			err = json.Unmarshal(modifiedHPAConfig.OriginalMetrics, &h.Spec.Metrics)
+		}
+	case *v2beta2.HorizontalPodAutoscaler:
+		if restoreBehavior {
+			h.Spec.Behavior = nil
+			err = json.Unmarshal(modifiedHPAConfig.OriginalBehavior, &h.Spec.Behavior)
+			if err != nil {
+				return err
+			}
+		}
+		if restoreMetrics {
+			h.Spec.Metrics = nil
+			err = json.Unmarshal(modifiedHPAConfig.OriginalMetrics, &h.Spec.Metrics)
+		}
+	case *v2hpa.HorizontalPodAutoscaler:
+		if restoreBehavior {
+			h.Spec.Behavior = nil
+			err = json.Unmarshal(modifiedHPAConfig.OriginalBehavior, &h.Spec.Behavior)
+			if err != nil {
+				return err
+			}
+		}
+		if restoreMetrics {
+			h.Spec.Metrics = nil
+			err = json.Unmarshal(modifiedHPAConfig.OriginalMetrics, &h.Spec.Metrics)
+		}
+	case *UCEntity.ScaledObject:
+	default:
+		return errors.New(errorConstant.HPAVersionUnknown)
+	}
+	return err
+}
 
+func (c *cluster) getScaleTargetRefData(scaleTargetRef interface{}) (
+	apiVersion, kind, name string,
+	err error,
+) {
 	switch ref := scaleTargetRef.(type) {
 	case v1hpa.CrossVersionObjectReference:
-		apiVersion = ref.APIVersion
-		kind = ref.Kind
-		name = ref.Name
+		return ref.APIVersion, ref.Kind, ref.Name, nil
 	case v2beta1.CrossVersionObjectReference:
-		apiVersion = ref.APIVersion
-		kind = ref.Kind
-		name = ref.Name
+		return ref.APIVersion, ref.Kind, ref.Name, nil
 	case v2beta2.CrossVersionObjectReference:
-		apiVersion = ref.APIVersion
-		kind = ref.Kind
-		name = ref.Name
+		return ref.APIVersion, ref.Kind, ref.Name, nil
+	case v2hpa.CrossVersionObjectReference:
+		return ref.APIVersion, ref.Kind, ref.Name, nil
 	default:
-		return nil, errors.New(errorConstant.HPAVersionUnknown)
+		return "", "", "", errors.New(errorConstant.HPAVersionUnknown)
 	}
+}
 
-	switch apiVersion {
-	case constant.AppsV1:
-	default:
-		return nil, errors.New(errorConstant.TargetRefResolveError)
+func (c *cluster) deploymentToScaleTargetData(deployment *v1Apps.Deployment) *UCEntity.ScaleTargetData {
+	status := deployment.Status
+	return &UCEntity.ScaleTargetData{
+		Kind:                constant.Deployment,
+		Name:                deployment.Name,
+		Namespace:           deployment.Namespace,
+		PodTemplate:         deployment.Spec.Template,
+		Replicas:            status.Replicas,
+		AvailableReplicas:   status.AvailableReplicas,
+		ReadyReplicas:       status.ReadyReplicas,
+		UnavailableReplicas: status.UnavailableReplicas,
 	}
+}
 
-	switch kind {
-	case constant.Deployment:
-		res, err = c.deploymentRepo.GetDeployment(ctx, client, namespace, name)
-	default:
+func (c *cluster) ResolveScaleTargetRefByDeploymentsMap(
+	ctx context.Context,
+	client kubernetes.Interface,
+	scaleTargetRef interface{},
+	namespace string,
+	deploymentsMap map[string]v1Apps.Deployment,
+	deleteKey ...bool,
+) (*UCEntity.ScaleTargetData, error) {
+	apiVersion, kind, name, err := c.getScaleTargetRefData(scaleTargetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only deployments are prefetched, other targets are resolved from the cluster
+	if apiVersion != constant.AppsV1 || kind != constant.Deployment {
+		return c.ResolveScaleTargetRef(ctx, client, scaleTargetRef, namespace)
+	}
+
+	key := fmt.Sprintf(constant.NameNSKeyFormat, name, namespace)
+	res, ok := deploymentsMap[key]
+	if !ok {
+		return nil, errors.New(errorConstant.DeploymentNotFound)
+	}
+	if len(deleteKey) > 0 {
+		delete(deploymentsMap, key)
+	}
+	return c.deploymentToScaleTargetData(&res), nil
+}
+
+func (c *cluster) ResolveScaleTargetRef(
+	ctx context.Context,
+	client kubernetes.Interface,
+	scaleTargetRef interface{},
+	namespace string,
+) (*UCEntity.ScaleTargetData, error) {
+	apiVersion, kind, name, err := c.getScaleTargetRefData(scaleTargetRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiVersion == constant.AppsV1 {
+		switch kind {
+		case constant.Deployment:
+			deployment, err := c.deploymentRepo.GetDeployment(ctx, client, namespace, name)
+			if err != nil {
+				return nil, err
+			}
+			return c.deploymentToScaleTargetData(deployment), nil
+		case constant.StatefulSet:
+			statefulSet, err := c.workloadRepo.GetStatefulSet(ctx, client, namespace, name)
+			if err != nil {
+				return nil, err
+			}
+			status := statefulSet.Status
+			return &UCEntity.ScaleTargetData{
+				Kind:                kind,
+				Name:                name,
+				Namespace:           namespace,
+				PodTemplate:         statefulSet.Spec.Template,
+				Replicas:            status.Replicas,
+				AvailableReplicas:   status.AvailableReplicas,
+				ReadyReplicas:       status.ReadyReplicas,
+				UnavailableReplicas: status.Replicas - status.AvailableReplicas,
+			}, nil
+		case constant.ReplicaSet:
+			replicaSet, err := c.workloadRepo.GetReplicaSet(ctx, client, namespace, name)
+			if err != nil {
+				return nil, err
+			}
+			status := replicaSet.Status
+			return &UCEntity.ScaleTargetData{
+				Kind:                kind,
+				Name:                name,
+				Namespace:           namespace,
+				PodTemplate:         replicaSet.Spec.Template,
+				Replicas:            status.Replicas,
+				AvailableReplicas:   status.AvailableReplicas,
+				ReadyReplicas:       status.ReadyReplicas,
+				UnavailableReplicas: status.Replicas - status.AvailableReplicas,
+			}, nil
+		}
+	}
+
+	return c.resolveScaleSubresource(ctx, client, apiVersion, kind, name, namespace)
+}
+
+// resolveScaleSubresource resolves any target that implements the scale subresource, such as
+// custom resources
+func (c *cluster) resolveScaleSubresource(
+	ctx context.Context,
+	client kubernetes.Interface,
+	apiVersion, kind, name, namespace string,
+) (*UCEntity.ScaleTargetData, error) {
+	resource, err := c.workloadRepo.GetResourceName(client, apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	if resource == "" {
 		return nil, errors.New(errorConstant.TargetRefResolveError)
 	}
-	return
+
+	scale, err := c.workloadRepo.GetScale(ctx, client, apiVersion, resource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	object, err := c.workloadRepo.GetObject(ctx, client, apiVersion, resource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &UCEntity.ScaleTargetData{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Replicas:  scale.Status.Replicas,
+	}
+
+	template, found, err := unstructured.NestedMap(object.Object, "spec", "template")
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, &data.PodTemplate)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Targets referencing their template elsewhere are resolved from one of their pods
+		if scale.Status.Selector == "" {
+			return nil, fmt.Errorf(errorConstant.PodTemplateNotFound, kind, name)
+		}
+		pods, err := c.workloadRepo.GetPodList(
+			ctx, client, namespace, v1.ListOptions{
+				LabelSelector: scale.Status.Selector,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(pods.Items) == 0 {
+			return nil, fmt.Errorf(errorConstant.PodTemplateNotFound, kind, name)
+		}
+		data.PodTemplate = v1Core.PodTemplateSpec{
+			ObjectMeta: v1.ObjectMeta{Labels: pods.Items[0].Labels},
+			Spec:       pods.Items[0].Spec,
+		}
+	}
+
+	readyReplicas, _, _ := unstructured.NestedInt64(object.Object, "status", "readyReplicas")
+	availableReplicas, found, _ := unstructured.NestedInt64(object.Object, "status", "availableReplicas")
+	if !found {
+		availableReplicas = readyReplicas
+	}
+	data.ReadyReplicas = int32(readyReplicas)
+	data.AvailableReplicas = int32(availableReplicas)
+	data.UnavailableReplicas = data.Replicas - data.AvailableReplicas
+	if data.UnavailableReplicas < 0 {
+		data.UnavailableReplicas = 0
+	}
+
+	return data, nil
+}
+
+func (c *cluster) getWorkloadResourceName(client kubernetes.Interface, kind string) (string, error) {
+	if kind != constant.Deployment && kind != constant.StatefulSet {
+		return "", fmt.Errorf(errorConstant.WorkloadKindInvalid, kind)
+	}
+	resource, err := c.workloadRepo.GetResourceName(client, constant.AppsV1, kind)
+	if err != nil {
+		return "", err
+	}
+	if resource == "" {
+		return "", fmt.Errorf(errorConstant.WorkloadKindInvalid, kind)
+	}
+	return resource, nil
+}
+
+// GetWorkloadReplicas returns the desired replicas from the scale subresource of the workload
+func (c *cluster) GetWorkloadReplicas(
+	ctx context.Context,
+	client kubernetes.Interface,
+	kind, name, namespace string,
+) (int32, error) {
+	resource, err := c.getWorkloadResourceName(client, kind)
+	if err != nil {
+		return 0, err
+	}
+	scale, err := c.workloadRepo.GetScale(ctx, client, constant.AppsV1, resource, namespace, name)
+	if err != nil {
+		return 0, err
+	}
+	return scale.Spec.Replicas, nil
+}
+
+func (c *cluster) ScaleWorkload(
+	ctx context.Context,
+	client kubernetes.Interface,
+	kind, name, namespace string,
+	replicas int32,
+) error {
+	resource, err := c.getWorkloadResourceName(client, kind)
+	if err != nil {
+		return err
+	}
+	_, err = c.workloadRepo.UpdateScale(
+		ctx,
+		client,
+		constant.AppsV1,
+		resource,
+		namespace,
+		name,
+		replicas,
+	)
+	return err
 }
 
 func (c *cluster) GetAllDeployments(
@@ -642,3 +1437,15 @@ func (c *cluster) GetAllDaemonSetsInNamespace(
 	}
 	return &UCEntity.K8sDaemonSetListData{DaemonSetListObject: data}, nil
 }
+
+func (c *cluster) GetAllPods(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace string,
+) (*UCEntity.K8sPodListData, error) {
+	data, err := c.workloadRepo.GetPodList(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.K8sPodListData{PodListObject: data}, nil
+}
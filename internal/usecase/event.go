@@ -1,12 +1,18 @@
 package useCase
 
 import (
+	"errors"
+	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"gorm.io/gorm"
+	"sort"
 	"time"
 )
 
@@ -15,6 +21,7 @@ type Event interface {
 	GetEventByName(tx *gorm.DB, eventName string) (*UCEntity.Event, error)
 	ListEventByClusterID(tx *gorm.DB, clusterID uuid.UUID) ([]UCEntity.Event, error)
 	UpdateEvent(tx *gorm.DB, eventData *UCEntity.Event) error
+	UpdateLeasedEventStatus(tx *gorm.DB, eventData *UCEntity.Event, owner string) error
 	GetEventByID(tx *gorm.DB, eventID uuid.UUID) (*UCEntity.Event, error)
 	GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 		*UCEntity.DetailedEvent,
@@ -34,37 +41,116 @@ type Event interface {
 		[]*UCEntity.Event,
 		error,
 	)
+	GetAllFinishedWatchedEvent(tx *gorm.DB, now time.Time) (
+		[]*UCEntity.Event,
+		error,
+	)
+	GetAllEventWithDueHPAStep(tx *gorm.DB, now time.Time) (
+		[]*UCEntity.Event,
+		error,
+	)
+	GetAllCancelledUnrestoredEvent(tx *gorm.DB) (
+		[]*UCEntity.Event,
+		error,
+	)
+	ScheduleEventNow(tx *gorm.DB, eventID uuid.UUID, now time.Time) error
+	CancelEvent(tx *gorm.DB, eventID uuid.UUID, now time.Time) error
+	RetryEvent(tx *gorm.DB, eventID uuid.UUID, now time.Time) error
+	GetAllExpiredLeaseEvent(tx *gorm.DB, status model.EventStatus, now time.Time) (
+		[]*UCEntity.Event,
+		error,
+	)
+	GetAllUnleasedEvent(tx *gorm.DB, status model.EventStatus) ([]*UCEntity.Event, error)
+	ClaimEvent(
+		tx *gorm.DB,
+		eventData *UCEntity.Event,
+		status model.EventStatus,
+		owner string,
+		now time.Time,
+	) (bool, error)
+	ClaimEventRestore(
+		tx *gorm.DB,
+		eventData *UCEntity.Event,
+		restoredStatus model.EventStatus,
+		owner string,
+		now time.Time,
+	) (bool, error)
+	RenewEventLease(tx *gorm.DB, eventID uuid.UUID, owner string, now time.Time) (bool, error)
+	ReleaseEventLease(tx *gorm.DB, eventID uuid.UUID, owner string, now time.Time) error
+	FindConflictingEvents(
+		tx *gorm.DB,
+		eventData *UCEntity.Event,
+		hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+	) ([]UCEntity.EventConflict, error)
+	MergeConflictingHPAConfigs(
+		hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+		conflicts []UCEntity.EventConflict,
+	) ([]UCEntity.EventModifiedHPAConfigData, error)
 }
 
 type event struct {
-	validatorInst                *validator.Validate
-	eventRepository              repository.Event
-	scheduledHPAConfigRepository repository.ScheduledHPAConfig
-	clusterRepository            repository.Cluster
+	validatorInst                     *validator.Validate
+	eventRepository                   repository.Event
+	scheduledHPAConfigRepository      repository.ScheduledHPAConfig
+	scheduledWorkloadConfigRepository repository.ScheduledWorkloadConfig
+	clusterRepository                 repository.Cluster
+	updatedNodePoolRepository         repository.UpdatedNodePool
 }
 
 func newEvent(
 	validatorInst *validator.Validate,
 	eventRepository repository.Event,
 	scheduledHPAConfigRepository repository.ScheduledHPAConfig,
+	scheduledWorkloadConfigRepository repository.ScheduledWorkloadConfig,
 	clusterRepository repository.Cluster,
+	updatedNodePoolRepository repository.UpdatedNodePool,
 ) Event {
 	return &event{
-		validatorInst:                validatorInst,
-		eventRepository:              eventRepository,
-		scheduledHPAConfigRepository: scheduledHPAConfigRepository,
-		clusterRepository:            clusterRepository,
+		validatorInst:                     validatorInst,
+		eventRepository:                   eventRepository,
+		scheduledHPAConfigRepository:      scheduledHPAConfigRepository,
+		scheduledWorkloadConfigRepository: scheduledWorkloadConfigRepository,
+		clusterRepository:                 clusterRepository,
+		updatedNodePoolRepository:         updatedNodePoolRepository,
+	}
+}
+
+func toEventEntity(event *model.Event) *UCEntity.Event {
+	return &UCEntity.Event{
+		CreatedAt:            event.CreatedAt,
+		UpdatedAt:            event.UpdatedAt,
+		ID:                   event.ID.GetUUID(),
+		Status:               event.Status,
+		Name:                 event.Name,
+		ExecuteConfigAt:      event.ExecuteConfigAt,
+		WatchingAt:           event.WatchingAt,
+		Message:              event.Message,
+		StartTime:            event.StartTime,
+		EndTime:              event.EndTime,
+		CalculateNodePool:    event.CalculateNodePool,
+		ForceHPAApply:        event.ForceHPAApply,
+		NodePoolDistribution: event.NodePoolDistribution,
+		PrimaryNodePool:      event.PrimaryNodePool,
+		RestoredStatus:       event.RestoredStatus,
+		Cluster: UCEntity.ClusterData{
+			Name:       event.Cluster.Name,
+			ID:         event.ClusterID.GetUUID(),
+			Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter},
+		},
 	}
 }
 
 func (e *event) RegisterEvents(tx *gorm.DB, eventData *UCEntity.Event) (uuid.UUID, error) {
 	data := &model.Event{
-		Name:              eventData.Name,
-		StartTime:         eventData.StartTime,
-		EndTime:           eventData.EndTime,
-		CalculateNodePool: eventData.CalculateNodePool,
-		ExecuteConfigAt:   eventData.ExecuteConfigAt,
-		WatchingAt:        eventData.WatchingAt,
+		Name:                 eventData.Name,
+		StartTime:            eventData.StartTime,
+		EndTime:              eventData.EndTime,
+		CalculateNodePool:    eventData.CalculateNodePool,
+		ForceHPAApply:        eventData.ForceHPAApply,
+		NodePoolDistribution: eventData.NodePoolDistribution,
+		PrimaryNodePool:      eventData.PrimaryNodePool,
+		ExecuteConfigAt:      eventData.ExecuteConfigAt,
+		WatchingAt:           eventData.WatchingAt,
 	}
 	data.ClusterID.SetUUID(eventData.Cluster.ID)
 
@@ -80,19 +166,7 @@ func (e *event) GetEventByName(tx *gorm.DB, eventName string) (*UCEntity.Event,
 	if err != nil {
 		return nil, err
 	}
-	return &UCEntity.Event{
-		ID:                data.ID.GetUUID(),
-		Name:              data.Name,
-		ExecuteConfigAt:   data.ExecuteConfigAt,
-		WatchingAt:        data.WatchingAt,
-		StartTime:         data.StartTime,
-		EndTime:           data.EndTime,
-		CreatedAt:         data.CreatedAt,
-		UpdatedAt:         data.UpdatedAt,
-		Status:            data.Status,
-		Message:           data.Message,
-		CalculateNodePool: data.CalculateNodePool,
-	}, nil
+	return toEventEntity(data), nil
 }
 
 func (e *event) GetEventByID(tx *gorm.DB, eventID uuid.UUID) (*UCEntity.Event, error) {
@@ -100,20 +174,7 @@ func (e *event) GetEventByID(tx *gorm.DB, eventID uuid.UUID) (*UCEntity.Event, e
 	if err != nil {
 		return nil, err
 	}
-	return &UCEntity.Event{
-		ID:                eventID,
-		Name:              data.Name,
-		StartTime:         data.StartTime,
-		EndTime:           data.EndTime,
-		CreatedAt:         data.CreatedAt,
-		ExecuteConfigAt:   data.ExecuteConfigAt,
-		WatchingAt:        data.WatchingAt,
-		UpdatedAt:         data.UpdatedAt,
-		Status:            data.Status,
-		Message:           data.Message,
-		CalculateNodePool: data.CalculateNodePool,
-		Cluster:           UCEntity.ClusterData{ID: data.ClusterID.GetUUID()},
-	}, nil
+	return toEventEntity(data), nil
 }
 
 func (e *event) ListEventByClusterID(tx *gorm.DB, clusterID uuid.UUID) ([]UCEntity.Event, error) {
@@ -123,35 +184,24 @@ func (e *event) ListEventByClusterID(tx *gorm.DB, clusterID uuid.UUID) ([]UCEnti
 	}
 	var output []UCEntity.Event
 	for _, event := range events {
-		output = append(
-			output, UCEntity.Event{
-				ID:                event.ID.GetUUID(),
-				Name:              event.Name,
-				ExecuteConfigAt:   event.ExecuteConfigAt,
-				WatchingAt:        event.WatchingAt,
-				StartTime:         event.StartTime,
-				EndTime:           event.EndTime,
-				CreatedAt:         event.CreatedAt,
-				UpdatedAt:         event.UpdatedAt,
-				Status:            event.Status,
-				Message:           event.Message,
-				CalculateNodePool: event.CalculateNodePool,
-			},
-		)
+		output = append(output, *toEventEntity(event))
 	}
 	return output, nil
 }
 
 func (e *event) UpdateEvent(tx *gorm.DB, eventData *UCEntity.Event) error {
 	data := &model.Event{
-		Name:              eventData.Name,
-		StartTime:         eventData.StartTime,
-		EndTime:           eventData.EndTime,
-		Status:            eventData.Status,
-		Message:           eventData.Message,
-		CalculateNodePool: eventData.CalculateNodePool,
-		ExecuteConfigAt:   eventData.ExecuteConfigAt,
-		WatchingAt:        eventData.WatchingAt,
+		Name:                 eventData.Name,
+		StartTime:            eventData.StartTime,
+		EndTime:              eventData.EndTime,
+		Status:               eventData.Status,
+		Message:              eventData.Message,
+		CalculateNodePool:    eventData.CalculateNodePool,
+		ForceHPAApply:        eventData.ForceHPAApply,
+		NodePoolDistribution: eventData.NodePoolDistribution,
+		PrimaryNodePool:      eventData.PrimaryNodePool,
+		ExecuteConfigAt:      eventData.ExecuteConfigAt,
+		WatchingAt:           eventData.WatchingAt,
 	}
 	data.CreatedAt = eventData.CreatedAt
 	data.UpdatedAt = eventData.UpdatedAt
@@ -160,6 +210,23 @@ func (e *event) UpdateEvent(tx *gorm.DB, eventData *UCEntity.Event) error {
 	return e.eventRepository.SaveEvent(tx, data)
 }
 
+func (e *event) UpdateLeasedEventStatus(tx *gorm.DB, eventData *UCEntity.Event, owner string) error {
+	updated, err := e.eventRepository.UpdateLeasedEventStatus(
+		tx,
+		eventData.ID,
+		owner,
+		eventData.Status,
+		eventData.Message,
+	)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New(errorConstant.EventLeaseLost)
+	}
+	return nil
+}
+
 func (e *event) GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 	*UCEntity.DetailedEvent,
 	error,
@@ -185,19 +252,30 @@ func (e *event) GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 		return nil, err
 	}
 
+	scheduledWorkloadConfigs, err := e.scheduledWorkloadConfigRepository.ListScheduledWorkloadConfigByEventID(
+		tx,
+		eventData.ID.GetUUID(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	data := &UCEntity.DetailedEvent{
 		Event: UCEntity.Event{
-			CreatedAt:         eventData.CreatedAt,
-			UpdatedAt:         eventData.UpdatedAt,
-			ID:                eventID,
-			Name:              eventData.Name,
-			ExecuteConfigAt:   eventData.ExecuteConfigAt,
-			WatchingAt:        eventData.WatchingAt,
-			StartTime:         eventData.StartTime,
-			Status:            eventData.Status,
-			Message:           eventData.Message,
-			EndTime:           eventData.EndTime,
-			CalculateNodePool: eventData.CalculateNodePool,
+			CreatedAt:            eventData.CreatedAt,
+			UpdatedAt:            eventData.UpdatedAt,
+			ID:                   eventID,
+			Name:                 eventData.Name,
+			ExecuteConfigAt:      eventData.ExecuteConfigAt,
+			WatchingAt:           eventData.WatchingAt,
+			StartTime:            eventData.StartTime,
+			Status:               eventData.Status,
+			Message:              eventData.Message,
+			EndTime:              eventData.EndTime,
+			CalculateNodePool:    eventData.CalculateNodePool,
+			ForceHPAApply:        eventData.ForceHPAApply,
+			NodePoolDistribution: eventData.NodePoolDistribution,
+			PrimaryNodePool:      eventData.PrimaryNodePool,
 			Cluster: UCEntity.ClusterData{
 				ID:   eventData.ClusterID.GetUUID(),
 				Name: clusterData.Name,
@@ -211,20 +289,23 @@ func (e *event) GetDetailedEventData(tx *gorm.DB, eventID uuid.UUID) (
 
 	var eventModifiedHPAConfigData []UCEntity.EventModifiedHPAConfigData
 	for _, hpa := range scheduledHPAConfigs {
-		eventModifiedHPAConfigData = append(
-			eventModifiedHPAConfigData, UCEntity.EventModifiedHPAConfigData{
-				ID:          hpa.ID.GetUUID(),
-				Name:        hpa.Name,
-				Namespace:   hpa.Namespace,
-				Status:      hpa.Status,
-				Message:     hpa.Message,
-				MinReplicas: hpa.MinPods,
-				MaxReplicas: hpa.MaxPods,
-			},
+		modifiedHPAConfigData, err := newEventModifiedHPAConfigData(hpa)
+		if err != nil {
+			return nil, err
+		}
+		eventModifiedHPAConfigData = append(eventModifiedHPAConfigData, *modifiedHPAConfigData)
+	}
+
+	var eventModifiedWorkloadConfigData []UCEntity.EventModifiedWorkloadConfigData
+	for _, workload := range scheduledWorkloadConfigs {
+		eventModifiedWorkloadConfigData = append(
+			eventModifiedWorkloadConfigData,
+			*newEventModifiedWorkloadConfigData(workload),
 		)
 	}
 
 	data.EventModifiedHPAConfigData = eventModifiedHPAConfigData
+	data.EventModifiedWorkloadConfigData = eventModifiedWorkloadConfigData
 	return data, nil
 }
 
@@ -242,22 +323,44 @@ func (e *event) GetAllPrescaledEvent(tx *gorm.DB, now time.Time) (
 	}
 	var eventsData []*UCEntity.Event
 	for _, event := range events {
-		eventsData = append(
-			eventsData, &UCEntity.Event{
-				CreatedAt:         event.CreatedAt,
-				UpdatedAt:         event.UpdatedAt,
-				ID:                event.ID.GetUUID(),
-				Status:            event.Status,
-				Name:              event.Name,
-				ExecuteConfigAt:   event.ExecuteConfigAt,
-				WatchingAt:        event.WatchingAt,
-				Message:           event.Message,
-				StartTime:         event.StartTime,
-				EndTime:           event.EndTime,
-				CalculateNodePool: event.CalculateNodePool,
-				Cluster:           UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
-			},
-		)
+		eventsData = append(eventsData, toEventEntity(event))
+	}
+
+	return eventsData, nil
+}
+
+func (e *event) GetAllFinishedWatchedEvent(tx *gorm.DB, now time.Time) (
+	[]*UCEntity.Event,
+	error,
+) {
+	events, err := e.eventRepository.FindEventByEndTime(tx, model.EventWatching, now)
+	if err != nil {
+		return nil, err
+	}
+	var eventsData []*UCEntity.Event
+	for _, event := range events {
+		eventsData = append(eventsData, toEventEntity(event))
+	}
+
+	return eventsData, nil
+}
+
+func (e *event) GetAllEventWithDueHPAStep(tx *gorm.DB, now time.Time) (
+	[]*UCEntity.Event,
+	error,
+) {
+	// Watched events apply their steps from the watcher holding their lease
+	events, err := e.eventRepository.FindEventWithDueHPAStep(
+		tx,
+		[]model.EventStatus{model.EventPrescaled},
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var eventsData []*UCEntity.Event
+	for _, event := range events {
+		eventsData = append(eventsData, toEventEntity(event))
 	}
 
 	return eventsData, nil
@@ -273,22 +376,7 @@ func (e *event) GetAllPendingExecutableEvent(tx *gorm.DB, now time.Time) (
 	}
 	var eventsData []*UCEntity.Event
 	for _, event := range events {
-		eventsData = append(
-			eventsData, &UCEntity.Event{
-				CreatedAt:         event.CreatedAt,
-				UpdatedAt:         event.UpdatedAt,
-				ID:                event.ID.GetUUID(),
-				Status:            event.Status,
-				Name:              event.Name,
-				ExecuteConfigAt:   event.ExecuteConfigAt,
-				WatchingAt:        event.WatchingAt,
-				Message:           event.Message,
-				StartTime:         event.StartTime,
-				EndTime:           event.EndTime,
-				CalculateNodePool: event.CalculateNodePool,
-				Cluster:           UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
-			},
-		)
+		eventsData = append(eventsData, toEventEntity(event))
 	}
 
 	return eventsData, nil
@@ -309,22 +397,7 @@ func (e *event) GetAllPrescaledEvent10MinBeforeStart(tx *gorm.DB, now time.Time)
 	}
 	var eventsData []*UCEntity.Event
 	for _, event := range events {
-		eventsData = append(
-			eventsData, &UCEntity.Event{
-				CreatedAt:         event.CreatedAt,
-				UpdatedAt:         event.UpdatedAt,
-				ID:                event.ID.GetUUID(),
-				Status:            event.Status,
-				Name:              event.Name,
-				ExecuteConfigAt:   event.ExecuteConfigAt,
-				WatchingAt:        event.WatchingAt,
-				Message:           event.Message,
-				StartTime:         event.StartTime,
-				EndTime:           event.EndTime,
-				CalculateNodePool: event.CalculateNodePool,
-				Cluster:           UCEntity.ClusterData{Name: event.Cluster.Name, ID: event.ClusterID.GetUUID(), Datacenter: UCEntity.DatacenterDetailedData{Datacenter: event.Cluster.Datacenter.Datacenter}},
-			},
-		)
+		eventsData = append(eventsData, toEventEntity(event))
 	}
 
 	return eventsData, nil
@@ -333,3 +406,390 @@ func (e *event) GetAllPrescaledEvent10MinBeforeStart(tx *gorm.DB, now time.Time)
 func (e *event) FinishAllWatchedEvent(tx *gorm.DB, now time.Time) error {
 	return e.eventRepository.FinishWatchedEvent(tx, now)
 }
+
+func (e *event) GetAllCancelledUnrestoredEvent(tx *gorm.DB) (
+	[]*UCEntity.Event,
+	error,
+) {
+	events, err := e.eventRepository.FindEventByStatusWithPendingRestore(tx, model.EventCancelled)
+	if err != nil {
+		return nil, err
+	}
+	var eventsData []*UCEntity.Event
+	for _, event := range events {
+		eventsData = append(eventsData, toEventEntity(event))
+	}
+
+	return eventsData, nil
+}
+
+// ScheduleEventNow moves the execution of a pending event to now,
+// the event cron picks it up on its next tick
+func (e *event) ScheduleEventNow(tx *gorm.DB, eventID uuid.UUID, now time.Time) error {
+	event, err := e.eventRepository.GetEventByID(tx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.Status != model.EventPending {
+		return fmt.Errorf(errorConstant.EventStatusInvalid, event.Status, "executed")
+	}
+
+	if !now.Before(event.EndTime) {
+		return errors.New(errorConstant.EventAlreadyEnded)
+	}
+
+	event.ExecuteConfigAt = now
+	if event.WatchingAt.Before(now) {
+		event.WatchingAt = now
+	}
+
+	return e.updateUnleasedEvent(tx, event, "executed", now, model.EventPending)
+}
+
+func (e *event) CancelEvent(tx *gorm.DB, eventID uuid.UUID, now time.Time) error {
+	event, err := e.eventRepository.GetEventByID(tx, eventID)
+	if err != nil {
+		return err
+	}
+
+	switch event.Status {
+	case model.EventPending, model.EventPrescaled:
+	default:
+		return fmt.Errorf(errorConstant.EventStatusInvalid, event.Status, "cancelled")
+	}
+
+	event.Status = model.EventCancelled
+
+	return e.updateUnleasedEvent(
+		tx,
+		event,
+		"cancelled",
+		now,
+		model.EventPending,
+		model.EventPrescaled,
+	)
+}
+
+// RetryEvent puts a failed event back to pending, events only fail while executing and the next execution
+// skips node pools, HPAs and workloads already updated by the failed attempt
+func (e *event) RetryEvent(tx *gorm.DB, eventID uuid.UUID, now time.Time) error {
+	event, err := e.eventRepository.GetEventByID(tx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.Status != model.EventFailed {
+		return fmt.Errorf(errorConstant.EventStatusInvalid, event.Status, "retried")
+	}
+
+	if !now.Before(event.EndTime) {
+		return errors.New(errorConstant.EventAlreadyEnded)
+	}
+
+	event.Status = model.EventPending
+	event.Message = ""
+	event.ExecuteConfigAt = now
+	if event.WatchingAt.Before(now) {
+		event.WatchingAt = now
+	}
+
+	return e.updateUnleasedEvent(tx, event, "retried", now, model.EventFailed)
+}
+
+// updateUnleasedEvent fails when a worker changed or claimed the event after it was read
+func (e *event) updateUnleasedEvent(
+	tx *gorm.DB,
+	event *model.Event,
+	action string,
+	now time.Time,
+	fromStatuses ...model.EventStatus,
+) error {
+	updated, err := e.eventRepository.UpdateUnleasedEvent(tx, event, fromStatuses, now)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		latestEvent, err := e.eventRepository.GetEventByID(tx, event.ID.GetUUID())
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf(errorConstant.EventStatusInvalid, latestEvent.Status, action)
+	}
+	return nil
+}
+
+func (e *event) GetAllExpiredLeaseEvent(tx *gorm.DB, status model.EventStatus, now time.Time) (
+	[]*UCEntity.Event,
+	error,
+) {
+	events, err := e.eventRepository.FindEventByExpiredLease(tx, status, now)
+	if err != nil {
+		return nil, err
+	}
+	var eventsData []*UCEntity.Event
+	for _, event := range events {
+		eventsData = append(eventsData, toEventEntity(event))
+	}
+
+	return eventsData, nil
+}
+
+func (e *event) ClaimEvent(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	status model.EventStatus,
+	owner string,
+	now time.Time,
+) (bool, error) {
+	claimed, err := e.eventRepository.ClaimEvent(
+		tx,
+		eventData.ID,
+		eventData.Status,
+		status,
+		owner,
+		now,
+		now.Add(constant.EventLeaseDuration),
+	)
+	if err != nil {
+		return false, err
+	}
+	if claimed {
+		eventData.Status = status
+	}
+	return claimed, nil
+}
+
+func (e *event) ClaimEventRestore(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	restoredStatus model.EventStatus,
+	owner string,
+	now time.Time,
+) (bool, error) {
+	claimed, err := e.eventRepository.ClaimEventRestore(
+		tx,
+		eventData.ID,
+		eventData.Status,
+		restoredStatus,
+		owner,
+		now,
+		now.Add(constant.EventLeaseDuration),
+	)
+	if err != nil {
+		return false, err
+	}
+	if claimed {
+		eventData.Status = model.EventRestoring
+		eventData.RestoredStatus = restoredStatus
+	}
+	return claimed, nil
+}
+
+func (e *event) RenewEventLease(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	owner string,
+	now time.Time,
+) (bool, error) {
+	return e.eventRepository.RenewEventLease(
+		tx,
+		eventID,
+		owner,
+		now.Add(constant.EventLeaseDuration),
+	)
+}
+
+func (e *event) ReleaseEventLease(tx *gorm.DB, eventID uuid.UUID, owner string, now time.Time) error {
+	return e.eventRepository.ReleaseEventLease(tx, eventID, owner, now)
+}
+
+func (e *event) GetAllUnleasedEvent(tx *gorm.DB, status model.EventStatus) (
+	[]*UCEntity.Event,
+	error,
+) {
+	events, err := e.eventRepository.FindEventWithoutLease(tx, status)
+	if err != nil {
+		return nil, err
+	}
+	var eventsData []*UCEntity.Event
+	for _, event := range events {
+		eventsData = append(eventsData, toEventEntity(event))
+	}
+
+	return eventsData, nil
+}
+
+func getHPAConfigKey(hpaConfig UCEntity.EventModifiedHPAConfigData) string {
+	return fmt.Sprintf(
+		constant.NameNSKeyFormat,
+		util.GetHPAObjectName(hpaConfig.Kind, hpaConfig.Name),
+		hpaConfig.Namespace,
+	)
+}
+
+// getEventNodePoolNames returns the node pools known to be scaled by the event, the node pools updated
+// by its execution and its primary node pool
+func (e *event) getEventNodePoolNames(tx *gorm.DB, eventData *UCEntity.Event) (map[string]bool, error) {
+	nodePoolNames := map[string]bool{}
+	if !eventData.CalculateNodePool {
+		return nodePoolNames, nil
+	}
+	if eventData.PrimaryNodePool != "" {
+		nodePoolNames[eventData.PrimaryNodePool] = true
+	}
+	if eventData.ID == uuid.Nil {
+		return nodePoolNames, nil
+	}
+	updatedNodePools, err := e.updatedNodePoolRepository.GetAllUpdatedNodePoolByEventID(tx, eventData.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, updatedNodePool := range updatedNodePools {
+		nodePoolNames[updatedNodePool.NodePoolName] = true
+	}
+	return nodePoolNames, nil
+}
+
+// FindConflictingEvents only reports shared node pools known by name on both events,
+// node pools selected by a pending event plan are unknown until the event is executed
+func (e *event) FindConflictingEvents(
+	tx *gorm.DB,
+	eventData *UCEntity.Event,
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+) ([]UCEntity.EventConflict, error) {
+	windowEnd := eventData.EndTime
+	for _, hpaConfig := range hpaConfigs {
+		for _, step := range hpaConfig.RampDownSteps {
+			if step.ExecuteAt.After(windowEnd) {
+				windowEnd = step.ExecuteAt
+			}
+		}
+	}
+
+	events, err := e.eventRepository.FindOverlappingEvent(
+		tx,
+		eventData.Cluster.ID,
+		eventData.ID,
+		[]model.EventStatus{
+			model.EventPending,
+			model.EventExecuting,
+			model.EventPrescaled,
+			model.EventWatching,
+			model.EventRestoring,
+		},
+		eventData.ExecuteConfigAt,
+		windowEnd,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	hpaConfigKeys := map[string]bool{}
+	for _, hpaConfig := range hpaConfigs {
+		hpaConfigKeys[getHPAConfigKey(hpaConfig)] = true
+	}
+
+	nodePoolNames, err := e.getEventNodePoolNames(tx, eventData)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []UCEntity.EventConflict
+	for _, event := range events {
+		conflict := UCEntity.EventConflict{
+			Event: UCEntity.Event{
+				ID:                   event.ID.GetUUID(),
+				Name:                 event.Name,
+				Status:               event.Status,
+				StartTime:            event.StartTime,
+				EndTime:              event.EndTime,
+				ExecuteConfigAt:      event.ExecuteConfigAt,
+				WatchingAt:           event.WatchingAt,
+				CalculateNodePool:    event.CalculateNodePool,
+				ForceHPAApply:        event.ForceHPAApply,
+				NodePoolDistribution: event.NodePoolDistribution,
+				PrimaryNodePool:      event.PrimaryNodePool,
+			},
+		}
+		conflict.Cluster.ID = event.ClusterID.GetUUID()
+
+		conflictNodePoolNames, err := e.getEventNodePoolNames(tx, &conflict.Event)
+		if err != nil {
+			return nil, err
+		}
+		for nodePoolName := range conflictNodePoolNames {
+			if nodePoolNames[nodePoolName] {
+				conflict.SharedNodePools = append(conflict.SharedNodePools, nodePoolName)
+			}
+		}
+		sort.Strings(conflict.SharedNodePools)
+
+		scheduledHPAConfigs, err := e.scheduledHPAConfigRepository.ListScheduledHPAConfigByEventID(
+			tx,
+			event.ID.GetUUID(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, scheduledHPAConfig := range scheduledHPAConfigs {
+			hpaConfig, err := newEventModifiedHPAConfigData(scheduledHPAConfig)
+			if err != nil {
+				return nil, err
+			}
+			if hpaConfigKeys[getHPAConfigKey(*hpaConfig)] {
+				conflict.HPAConfigs = append(conflict.HPAConfigs, *hpaConfig)
+			}
+		}
+
+		if len(conflict.HPAConfigs) > 0 || len(conflict.SharedNodePools) > 0 {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// MergeConflictingHPAConfigs raises the shared HPAs of the saved event to the highest replicas
+// among the conflicting events, the conflicting events are left untouched
+func (e *event) MergeConflictingHPAConfigs(
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+	conflicts []UCEntity.EventConflict,
+) ([]UCEntity.EventModifiedHPAConfigData, error) {
+	type replicas struct {
+		min *int32
+		max int32
+	}
+	mergedReplicas := map[string]*replicas{}
+	takeMax := func(hpaConfig UCEntity.EventModifiedHPAConfigData) {
+		key := getHPAConfigKey(hpaConfig)
+		merged, ok := mergedReplicas[key]
+		if !ok {
+			mergedReplicas[key] = &replicas{min: hpaConfig.MinReplicas, max: hpaConfig.MaxReplicas}
+			return
+		}
+		if hpaConfig.MaxReplicas > merged.max {
+			merged.max = hpaConfig.MaxReplicas
+		}
+		if hpaConfig.MinReplicas != nil && (merged.min == nil || *hpaConfig.MinReplicas > *merged.min) {
+			merged.min = hpaConfig.MinReplicas
+		}
+	}
+	for _, hpaConfig := range hpaConfigs {
+		takeMax(hpaConfig)
+	}
+	for _, conflict := range conflicts {
+		for _, hpaConfig := range conflict.HPAConfigs {
+			takeMax(hpaConfig)
+		}
+	}
+
+	var mergedHPAConfigs []UCEntity.EventModifiedHPAConfigData
+	for _, hpaConfig := range hpaConfigs {
+		merged := mergedReplicas[getHPAConfigKey(hpaConfig)]
+		hpaConfig.MinReplicas = merged.min
+		hpaConfig.MaxReplicas = merged.max
+		mergedHPAConfigs = append(mergedHPAConfigs, hpaConfig)
+	}
+
+	return mergedHPAConfigs, nil
+}
@@ -0,0 +1,1155 @@
+package useCase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+	v1Apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/autoscaling/v2beta2"
+	v1Core "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+	"sync"
+)
+
+// nodePoolSource is a provider node pool taking part in the event plan calculation.
+// maxPodsPerNode falls back to the node allocatable pods when it is zero.
+// taints replaces the taints read from the pool node when it is not nil.
+// getNodeTemplate describes a node of the pool when the pool has no node yet.
+type nodePoolSource struct {
+	plan            *UCEntity.NodePoolPlan
+	maxPodsPerNode  int64
+	taints          []v1Core.Taint
+	getNodes        func(ctx context.Context) (*UCEntity.K8sNodeListData, error)
+	getNodeTemplate func(ctx context.Context) (*v1Core.Node, error)
+}
+
+// getNodePoolMaxNode returns the maximum node count of the whole node pool
+func getNodePoolMaxNode(plan *UCEntity.NodePoolPlan) int32 {
+	if plan.PerZoneMaxNode && len(plan.Zones) > 0 {
+		return plan.CurrentMaxNode * int32(len(plan.Zones))
+	}
+	return plan.CurrentMaxNode
+}
+
+// planGroupError drops the error of a goroutine cancelled by another failure of its errgroup,
+// other errors are logged with the error message as the last format argument
+func planGroupError(ctxEg context.Context, err error, format string, args ...interface{}) error {
+	if ctxEg.Err() != nil {
+		return nil
+	}
+	log.Errorf(format, append(args, err.Error())...)
+	return err
+}
+
+type eventPlanner struct {
+	clusterUC                 Cluster
+	scheduledHPAConfigUC      ScheduledHPAConfig
+	scheduledWorkloadConfigUC ScheduledWorkloadConfig
+}
+
+func (p *eventPlanner) matchNodePools(
+	podSpec v1Core.PodSpec,
+	nodePoolsMaxResources map[string]*UCEntity.NodePoolResourceData,
+) ([]string, error) {
+	nodeSelector := labels.Set(podSpec.NodeSelector).AsSelector()
+	var nodeAffinity *v1Core.NodeAffinity
+	if podSpec.Affinity != nil {
+		if podSpec.Affinity.NodeAffinity != nil {
+			nodeAffinity = podSpec.Affinity.NodeAffinity
+		}
+	}
+
+	var matchedNodePools []string
+	for nodePoolName, nodePoolResourceData := range nodePoolsMaxResources {
+		nodePoolMatch, err := util.CheckPodNodePoolMatch(
+			nodePoolResourceData.NodeLabels,
+			nodePoolResourceData.NodeTaints,
+			nodeAffinity,
+			nodeSelector,
+			podSpec.Tolerations,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if nodePoolMatch {
+			matchedNodePools = append(matchedNodePools, nodePoolName)
+		}
+	}
+	return matchedNodePools, nil
+}
+
+func (p *eventPlanner) selectEventHPAs(
+	ctx context.Context,
+	tx *gorm.DB,
+	kubernetesClient kubernetes.Interface,
+	clusterData *UCEntity.ClusterData,
+	e *UCEntity.Event,
+) (*UCEntity.EventPlan, []interface{}, error) {
+	plan := &UCEntity.EventPlan{}
+
+	modifiedHPAs, err := p.scheduledHPAConfigUC.ListScheduledHPAConfigByEventID(tx, e.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check HPA
+	log.Infof("[EventPlan] Event : %s, Checking HPAs", e.Name)
+	existingK8sHPA, err := p.clusterUC.GetAllK8sHPAObjectInCluster(
+		ctx,
+		kubernetesClient,
+		clusterData.ID,
+		clusterData.LatestHPAAPIVersion,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Search selected and unselected hpa
+	var unselectedK8sHPAs []interface{}
+	var selectedK8sHPANames []string
+	var unselectedK8sHPANames []string
+	modifiedHPAMap := map[string]*UCEntity.EventModifiedHPAConfigData{}
+	for _, modifiedHPA := range modifiedHPAs {
+		key := fmt.Sprintf(
+			constant.NameNSKeyFormat,
+			util.GetHPAObjectName(modifiedHPA.Kind, modifiedHPA.Name),
+			modifiedHPA.Namespace,
+		)
+		modifiedHPAMap[key] = modifiedHPA
+	}
+
+	for _, data := range existingK8sHPA {
+		var name, namespace string
+		var deepCopy interface{}
+		var minReplicas *int32
+		var maxReplicas int32
+		var objectLabels map[string]string
+		switch h := data.HPAObject.(type) {
+		case v1.HorizontalPodAutoscaler:
+			name = h.Name
+			objectLabels = h.Labels
+			namespace = h.Namespace
+			minReplicas = h.Spec.MinReplicas
+			maxReplicas = h.Spec.MaxReplicas
+			deepCopy = h.DeepCopy()
+		case v2beta1.HorizontalPodAutoscaler:
+			name = h.Name
+			objectLabels = h.Labels
+			namespace = h.Namespace
+			minReplicas = h.Spec.MinReplicas
+			maxReplicas = h.Spec.MaxReplicas
+			deepCopy = h.DeepCopy()
+		case v2beta2.HorizontalPodAutoscaler:
+			name = h.Name
+			objectLabels = h.Labels
+			namespace = h.Namespace
+			minReplicas = h.Spec.MinReplicas
+			maxReplicas = h.Spec.MaxReplicas
+			deepCopy = h.DeepCopy()
+		case v2.HorizontalPodAutoscaler:
+			name = h.Name
+			objectLabels = h.Labels
+			namespace = h.Namespace
+			minReplicas = h.Spec.MinReplicas
+			maxReplicas = h.Spec.MaxReplicas
+			deepCopy = h.DeepCopy()
+		case UCEntity.ScaledObject:
+			name = util.GetHPAObjectName(constant.ScaledObject, h.Name)
+			namespace = h.Namespace
+			minReplicas = h.MinReplicaCount
+			maxReplicas = h.MaxReplicaCount
+			deepCopy = &h
+		default:
+			continue
+		}
+		// HPAs managed by KEDA are covered by their ScaledObject
+		if _, ok := objectLabels[constant.KedaScaledObjectLabel]; ok {
+			continue
+		}
+		key := fmt.Sprintf(constant.NameNSKeyFormat, name, namespace)
+		modifiedHPA, ok := modifiedHPAMap[key]
+		if ok && modifiedHPA != nil {
+			behavior, metrics, err := p.clusterUC.GetHPABehaviorAndMetrics(deepCopy)
+			if err != nil {
+				return nil, nil, err
+			}
+			plan.SelectedHPAs = append(
+				plan.SelectedHPAs, &UCEntity.HPAPlan{
+					ModifiedHPAConfig:  modifiedHPA,
+					HPAObject:          deepCopy,
+					CurrentMinReplicas: minReplicas,
+					CurrentMaxReplicas: maxReplicas,
+					CurrentBehavior:    behavior,
+					CurrentMetrics:     metrics,
+				},
+			)
+			selectedK8sHPANames = append(selectedK8sHPANames, key)
+			delete(modifiedHPAMap, key)
+			continue
+		}
+		unselectedK8sHPAs = append(unselectedK8sHPAs, deepCopy)
+		unselectedK8sHPANames = append(unselectedK8sHPANames, key)
+	}
+
+	for _, modifiedHPA := range modifiedHPAMap {
+		plan.MissingHPAs = append(plan.MissingHPAs, modifiedHPA)
+	}
+
+	err = p.selectEventWorkloads(ctx, tx, kubernetesClient, e, plan)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(plan.SelectedHPAs) == 0 && len(plan.SelectedWorkloads) == 0 {
+		return plan, nil, nil
+	}
+
+	log.Infof(
+		"[EventPlan] Event : %s, Selected HPAs:\n%s\nUnselected HPAs:\n%s",
+		e.Name,
+		strings.Join(selectedK8sHPANames, "\n"),
+		strings.Join(unselectedK8sHPANames, "\n"),
+	)
+
+	return plan, unselectedK8sHPAs, nil
+}
+
+func (p *eventPlanner) selectEventWorkloads(
+	ctx context.Context,
+	tx *gorm.DB,
+	kubernetesClient kubernetes.Interface,
+	e *UCEntity.Event,
+	plan *UCEntity.EventPlan,
+) error {
+	modifiedWorkloads, err := p.scheduledWorkloadConfigUC.ListScheduledWorkloadConfigByEventID(
+		tx,
+		e.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(modifiedWorkloads) == 0 {
+		return nil
+	}
+
+	// Check Workloads
+	log.Infof("[EventPlan] Event : %s, Checking workloads", e.Name)
+	var selectedWorkloadNames []string
+	for _, modifiedWorkload := range modifiedWorkloads {
+		currentReplicas, err := p.clusterUC.GetWorkloadReplicas(
+			ctx,
+			kubernetesClient,
+			modifiedWorkload.Kind,
+			modifiedWorkload.Name,
+			modifiedWorkload.Namespace,
+		)
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				plan.MissingWorkloads = append(plan.MissingWorkloads, modifiedWorkload)
+				continue
+			}
+			return err
+		}
+		plan.SelectedWorkloads = append(
+			plan.SelectedWorkloads, &UCEntity.WorkloadPlan{
+				ModifiedWorkloadConfig: modifiedWorkload,
+				CurrentReplicas:        currentReplicas,
+			},
+		)
+		selectedWorkloadNames = append(
+			selectedWorkloadNames,
+			fmt.Sprintf(
+				constant.NameNSKeyFormat,
+				fmt.Sprintf("%s/%s", modifiedWorkload.Kind, modifiedWorkload.Name),
+				modifiedWorkload.Namespace,
+			),
+		)
+	}
+
+	log.Infof(
+		"[EventPlan] Event : %s, Selected workloads:\n%s",
+		e.Name,
+		strings.Join(selectedWorkloadNames, "\n"),
+	)
+
+	return nil
+}
+
+func (p *eventPlanner) calculateNodePools(
+	ctx context.Context,
+	kubernetesClient kubernetes.Interface,
+	e *UCEntity.Event,
+	plan *UCEntity.EventPlan,
+	unselectedK8sHPAs []interface{},
+	nodePools []*nodePoolSource,
+) error {
+	// Get Linux Daemonsets and Calculate Required Resources
+	var daemonSetsDataList []*UCEntity.DaemonSetData
+	if e.CalculateNodePool {
+		log.Infof("[EventPlan] Event : %s, Calculate daemonsets resources", e.Name)
+		daemonSetsData, err := p.clusterUC.GetAllDaemonSetsInNamespace(
+			ctx,
+			kubernetesClient,
+			"",
+		)
+		if err != nil {
+			return err
+		}
+		daemonSets := daemonSetsData.DaemonSetListObject
+
+		for _, daemonSet := range daemonSets.Items {
+			spec := daemonSet.Spec.Template.Spec
+			totalRequestedMemory := float64(0)
+			totalRequestedCPU := float64(0)
+			for _, containerData := range spec.Containers {
+				totalRequestedMemory += containerData.Resources.Requests.Memory().AsApproximateFloat64()
+				totalRequestedCPU += containerData.Resources.Requests.Cpu().AsApproximateFloat64()
+			}
+			var nodeAffinity *v1Core.NodeAffinity
+			if spec.Affinity != nil {
+				if spec.Affinity.NodeAffinity != nil {
+					nodeAffinity = spec.Affinity.NodeAffinity
+				}
+			}
+			daemonSetsDataList = append(
+				daemonSetsDataList, &UCEntity.DaemonSetData{
+					NodeSelector:    labels.Set(spec.NodeSelector).AsSelector(),
+					NodeAffinity:    nodeAffinity,
+					Tolerations:     spec.Tolerations,
+					RequestedMemory: totalRequestedMemory,
+					RequestedCPU:    totalRequestedCPU,
+					Name:            daemonSet.Name,
+					Namespace:       daemonSet.Namespace,
+				},
+			)
+			log.Infof(
+				"[EventPlan] Event : %s, Registering daemonset %s namespace %s, %f requested memory, %f requested CPU",
+				e.Name,
+				daemonSet.Name,
+				daemonSet.Namespace,
+				totalRequestedMemory,
+				totalRequestedCPU,
+			)
+		}
+	}
+
+	// Get Maximum Resources each Node Pools
+	log.Infof(
+		"[EventPlan] Event : %s, Calculate maximum available resources in node pools",
+		e.Name,
+	)
+	nodePoolsMaxResources := map[string]*UCEntity.NodePoolResourceData{}
+	nodePoolsRequestedResources := map[string]*UCEntity.NodePoolRequestedResourceData{}
+	nodePoolByNodeName := map[string]string{}
+	var nodePoolByNodeNameLock sync.Mutex
+	errGroup, ctxEg := errgroup.WithContext(ctx)
+	for _, nodePool := range nodePools {
+		nodePoolPlan := nodePool.plan
+		nodePoolPlan.NewMaxNode = nodePoolPlan.CurrentMaxNode
+
+		if e.CalculateNodePool {
+			nodePoolsRequestedResources[nodePoolPlan.NodePoolName] = &nodePoolPlan.RequestedResources
+			nodePoolsMaxResources[nodePoolPlan.NodePoolName] = &nodePoolPlan.AvailableResources
+
+			loadFunc := func(
+				nP *nodePoolSource,
+				rD *UCEntity.NodePoolResourceData,
+			) func() error {
+				return func() error {
+					nodePoolName := nP.plan.NodePoolName
+					nodePoolMaxNode := getNodePoolMaxNode(nP.plan)
+
+					// Fetch nodepool labels from existing node
+					nodeData, err := nP.getNodes(ctxEg)
+					if err != nil {
+						return planGroupError(
+							ctxEg,
+							err,
+							"[EventPlan] Event : %s, Node pool %s, Error : %s",
+							e.Name,
+							nodePoolName,
+						)
+					}
+					nodes := nodeData.NodeListObject
+					nodePoolByNodeNameLock.Lock()
+					for _, nodeItem := range nodes.Items {
+						nodePoolByNodeName[nodeItem.Name] = nodePoolName
+					}
+					nodePoolByNodeNameLock.Unlock()
+					var node v1Core.Node
+					switch {
+					case len(nodes.Items) > 0:
+						node = nodes.Items[0]
+					case nP.getNodeTemplate != nil:
+						nodeTemplate, err := nP.getNodeTemplate(ctxEg)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Node pool %s, Error : %s",
+								e.Name,
+								nodePoolName,
+							)
+						}
+						node = *nodeTemplate
+					default:
+						log.Errorf(
+							"[EventPlan] Event : %s, Node pool %s, Error : %s",
+							e.Name,
+							nodePoolName,
+							errorConstant.NoExistingNode,
+						)
+						return errors.New(errorConstant.NoExistingNode)
+					}
+					availablePods := nP.maxPodsPerNode
+					if availablePods == 0 {
+						availablePods = node.Status.Allocatable.Pods().Value()
+					}
+					rD.NodeLabels = node.Labels
+					rD.NodeTaints = nP.taints
+					if rD.NodeTaints == nil {
+						rD.NodeTaints = util.GetNodePoolTaints(node.Spec.Taints)
+					}
+					totalMatchesDaemonSet := int64(0)
+					totalDaemonSetsRequestedCPU := float64(0)
+					totalDaemonSetsRequestedMemory := float64(0)
+					var matchesDaemonSet []string
+					for _, daemonSet := range daemonSetsDataList {
+						nodePoolMatch, err := util.CheckPodNodePoolMatch(
+							rD.NodeLabels,
+							rD.NodeTaints,
+							daemonSet.NodeAffinity,
+							daemonSet.NodeSelector,
+							daemonSet.Tolerations,
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Node pool %s, Error : %s",
+								e.Name,
+								nodePoolName,
+							)
+						}
+						if nodePoolMatch {
+							totalDaemonSetsRequestedCPU += daemonSet.RequestedCPU
+							totalDaemonSetsRequestedMemory += daemonSet.RequestedMemory
+							totalMatchesDaemonSet += 1
+							matchesDaemonSet = append(
+								matchesDaemonSet,
+								fmt.Sprintf(
+									constant.NameNSKeyFormat,
+									daemonSet.Name,
+									daemonSet.Namespace,
+								),
+							)
+						}
+					}
+
+					log.Infof(
+						"[EventPlan] Event : %s, Node pool %s, %d matches daemonset with %f requested memory and %f requested cpu\nDaemonset list :\n%s",
+						e.Name,
+						nodePoolName,
+						totalMatchesDaemonSet,
+						totalDaemonSetsRequestedMemory,
+						totalDaemonSetsRequestedCPU,
+						strings.Join(matchesDaemonSet, "\n"),
+					)
+
+					rD.AvailablePods = availablePods - totalMatchesDaemonSet
+					rD.MaxAvailablePods = availablePods * int64(nodePoolMaxNode)
+					allocatableCPU := node.Status.Allocatable.Cpu().AsApproximateFloat64()
+					allocatableMemory := node.Status.Allocatable.Memory().AsApproximateFloat64()
+					availableCPU := allocatableCPU - totalDaemonSetsRequestedCPU
+					availableMemory := allocatableMemory - totalDaemonSetsRequestedMemory
+					rD.AvailableCPU = availableCPU
+					rD.AvailableMemory = availableMemory
+					rD.MaxAvailableCPU = availableCPU * float64(nodePoolMaxNode)
+					rD.MaxAvailableMemory = availableMemory * float64(nodePoolMaxNode)
+					rD.CurrentNodeCount = len(nodes.Items)
+
+					log.Infof(
+						"[EventPlan] Event : %s, Node pool %s has maximum %d available pods, maximum %f available cpu and maximum %f available memory",
+						e.Name,
+						nodePoolName,
+						rD.MaxAvailablePods,
+						rD.MaxAvailableCPU,
+						rD.MaxAvailableMemory,
+					)
+
+					return nil
+				}
+			}
+			errGroup.Go(loadFunc(nodePool, &nodePoolPlan.AvailableResources))
+		}
+	}
+
+	if err := errGroup.Wait(); err != nil {
+		return err
+	}
+
+	// Calculate Required Resource
+	var nodePoolRequestedResourceLock sync.Mutex
+	var deploymentsMap map[string]v1Apps.Deployment
+	var distributor *nodePoolDemandDistributor
+	errGroup, ctxEg = errgroup.WithContext(ctx)
+	if e.CalculateNodePool {
+		log.Infof("[EventPlan] Event : %s, Calculate required resources", e.Name)
+
+		deploymentsMap = map[string]v1Apps.Deployment{}
+		var deploymentNames []string
+
+		log.Infof("[EventPlan] Event : %s, Fetching deployments", e.Name)
+		deploymentsData, err := p.clusterUC.GetAllDeployments(ctxEg, kubernetesClient, "")
+		if err != nil {
+			return err
+		}
+
+		deployments := deploymentsData.DeploymentListObject
+		for _, deployment := range deployments.Items {
+			key := fmt.Sprintf(constant.NameNSKeyFormat, deployment.Name, deployment.Namespace)
+			deploymentNames = append(deploymentNames, key)
+			deploymentsMap[key] = deployment
+		}
+		log.Infof(
+			"[EventPlan] Event : %s, Found deployments:\n%s",
+			e.Name,
+			strings.Join(deploymentNames, "\n"),
+		)
+
+		// Preferred affinity weights are read from the pod spec, other strategies need the current placement
+		var pods *v1Core.PodList
+		if e.NodePoolDistribution != model.NodePoolDistributionPreferredAffinity {
+			log.Infof("[EventPlan] Event : %s, Fetching pods placement", e.Name)
+			podsData, err := p.clusterUC.GetAllPods(ctxEg, kubernetesClient, "")
+			if err != nil {
+				return err
+			}
+			pods = podsData.PodListObject
+		}
+		distributor = newNodePoolDemandDistributor(e, nodePoolsMaxResources, nodePoolByNodeName, pods)
+		log.Infof(
+			"[EventPlan] Event : %s, Distribute workload demand between node pools with %s strategy",
+			e.Name,
+			distributor.strategy,
+		)
+	}
+
+	log.Infof("[EventPlan] Event : %s, Calculate selected HPA", e.Name)
+	// Calculate Selected HPA
+	for _, selectedHPA := range plan.SelectedHPAs {
+		errGroup.Go(
+			func(hpaPlan *UCEntity.HPAPlan) func() error {
+				return func() error {
+					requestedModification := hpaPlan.ModifiedHPAConfig
+					var scaleTargetRef interface{}
+					name := requestedModification.Name
+					namespace := requestedModification.Namespace
+					maxReplicas := requestedModification.MaxReplicas
+
+					// Ramped HPAs start at their first step, node pools are still sized for the target
+					appliedMinReplicas := requestedModification.MinReplicas
+					appliedMaxReplicas := maxReplicas
+					if len(requestedModification.RampUpSteps) > 0 {
+						appliedMinReplicas = requestedModification.RampUpSteps[0].MinReplicas
+						appliedMaxReplicas = requestedModification.RampUpSteps[0].MaxReplicas
+					}
+					hpaPlan.AppliedMinReplicas = appliedMinReplicas
+					hpaPlan.AppliedMaxReplicas = appliedMaxReplicas
+
+					// Modify HPA, Get Target Ref and Namespace
+					switch h := hpaPlan.HPAObject.(type) {
+					case *v1.HorizontalPodAutoscaler:
+						h.Spec.MinReplicas = appliedMinReplicas
+						h.Spec.MaxReplicas = appliedMaxReplicas
+						scaleTargetRef = h.Spec.ScaleTargetRef
+					case *v2beta1.HorizontalPodAutoscaler:
+						h.Spec.MinReplicas = appliedMinReplicas
+						h.Spec.MaxReplicas = appliedMaxReplicas
+						scaleTargetRef = h.Spec.ScaleTargetRef
+					case *v2beta2.HorizontalPodAutoscaler:
+						h.Spec.MinReplicas = appliedMinReplicas
+						h.Spec.MaxReplicas = appliedMaxReplicas
+						scaleTargetRef = h.Spec.ScaleTargetRef
+					case *v2.HorizontalPodAutoscaler:
+						h.Spec.MinReplicas = appliedMinReplicas
+						h.Spec.MaxReplicas = appliedMaxReplicas
+						scaleTargetRef = h.Spec.ScaleTargetRef
+					case *UCEntity.ScaledObject:
+						h.MinReplicaCount = appliedMinReplicas
+						h.MaxReplicaCount = appliedMaxReplicas
+						scaleTargetRef = h.ScaleTargetRef
+					default:
+						return errors.New(errorConstant.HPAVersionUnknown)
+					}
+
+					err := p.clusterUC.OverrideHPABehaviorAndMetrics(hpaPlan.HPAObject, requestedModification)
+					if err != nil {
+						return err
+					}
+
+					if e.CalculateNodePool {
+						// Resolve Target Ref to Get Pods
+						resolveRes, err := p.clusterUC.ResolveScaleTargetRefByDeploymentsMap(
+							ctxEg,
+							kubernetesClient,
+							scaleTargetRef,
+							namespace,
+							deploymentsMap,
+							true,
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Selected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						// Calculate Requested Resource
+						var maxRequestedCPU, maxRequestedMemory float64
+						totalCpuRequested := float64(0)
+						totalMemoryRequested := float64(0)
+						containers := resolveRes.PodTemplate.Spec.Containers
+						for _, containerSpec := range containers {
+							totalCpuRequested += containerSpec.Resources.Requests.Cpu().AsApproximateFloat64()
+							totalMemoryRequested += containerSpec.Resources.Requests.Memory().AsApproximateFloat64()
+						}
+						maxRequestedCPU = totalCpuRequested * float64(maxReplicas)
+						maxRequestedMemory = totalMemoryRequested * float64(maxReplicas)
+
+						//Resolve node selector and Find all node pools
+						selectedNodePools, err := p.matchNodePools(
+							resolveRes.PodTemplate.Spec,
+							nodePoolsMaxResources,
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Selected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						podsPerNodePool, err := distributor.distribute(
+							resolveRes.PodTemplate,
+							namespace,
+							selectedNodePools,
+							int64(maxReplicas),
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Selected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						// Calculate & Save requested resource data
+						nodePoolRequestedResourceLock.Lock()
+						defer nodePoolRequestedResourceLock.Unlock()
+
+						addNodePoolsDemand(
+							nodePoolsRequestedResources,
+							podsPerNodePool,
+							totalCpuRequested,
+							totalMemoryRequested,
+							util.IsPodSpreadAcrossZones(resolveRes.PodTemplate.Spec),
+						)
+						hpaPlan.NodePools = selectedNodePools
+
+						log.Infof(
+							"[EventPlan] Event : %s, Selected HPA %s namespace %s, maximum %d pods, maximum %f requested memory, maximum %f requested cpu\nNode pools:\n%s",
+							e.Name,
+							name,
+							namespace,
+							maxReplicas,
+							maxRequestedMemory,
+							maxRequestedCPU,
+							strings.Join(selectedNodePools, "\n"),
+						)
+						return nil
+					}
+
+					log.Infof(
+						"[EventPlan] Event : %s, Selected HPA %s namespace %s, maximum %d pods",
+						e.Name,
+						name,
+						namespace,
+						maxReplicas,
+					)
+
+					return nil
+				}
+			}(selectedHPA),
+		)
+	}
+
+	log.Infof("[EventPlan] Event : %s, Calculate selected workloads", e.Name)
+	// Calculate Selected Workloads
+	for _, selectedWorkload := range plan.SelectedWorkloads {
+		errGroup.Go(
+			func(workloadPlan *UCEntity.WorkloadPlan) func() error {
+				return func() error {
+					requestedModification := workloadPlan.ModifiedWorkloadConfig
+					kind := requestedModification.Kind
+					name := requestedModification.Name
+					namespace := requestedModification.Namespace
+					replicas := requestedModification.Replicas
+
+					if !e.CalculateNodePool {
+						log.Infof(
+							"[EventPlan] Event : %s, Selected %s %s namespace %s, %d pods",
+							e.Name,
+							kind,
+							name,
+							namespace,
+							replicas,
+						)
+						return nil
+					}
+
+					// Resolve Workload to Get Pods
+					resolveRes, err := p.clusterUC.ResolveScaleTargetRefByDeploymentsMap(
+						ctxEg,
+						kubernetesClient,
+						v1.CrossVersionObjectReference{
+							Kind:       kind,
+							Name:       name,
+							APIVersion: constant.AppsV1,
+						},
+						namespace,
+						deploymentsMap,
+						true,
+					)
+					if err != nil {
+						return planGroupError(
+							ctxEg,
+							err,
+							"[EventPlan] Event : %s, Selected %s %s Namespace %s, Error : %s",
+							e.Name,
+							kind,
+							name,
+							namespace,
+						)
+					}
+
+					// Calculate Requested Resource
+					var maxRequestedCPU, maxRequestedMemory float64
+					totalCpuRequested := float64(0)
+					totalMemoryRequested := float64(0)
+					containers := resolveRes.PodTemplate.Spec.Containers
+					for _, containerSpec := range containers {
+						totalCpuRequested += containerSpec.Resources.Requests.Cpu().AsApproximateFloat64()
+						totalMemoryRequested += containerSpec.Resources.Requests.Memory().AsApproximateFloat64()
+					}
+					maxRequestedCPU = totalCpuRequested * float64(replicas)
+					maxRequestedMemory = totalMemoryRequested * float64(replicas)
+
+					//Resolve node selector and Find all node pools
+					selectedNodePools, err := p.matchNodePools(
+						resolveRes.PodTemplate.Spec,
+						nodePoolsMaxResources,
+					)
+					if err != nil {
+						return planGroupError(
+							ctxEg,
+							err,
+							"[EventPlan] Event : %s, Selected %s %s Namespace %s, Error : %s",
+							e.Name,
+							kind,
+							name,
+							namespace,
+						)
+					}
+
+					podsPerNodePool, err := distributor.distribute(
+						resolveRes.PodTemplate,
+						namespace,
+						selectedNodePools,
+						int64(replicas),
+					)
+					if err != nil {
+						return planGroupError(
+							ctxEg,
+							err,
+							"[EventPlan] Event : %s, Selected %s %s Namespace %s, Error : %s",
+							e.Name,
+							kind,
+							name,
+							namespace,
+						)
+					}
+
+					// Calculate & Save requested resource data
+					nodePoolRequestedResourceLock.Lock()
+					defer nodePoolRequestedResourceLock.Unlock()
+
+					addNodePoolsDemand(
+						nodePoolsRequestedResources,
+						podsPerNodePool,
+						totalCpuRequested,
+						totalMemoryRequested,
+						util.IsPodSpreadAcrossZones(resolveRes.PodTemplate.Spec),
+					)
+					workloadPlan.NodePools = selectedNodePools
+
+					log.Infof(
+						"[EventPlan] Event : %s, Selected %s %s namespace %s, %d pods, maximum %f requested memory, maximum %f requested cpu\nNode pools:\n%s",
+						e.Name,
+						kind,
+						name,
+						namespace,
+						replicas,
+						maxRequestedMemory,
+						maxRequestedCPU,
+						strings.Join(selectedNodePools, "\n"),
+					)
+					return nil
+				}
+			}(selectedWorkload),
+		)
+	}
+
+	if e.CalculateNodePool {
+		// Calculate Unselected HPA
+		for idx, unselectedHPA := range unselectedK8sHPAs {
+			errGroup.Go(
+				func(i int, hpa interface{}) func() error {
+					return func() error {
+						var scaleTargetRef interface{}
+						var namespace, name string
+						var maxReplicas int32
+
+						// Get Target Ref and Namespace
+						switch h := hpa.(type) {
+						case *v1.HorizontalPodAutoscaler:
+							scaleTargetRef = h.Spec.ScaleTargetRef
+							namespace = h.Namespace
+							name = h.Name
+							maxReplicas = h.Spec.MaxReplicas
+						case *v2beta1.HorizontalPodAutoscaler:
+							scaleTargetRef = h.Spec.ScaleTargetRef
+							namespace = h.Namespace
+							name = h.Name
+							maxReplicas = h.Spec.MaxReplicas
+						case *v2beta2.HorizontalPodAutoscaler:
+							scaleTargetRef = h.Spec.ScaleTargetRef
+							namespace = h.Namespace
+							name = h.Name
+							maxReplicas = h.Spec.MaxReplicas
+						case *v2.HorizontalPodAutoscaler:
+							scaleTargetRef = h.Spec.ScaleTargetRef
+							namespace = h.Namespace
+							name = h.Name
+							maxReplicas = h.Spec.MaxReplicas
+						case *UCEntity.ScaledObject:
+							scaleTargetRef = h.ScaleTargetRef
+							namespace = h.Namespace
+							name = util.GetHPAObjectName(constant.ScaledObject, h.Name)
+							maxReplicas = h.MaxReplicaCount
+						default:
+							return errors.New(errorConstant.HPAVersionUnknown)
+						}
+
+						// Resolve Target Ref to Get Pods
+						resolveRes, err := p.clusterUC.ResolveScaleTargetRefByDeploymentsMap(
+							ctxEg,
+							kubernetesClient,
+							scaleTargetRef,
+							namespace,
+							deploymentsMap,
+							true,
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Unselected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						// Calculate Requested Resource
+						var maxRequestedCPU, maxRequestedMemory float64
+						totalCpuRequested := float64(0)
+						totalMemoryRequested := float64(0)
+						containers := resolveRes.PodTemplate.Spec.Containers
+						for _, containerSpec := range containers {
+							totalCpuRequested += containerSpec.Resources.Requests.Cpu().AsApproximateFloat64()
+							totalMemoryRequested += containerSpec.Resources.Requests.Memory().AsApproximateFloat64()
+						}
+						maxRequestedCPU = totalCpuRequested * float64(maxReplicas)
+						maxRequestedMemory = totalMemoryRequested * float64(maxReplicas)
+
+						//Resolve node selector and Find all node pools
+						selectedNodePools, err := p.matchNodePools(
+							resolveRes.PodTemplate.Spec,
+							nodePoolsMaxResources,
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Unselected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						podsPerNodePool, err := distributor.distribute(
+							resolveRes.PodTemplate,
+							namespace,
+							selectedNodePools,
+							int64(maxReplicas),
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Unselected HPA %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						// Calculate & Save requested resource data
+						nodePoolRequestedResourceLock.Lock()
+						defer nodePoolRequestedResourceLock.Unlock()
+
+						addNodePoolsDemand(
+							nodePoolsRequestedResources,
+							podsPerNodePool,
+							totalCpuRequested,
+							totalMemoryRequested,
+							util.IsPodSpreadAcrossZones(resolveRes.PodTemplate.Spec),
+						)
+
+						log.Infof(
+							"[EventPlan] Event : %s, Unselected HPA %s namespace %s, maximum %d pods, maximum %f requested memory, maximum %f requested cpu\nNode pools:\n%s",
+							e.Name,
+							name,
+							namespace,
+							maxReplicas,
+							maxRequestedMemory,
+							maxRequestedCPU,
+							strings.Join(selectedNodePools, "\n"),
+						)
+
+						return nil
+					}
+				}(idx, unselectedHPA),
+			)
+		}
+	}
+
+	// Wait for every HPA to resolve its deployment before counting remaining deployments
+	if err := errGroup.Wait(); err != nil {
+		return err
+	}
+
+	if e.CalculateNodePool {
+		errGroup, ctxEg = errgroup.WithContext(ctx)
+
+		// Calculate remaining deployment
+		for _, deployment := range deploymentsMap {
+			errGroup.Go(
+				func(d v1Apps.Deployment) func() error {
+					return func() error {
+						name := d.Name
+						namespace := d.Namespace
+						podCounts := d.Spec.Replicas
+						if podCounts == nil {
+							podCounts = &constant.MinimumPod
+						}
+
+						// Calculate Requested Resource
+						var maxRequestedCPU, maxRequestedMemory float64
+						totalCpuRequested := float64(0)
+						totalMemoryRequested := float64(0)
+						containers := d.Spec.Template.Spec.Containers
+						for _, containerSpec := range containers {
+							totalCpuRequested += containerSpec.Resources.Requests.Cpu().AsApproximateFloat64()
+							totalMemoryRequested += containerSpec.Resources.Requests.Memory().AsApproximateFloat64()
+						}
+						maxRequestedCPU = totalCpuRequested * float64(*podCounts)
+						maxRequestedMemory = totalMemoryRequested * float64(*podCounts)
+
+						//Resolve node selector and Find all node pools
+						selectedNodePools, err := p.matchNodePools(
+							d.Spec.Template.Spec,
+							nodePoolsMaxResources,
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Deployment %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						podsPerNodePool, err := distributor.distribute(
+							d.Spec.Template,
+							namespace,
+							selectedNodePools,
+							int64(*podCounts),
+						)
+						if err != nil {
+							return planGroupError(
+								ctxEg,
+								err,
+								"[EventPlan] Event : %s, Deployment %s Namespace %s, Error : %s",
+								e.Name,
+								name,
+								namespace,
+							)
+						}
+
+						// Calculate & Save requested resource data
+						nodePoolRequestedResourceLock.Lock()
+						defer nodePoolRequestedResourceLock.Unlock()
+
+						addNodePoolsDemand(
+							nodePoolsRequestedResources,
+							podsPerNodePool,
+							totalCpuRequested,
+							totalMemoryRequested,
+							util.IsPodSpreadAcrossZones(d.Spec.Template.Spec),
+						)
+
+						log.Infof(
+							"[EventPlan] Event : %s, Deployment %s namespace %s, %d pods, maximum %f requested memory, maximum %f requested cpu\nNode pools:\n%s",
+							e.Name,
+							name,
+							namespace,
+							*podCounts,
+							maxRequestedMemory,
+							maxRequestedCPU,
+							strings.Join(selectedNodePools, "\n"),
+						)
+
+						return nil
+					}
+				}(deployment),
+			)
+		}
+
+		if err := errGroup.Wait(); err != nil {
+			return err
+		}
+
+		// Calculate Requested Resource Each Node Pool
+		log.Infof(
+			"[EventPlan] Event : %s, Calculate needed pool based on requested resources",
+			e.Name,
+		)
+		for _, nodePool := range nodePools {
+			nodePoolPlan := nodePool.plan
+			reqResources := nodePoolPlan.RequestedResources
+			maxResources := nodePoolPlan.AvailableResources
+
+			log.Infof(
+				"[EventPlan] Event : %s, Node pool %s, %f requested cpu (%f max available cpu), %f requested memory (%f max available memory), %d requested pods (%d max available pods)",
+				e.Name,
+				nodePoolPlan.NodePoolName,
+				reqResources.MaxCPU,
+				maxResources.MaxAvailableCPU,
+				reqResources.MaxMemory,
+				maxResources.MaxAvailableMemory,
+				reqResources.MaxPods,
+				maxResources.MaxAvailablePods,
+			)
+
+			// Multi-zone pools are packed per zone when the max node count applies to each zone
+			// or when pods have to be balanced between the zones
+			var packingRes binPackingResult
+			requiredMaxNode := int32(0)
+			if len(nodePoolPlan.Zones) > 1 &&
+				(nodePoolPlan.PerZoneMaxNode || hasZoneSpreadPodGroup(reqResources.PodGroups)) {
+				zonePackingRes := simulateZoneFirstFitDecreasing(
+					maxResources,
+					reqResources.PodGroups,
+					nodePoolPlan.Zones,
+				)
+				packingRes = zonePackingRes.binPackingResult
+				nodePoolPlan.ZoneNodeCounts = zonePackingRes.zoneNodeCounts
+				requiredMaxNode = packingRes.nodeCount
+				if nodePoolPlan.PerZoneMaxNode {
+					requiredMaxNode = zonePackingRes.maxZoneNodeCount
+				}
+
+				var zoneNodeCounts []string
+				for _, zone := range nodePoolPlan.Zones {
+					zoneNodeCounts = append(
+						zoneNodeCounts,
+						fmt.Sprintf("%s : %d", zone, zonePackingRes.zoneNodeCounts[zone]),
+					)
+				}
+				log.Infof(
+					"[EventPlan] Event : %s, Node pool %s, requested pods packed per zone\n%s",
+					e.Name,
+					nodePoolPlan.NodePoolName,
+					strings.Join(zoneNodeCounts, "\n"),
+				)
+			} else {
+				packingRes = simulateFirstFitDecreasing(maxResources, reqResources.PodGroups)
+				requiredMaxNode = packingRes.nodeCount
+			}
+			nodePoolPlan.PackedNodeCount = packingRes.nodeCount
+			nodePoolPlan.PackingEfficiency = packingRes.packingEfficiency
+			nodePoolPlan.UnschedulablePods = packingRes.unschedulablePods
+
+			log.Infof(
+				"[EventPlan] Event : %s, Node pool %s, requested pods packed into %d node with %f packing efficiency",
+				e.Name,
+				nodePoolPlan.NodePoolName,
+				packingRes.nodeCount,
+				packingRes.packingEfficiency,
+			)
+			if packingRes.unschedulablePods > 0 {
+				log.Warnf(
+					"[EventPlan] Event : %s, Node pool %s, %d pods requested more resources than a single node has",
+					e.Name,
+					nodePoolPlan.NodePoolName,
+					packingRes.unschedulablePods,
+				)
+			}
+
+			nodePoolPlan.NeededNode = getNeededNode(nodePoolPlan.CurrentMaxNode, requiredMaxNode)
+			nodePoolPlan.NewMaxNode = nodePoolPlan.CurrentMaxNode + nodePoolPlan.NeededNode
+		}
+	}
+
+	return nil
+}
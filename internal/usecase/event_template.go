@@ -0,0 +1,118 @@
+package useCase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type EventTemplate interface {
+	RegisterEventTemplate(tx *gorm.DB, data *UCEntity.EventTemplate) (uuid.UUID, error)
+	GetEventTemplateByID(tx *gorm.DB, id uuid.UUID) (*UCEntity.EventTemplate, error)
+	ListEventTemplateByClusterID(tx *gorm.DB, clusterID uuid.UUID) ([]*UCEntity.EventTemplate, error)
+	DeleteEventTemplate(tx *gorm.DB, id uuid.UUID) error
+}
+
+type eventTemplate struct {
+	eventTemplateRepo repository.EventTemplate
+}
+
+func newEventTemplate(eventTemplateRepo repository.EventTemplate) EventTemplate {
+	return &eventTemplate{eventTemplateRepo: eventTemplateRepo}
+}
+
+func newEventTemplateData(data *model.EventTemplate) (*UCEntity.EventTemplate, error) {
+	eventTemplateData := &UCEntity.EventTemplate{
+		CreatedAt:         data.CreatedAt,
+		UpdatedAt:         data.UpdatedAt,
+		ID:                data.ID.GetUUID(),
+		Name:              data.Name,
+		CalculateNodePool: data.CalculateNodePool,
+		ForceHPAApply:     data.ForceHPAApply,
+		ClusterID:         data.ClusterID.GetUUID(),
+	}
+	err := unmarshalModifiedConfigs(
+		data.ModifiedHPAConfigs,
+		data.ModifiedWorkloadConfigs,
+		&eventTemplateData.ModifiedHPAConfigs,
+		&eventTemplateData.ModifiedWorkloadConfigs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return eventTemplateData, nil
+}
+
+func (e *eventTemplate) RegisterEventTemplate(
+	tx *gorm.DB,
+	data *UCEntity.EventTemplate,
+) (uuid.UUID, error) {
+	_, err := e.eventTemplateRepo.GetEventTemplateByName(tx, data.ClusterID, data.Name)
+	if err == nil {
+		return uuid.UUID{}, fmt.Errorf(errorConstant.EventTemplateNameExist, data.Name)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.UUID{}, err
+	}
+
+	modelData := &model.EventTemplate{
+		Name:              data.Name,
+		CalculateNodePool: data.CalculateNodePool,
+		ForceHPAApply:     data.ForceHPAApply,
+	}
+	modelData.ClusterID.SetUUID(data.ClusterID)
+	err = marshalModifiedConfigs(
+		data.ModifiedHPAConfigs,
+		data.ModifiedWorkloadConfigs,
+		&modelData.ModifiedHPAConfigs,
+		&modelData.ModifiedWorkloadConfigs,
+	)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = e.eventTemplateRepo.InsertEventTemplate(tx, modelData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return modelData.ID.GetUUID(), nil
+}
+
+func (e *eventTemplate) GetEventTemplateByID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*UCEntity.EventTemplate, error) {
+	data, err := e.eventTemplateRepo.GetEventTemplateByID(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newEventTemplateData(data)
+}
+
+func (e *eventTemplate) ListEventTemplateByClusterID(
+	tx *gorm.DB,
+	clusterID uuid.UUID,
+) ([]*UCEntity.EventTemplate, error) {
+	data, err := e.eventTemplateRepo.ListEventTemplateByClusterID(tx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	var output []*UCEntity.EventTemplate
+	for _, datum := range data {
+		eventTemplateData, err := newEventTemplateData(datum)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, eventTemplateData)
+	}
+	return output, nil
+}
+
+func (e *eventTemplate) DeleteEventTemplate(tx *gorm.DB, id uuid.UUID) error {
+	return e.eventTemplateRepo.DeleteEventTemplate(tx, id)
+}
@@ -0,0 +1,64 @@
+package useCase
+
+import (
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"testing"
+)
+
+func int32Pointer(value int32) *int32 {
+	return &value
+}
+
+func TestMergeConflictingHPAConfigs(t *testing.T) {
+	hpaConfigs := []UCEntity.EventModifiedHPAConfigData{
+		{
+			Kind:        constant.HorizontalPodAutoscaler,
+			Name:        "api",
+			Namespace:   "default",
+			MinReplicas: int32Pointer(2),
+			MaxReplicas: 10,
+		},
+		{
+			Kind:        constant.HorizontalPodAutoscaler,
+			Name:        "worker",
+			Namespace:   "default",
+			MaxReplicas: 5,
+		},
+	}
+	conflictHPAConfig := UCEntity.EventModifiedHPAConfigData{
+		Kind:        constant.HorizontalPodAutoscaler,
+		Name:        "api",
+		Namespace:   "default",
+		MinReplicas: int32Pointer(4),
+		MaxReplicas: 8,
+	}
+	conflicts := []UCEntity.EventConflict{
+		{
+			Event:      UCEntity.Event{Status: model.EventPending},
+			HPAConfigs: []UCEntity.EventModifiedHPAConfigData{conflictHPAConfig},
+		},
+	}
+
+	merged, err := (&event{}).MergeConflictingHPAConfigs(hpaConfigs, conflicts)
+	if err != nil {
+		t.Fatalf("unexpected error : %s", err.Error())
+	}
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 HPA configs, got %d", len(merged))
+	}
+	if *merged[0].MinReplicas != 4 || merged[0].MaxReplicas != 10 {
+		t.Fatalf("expected api to be merged to 4-10, got %d-%d", *merged[0].MinReplicas, merged[0].MaxReplicas)
+	}
+	if merged[1].MinReplicas != nil || merged[1].MaxReplicas != 5 {
+		t.Fatalf("expected worker to be kept, got %v-%d", merged[1].MinReplicas, merged[1].MaxReplicas)
+	}
+	if *hpaConfigs[0].MinReplicas != 2 || hpaConfigs[0].MaxReplicas != 10 {
+		t.Fatalf("expected the given HPA configs to be kept")
+	}
+	kept := conflicts[0].HPAConfigs[0]
+	if *kept.MinReplicas != 4 || kept.MaxReplicas != 8 {
+		t.Fatalf("expected the conflicting event HPA configs to be kept, got %d-%d", *kept.MinReplicas, kept.MaxReplicas)
+	}
+}
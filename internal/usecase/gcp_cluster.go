@@ -53,6 +53,16 @@ type GCPCluster interface {
 		ctx context.Context,
 		googleCredential *google.Credentials,
 	) (*compute.InstanceTemplatesClient, error)
+	GetGoogleMachineTypesClient(
+		ctx context.Context,
+		googleCredential *google.Credentials,
+	) (*compute.MachineTypesClient, error)
+	GetGCPMachineTypeCapacity(
+		ctx context.Context,
+		tx *gorm.DB,
+		machineTypesClient *compute.MachineTypesClient,
+		project, zone, machineType string,
+	) (*UCEntity.MachineTypeCapacity, error)
 	GetGCPClusterObject(
 		ctx context.Context,
 		clusterClient *container.ClusterManagerClient,
@@ -76,11 +86,12 @@ type GCPCluster interface {
 }
 
 type gcpCluster struct {
-	validatorInst    *validator.Validate
-	clusterRepo      repository.Cluster
-	gcpClusterRepo   repository.GCPCluster
-	k8sDiscoveryRepo repository.K8SDiscovery
-	k8sNodeRepo      repository.K8sNode
+	validatorInst           *validator.Validate
+	clusterRepo             repository.Cluster
+	gcpClusterRepo          repository.GCPCluster
+	k8sDiscoveryRepo        repository.K8SDiscovery
+	k8sNodeRepo             repository.K8sNode
+	machineTypeCapacityRepo repository.MachineTypeCapacity
 }
 
 func newGCPCluster(
@@ -89,13 +100,15 @@ func newGCPCluster(
 	gcpClusterRepo repository.GCPCluster,
 	k8sDiscoveryRepo repository.K8SDiscovery,
 	k8sNodeRepo repository.K8sNode,
+	machineTypeCapacityRepo repository.MachineTypeCapacity,
 ) GCPCluster {
 	return &gcpCluster{
-		validatorInst:    validatorInst,
-		clusterRepo:      clusterRepo,
-		gcpClusterRepo:   gcpClusterRepo,
-		k8sDiscoveryRepo: k8sDiscoveryRepo,
-		k8sNodeRepo:      k8sNodeRepo,
+		validatorInst:           validatorInst,
+		clusterRepo:             clusterRepo,
+		gcpClusterRepo:          gcpClusterRepo,
+		k8sDiscoveryRepo:        k8sDiscoveryRepo,
+		k8sNodeRepo:             k8sNodeRepo,
+		machineTypeCapacityRepo: machineTypeCapacityRepo,
 	}
 }
 
@@ -127,6 +140,60 @@ func (c *gcpCluster) GetGoogleInstanceTemplatesClient(
 	return compute.NewInstanceTemplatesRESTClient(ctx, option.WithCredentials(googleCredential))
 }
 
+func (c *gcpCluster) GetGoogleMachineTypesClient(
+	ctx context.Context,
+	googleCredential *google.Credentials,
+) (*compute.MachineTypesClient, error) {
+	return compute.NewMachineTypesRESTClient(ctx, option.WithCredentials(googleCredential))
+}
+
+// GetGCPMachineTypeCapacity only calls the compute API for machine types missing from the cache
+func (c *gcpCluster) GetGCPMachineTypeCapacity(
+	ctx context.Context,
+	tx *gorm.DB,
+	machineTypesClient *compute.MachineTypesClient,
+	project, zone, machineType string,
+) (*UCEntity.MachineTypeCapacity, error) {
+	cachedData, err := c.machineTypeCapacityRepo.GetMachineTypeCapacity(tx, model.GCP, machineType)
+	if err == nil {
+		return &UCEntity.MachineTypeCapacity{
+			MachineType: cachedData.MachineType,
+			GuestCPUs:   cachedData.GuestCPUs,
+			MemoryMB:    cachedData.MemoryMB,
+		}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	machineTypeData, err := c.gcpClusterRepo.GetMachineType(
+		ctx,
+		machineTypesClient,
+		project,
+		zone,
+		machineType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &model.MachineTypeCapacity{
+		Datacenter:  model.GCP,
+		MachineType: machineType,
+		GuestCPUs:   machineTypeData.GetGuestCpus(),
+		MemoryMB:    machineTypeData.GetMemoryMb(),
+	}
+	err = c.machineTypeCapacityRepo.InsertMachineTypeCapacity(tx, data)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.MachineTypeCapacity{
+		MachineType: data.MachineType,
+		GuestCPUs:   data.GuestCPUs,
+		MemoryMB:    data.MemoryMB,
+	}, nil
+}
+
 func (c *gcpCluster) GetAllClustersInGCPProject(
 	ctx context.Context,
 	projectID string,
@@ -253,9 +320,6 @@ func (c *gcpCluster) GetNodesFromGCPNodePool(
 	if err != nil {
 		return nil, err
 	}
-	if len(data.Items) == 0 {
-		return nil, errors.New(errorConstant.NoExistingNode)
-	}
 	return &UCEntity.K8sNodeListData{NodeListObject: data}, nil
 }
 
@@ -0,0 +1,208 @@
+package useCase
+
+import (
+	compute "cloud.google.com/go/compute/apiv1"
+	container "cloud.google.com/go/container/apiv1"
+	"context"
+	"errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	containerEntity "google.golang.org/genproto/googleapis/container/v1"
+	"gorm.io/gorm"
+	v1Core "k8s.io/api/core/v1"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+)
+
+type GCPEvent interface {
+	CalculateGCPEventPlan(
+		ctx context.Context,
+		tx *gorm.DB,
+		kubernetesClient kubernetes.Interface,
+		clusterClient *container.ClusterManagerClient,
+		machineTypesClient *compute.MachineTypesClient,
+		clusterData *UCEntity.ClusterData,
+		event *UCEntity.Event,
+	) (*UCEntity.GCPEventPlan, error)
+}
+
+type gcpEvent struct {
+	eventPlanner
+	gcpClusterUC GCPCluster
+}
+
+func newGCPEvent(
+	clusterUC Cluster,
+	gcpClusterUC GCPCluster,
+	scheduledHPAConfigUC ScheduledHPAConfig,
+	scheduledWorkloadConfigUC ScheduledWorkloadConfig,
+) GCPEvent {
+	return &gcpEvent{
+		eventPlanner: eventPlanner{
+			clusterUC:                 clusterUC,
+			scheduledHPAConfigUC:      scheduledHPAConfigUC,
+			scheduledWorkloadConfigUC: scheduledWorkloadConfigUC,
+		},
+		gcpClusterUC: gcpClusterUC,
+	}
+}
+
+func (g *gcpEvent) CalculateGCPEventPlan(
+	ctx context.Context,
+	tx *gorm.DB,
+	kubernetesClient kubernetes.Interface,
+	clusterClient *container.ClusterManagerClient,
+	machineTypesClient *compute.MachineTypesClient,
+	clusterData *UCEntity.ClusterData,
+	e *UCEntity.Event,
+) (*UCEntity.GCPEventPlan, error) {
+	eventPlan, unselectedK8sHPAs, err := g.selectEventHPAs(ctx, tx, kubernetesClient, clusterData, e)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UCEntity.GCPEventPlan{EventPlan: *eventPlan}
+	if len(plan.SelectedHPAs) == 0 && len(plan.SelectedWorkloads) == 0 {
+		return plan, nil
+	}
+
+	// Parse GCP Cluster Name
+	clusterMetadata := strings.Split(clusterData.Name, "_")
+	plan.Project = clusterMetadata[1]
+	plan.Location = clusterMetadata[3]
+	plan.ClusterName = clusterMetadata[2]
+
+	// Get GCP Node Pools
+	googleClusterData, err := g.gcpClusterUC.GetGCPClusterObject(
+		ctx,
+		clusterClient,
+		plan.Project,
+		plan.Location,
+		plan.ClusterName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodePools []*nodePoolSource
+	for _, nodePool := range googleClusterData.ClusterObject.NodePools {
+		nodePoolPlan := &UCEntity.GCPNodePoolPlan{
+			NodePoolPlan: UCEntity.NodePoolPlan{
+				NodePoolName: nodePool.Name,
+			},
+			NodePoolObject: nodePool,
+		}
+		nodePoolPlan.Zones = nodePool.Locations
+		if len(nodePoolPlan.Zones) == 0 {
+			nodePoolPlan.Zones = googleClusterData.ClusterObject.Locations
+		}
+		if nodePool.Autoscaling != nil {
+			// Total limits cover the whole pool, otherwise the max node count applies to each zone
+			if nodePool.Autoscaling.TotalMaxNodeCount > 0 {
+				nodePoolPlan.CurrentMaxNode = nodePool.Autoscaling.TotalMaxNodeCount
+			} else {
+				nodePoolPlan.CurrentMaxNode = nodePool.Autoscaling.MaxNodeCount
+				nodePoolPlan.PerZoneMaxNode = true
+			}
+		}
+		var maxPodsPerNode int64
+		if nodePool.MaxPodsConstraint != nil {
+			maxPodsPerNode = nodePool.MaxPodsConstraint.MaxPodsPerNode
+		}
+		plan.NodePools = append(plan.NodePools, nodePoolPlan)
+
+		nodePoolName := nodePool.Name
+		nodePoolObject := nodePool
+		nodePools = append(
+			nodePools, &nodePoolSource{
+				plan:           &nodePoolPlan.NodePoolPlan,
+				maxPodsPerNode: maxPodsPerNode,
+				taints:         util.GetGKENodePoolTaints(nodePool.Config),
+				getNodes: func(ctx context.Context) (*UCEntity.K8sNodeListData, error) {
+					return g.gcpClusterUC.GetNodesFromGCPNodePool(ctx, kubernetesClient, nodePoolName)
+				},
+				getNodeTemplate: func(ctx context.Context) (*v1Core.Node, error) {
+					return g.getNodePoolTemplate(
+						ctx,
+						tx,
+						machineTypesClient,
+						plan,
+						googleClusterData.ClusterObject,
+						nodePoolObject,
+						maxPodsPerNode,
+					)
+				},
+			},
+		)
+	}
+
+	err = g.calculateNodePools(ctx, kubernetesClient, e, &plan.EventPlan, unselectedK8sHPAs, nodePools)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// getNodePoolTemplate builds a node of the node pool from its config for pools scaled to zero,
+// the allocatable is derived from the machine type minus the GKE system reservations
+func (g *gcpEvent) getNodePoolTemplate(
+	ctx context.Context,
+	tx *gorm.DB,
+	machineTypesClient *compute.MachineTypesClient,
+	plan *UCEntity.GCPEventPlan,
+	cluster *containerEntity.Cluster,
+	nodePool *containerEntity.NodePool,
+	maxPodsPerNode int64,
+) (*v1Core.Node, error) {
+	config := nodePool.Config
+	if config == nil || machineTypesClient == nil {
+		return nil, errors.New(errorConstant.NoExistingNode)
+	}
+
+	zone := plan.Location
+	if len(nodePool.Locations) > 0 {
+		zone = nodePool.Locations[0]
+	} else if len(cluster.Locations) > 0 {
+		zone = cluster.Locations[0]
+	}
+	capacity, err := g.gcpClusterUC.GetGCPMachineTypeCapacity(
+		ctx,
+		tx,
+		machineTypesClient,
+		plan.Project,
+		zone,
+		config.MachineType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeLabels := map[string]string{}
+	for key, value := range config.Labels {
+		nodeLabels[key] = value
+	}
+	nodeLabels[constant.GCPNodePoolLabel] = nodePool.Name
+	nodeLabels[v1Core.LabelInstanceTypeStable] = config.MachineType
+	nodeLabels[constant.GCPMachineFamilyLabel] = strings.Split(config.MachineType, "-")[0]
+	nodeLabels[v1Core.LabelOSStable] = "linux"
+	if strings.HasPrefix(config.ImageType, "WINDOWS") {
+		nodeLabels[v1Core.LabelOSStable] = "windows"
+	}
+	if config.Preemptible {
+		nodeLabels[constant.GCPPreemptibleLabel] = "true"
+	}
+
+	if maxPodsPerNode == 0 {
+		maxPodsPerNode = constant.GKEDefaultMaxPodsPerNode
+	}
+	return &v1Core.Node{
+		ObjectMeta: v1Option.ObjectMeta{Labels: nodeLabels},
+		Status: v1Core.NodeStatus{
+			Allocatable: util.GetGKENodeAllocatable(capacity.GuestCPUs, capacity.MemoryMB, maxPodsPerNode),
+		},
+	}, nil
+}
@@ -6,26 +6,60 @@ import (
 )
 
 type UseCases struct {
-	GcpDatacenter      GCPDatacenter
-	GcpCluster         GCPCluster
-	Cluster            Cluster
-	Datacenter         Datacenter
-	Event              Event
-	ScheduledHPAConfig ScheduledHPAConfig
-	UpdatedNodePool    Statistic
+	GcpDatacenter           GCPDatacenter
+	GcpCluster              GCPCluster
+	Cluster                 Cluster
+	Datacenter              Datacenter
+	Event                   Event
+	ScheduledHPAConfig      ScheduledHPAConfig
+	ScheduledWorkloadConfig ScheduledWorkloadConfig
+	UpdatedNodePool         Statistic
+	GcpEvent                GCPEvent
+	AwsDatacenter           AWSDatacenter
+	AwsCluster              AWSCluster
+	AwsEvent                AWSEvent
+	AzureDatacenter         AzureDatacenter
+	AzureCluster            AzureCluster
+	AzureEvent              AzureEvent
+	KubeconfigDatacenter    KubeconfigDatacenter
+	KubeconfigCluster       KubeconfigCluster
+	KubeconfigEvent         KubeconfigEvent
+	RecurringEvent          RecurringEvent
+	EventTemplate           EventTemplate
 }
 
 func BuildUseCases(
 	resources *config.KubeEPResources,
 	repositories *repository.Repositories,
 ) *UseCases {
-	return &UseCases{
+	useCases := &UseCases{
 		GcpCluster: newGCPCluster(
 			resources.ValidatorInst, repositories.Cluster,
 			repositories.GCPCluster, repositories.K8SDiscovery,
-			repositories.K8sNode,
+			repositories.K8sNode, repositories.MachineTypeCapacity,
 		),
 		GcpDatacenter: newGCPDatacenter(repositories.Datacenter, resources.ValidatorInst),
+		AwsCluster: newAWSCluster(
+			resources.ValidatorInst,
+			repositories.Cluster,
+			repositories.AWSCluster,
+			repositories.K8sNode,
+		),
+		AwsDatacenter: newAWSDatacenter(repositories.Datacenter, resources.ValidatorInst),
+		AzureCluster: newAzureCluster(
+			resources.ValidatorInst,
+			repositories.Cluster,
+			repositories.AzureCluster,
+			repositories.K8sNode,
+		),
+		AzureDatacenter: newAzureDatacenter(repositories.Datacenter, resources.ValidatorInst),
+		KubeconfigCluster: newKubeconfigCluster(
+			resources.ValidatorInst,
+			repositories.Cluster,
+			repositories.K8sNode,
+			repositories.K8sCapacity,
+		),
+		KubeconfigDatacenter: newKubeconfigDatacenter(repositories.Datacenter, resources.ValidatorInst),
 		Cluster: newCluster(
 			resources.ValidatorInst,
 			repositories.Cluster,
@@ -34,19 +68,62 @@ func BuildUseCases(
 			repositories.K8SDiscovery,
 			repositories.K8sDeployment,
 			repositories.K8sDaemonSets,
+			repositories.K8sWorkload,
+			repositories.K8sScaledObject,
 		),
 		Datacenter: newDatacenter(resources.ValidatorInst, repositories.Datacenter),
 		Event: newEvent(
 			resources.ValidatorInst,
 			repositories.Event,
 			repositories.ScheduledHPAConfig,
+			repositories.ScheduledWorkloadConfig,
 			repositories.Cluster,
+			repositories.UpdatedNodePool,
+		),
+		ScheduledHPAConfig: newScheduledHPAConfig(
+			repositories.ScheduledHPAConfig,
+			repositories.ScheduledHPAStep,
+		),
+		ScheduledWorkloadConfig: newScheduledWorkloadConfig(
+			repositories.ScheduledWorkloadConfig,
 		),
-		ScheduledHPAConfig: newScheduledHPAConfig(repositories.ScheduledHPAConfig),
 		UpdatedNodePool: newStatistic(
 			repositories.UpdatedNodePool,
 			repositories.HPAStatus,
 			repositories.NodePoolStatus,
+			repositories.WorkloadStatus,
 		),
 	}
+	useCases.GcpEvent = newGCPEvent(
+		useCases.Cluster,
+		useCases.GcpCluster,
+		useCases.ScheduledHPAConfig,
+		useCases.ScheduledWorkloadConfig,
+	)
+	useCases.AwsEvent = newAWSEvent(
+		useCases.Cluster,
+		useCases.AwsCluster,
+		useCases.ScheduledHPAConfig,
+		useCases.ScheduledWorkloadConfig,
+	)
+	useCases.AzureEvent = newAzureEvent(
+		useCases.Cluster,
+		useCases.AzureCluster,
+		useCases.ScheduledHPAConfig,
+		useCases.ScheduledWorkloadConfig,
+	)
+	useCases.KubeconfigEvent = newKubeconfigEvent(
+		useCases.Cluster,
+		useCases.KubeconfigCluster,
+		useCases.ScheduledHPAConfig,
+		useCases.ScheduledWorkloadConfig,
+	)
+	useCases.RecurringEvent = newRecurringEvent(
+		repositories.RecurringEvent,
+		useCases.Event,
+		useCases.ScheduledHPAConfig,
+		useCases.ScheduledWorkloadConfig,
+	)
+	useCases.EventTemplate = newEventTemplate(repositories.EventTemplate)
+	return useCases
 }
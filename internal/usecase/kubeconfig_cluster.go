@@ -0,0 +1,324 @@
+package useCase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/k8s/client"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sort"
+)
+
+type KubeconfigCluster interface {
+	GetAllClustersInKubeconfig(
+		datacenterName string,
+		credentials *UCEntity.KubeconfigCredentials,
+	) ([]*UCEntity.KubeconfigClusterData, error)
+	RegisterClusters(
+		tx *gorm.DB,
+		datacenterID uuid.UUID,
+		listCluster []*UCEntity.KubeconfigClusterData,
+	) error
+	GetKubeconfigClusterMetaData(clusterData *UCEntity.ClusterData) (
+		*UCEntity.KubeconfigClusterMetaData,
+		error,
+	)
+	GetKubernetesClusterClient(
+		credentials *UCEntity.KubeconfigCredentials,
+		clusterData *UCEntity.ClusterData,
+	) (*kubernetes.Clientset, error)
+	GetNodePoolName(nodeLabels map[string]string, nodePoolLabel string) string
+	GetAllNodePoolsInCluster(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		nodePoolLabel string,
+	) ([]string, map[string]int32, error)
+	GetNodesFromNodePool(
+		ctx context.Context,
+		k8sClient kubernetes.Interface,
+		nodePoolLabel, nodePoolName string,
+	) (*UCEntity.K8sNodeListData, error)
+	ValidateCapacityBackend(capacityBackend constant.CapacityBackend) error
+	GetCapacityBackend(clusterData *UCEntity.ClusterData) (CapacityBackend, error)
+	UpdateCapacityBackend(
+		tx *gorm.DB,
+		clusterData *UCEntity.ClusterData,
+		capacityBackend constant.CapacityBackend,
+	) error
+}
+
+type kubeconfigCluster struct {
+	validatorInst    *validator.Validate
+	clusterRepo      repository.Cluster
+	k8sNodeRepo      repository.K8sNode
+	capacityBackends map[constant.CapacityBackend]CapacityBackend
+}
+
+func newKubeconfigCluster(
+	validatorInst *validator.Validate,
+	clusterRepo repository.Cluster,
+	k8sNodeRepo repository.K8sNode,
+	k8sCapacityRepo repository.K8sCapacity,
+) KubeconfigCluster {
+	return &kubeconfigCluster{
+		validatorInst:    validatorInst,
+		clusterRepo:      clusterRepo,
+		k8sNodeRepo:      k8sNodeRepo,
+		capacityBackends: newCapacityBackends(k8sCapacityRepo, k8sNodeRepo),
+	}
+}
+
+func (c *kubeconfigCluster) GetAllClustersInKubeconfig(
+	datacenterName string,
+	credentials *UCEntity.KubeconfigCredentials,
+) ([]*UCEntity.KubeconfigClusterData, error) {
+	datacenterInfo := UCEntity.DatacenterDetailedData{
+		Datacenter: model.Kubeconfig,
+	}
+	if credentials.Kubeconfig == "" {
+		return []*UCEntity.KubeconfigClusterData{
+			{
+				ClusterData: UCEntity.ClusterData{
+					Name:           fmt.Sprintf("kubeconfig_%s", datacenterName),
+					ServerEndpoint: credentials.ServerEndpoint,
+					Certificate:    credentials.Certificate,
+					Datacenter:     datacenterInfo,
+				},
+			},
+		}, nil
+	}
+
+	config, err := clientcmd.Load([]byte(credentials.Kubeconfig))
+	if err != nil {
+		return nil, errors.New(errorConstant.KubeconfigInvalid)
+	}
+
+	var contextNames []string
+	for contextName := range config.Contexts {
+		contextNames = append(contextNames, contextName)
+	}
+	sort.Strings(contextNames)
+
+	var clusterData []*UCEntity.KubeconfigClusterData
+	for _, contextName := range contextNames {
+		cluster, ok := config.Clusters[config.Contexts[contextName].Cluster]
+		if !ok {
+			continue
+		}
+		clusterData = append(
+			clusterData, &UCEntity.KubeconfigClusterData{
+				ClusterData: UCEntity.ClusterData{
+					Name:           fmt.Sprintf("kubeconfig_%s_%s", datacenterName, contextName),
+					ServerEndpoint: cluster.Server,
+					Certificate:    base64.StdEncoding.EncodeToString(cluster.CertificateAuthorityData),
+					Datacenter:     datacenterInfo,
+				},
+				Context: contextName,
+			},
+		)
+	}
+	return clusterData, nil
+}
+
+func (c *kubeconfigCluster) RegisterClusters(
+	tx *gorm.DB,
+	datacenterID uuid.UUID,
+	listCluster []*UCEntity.KubeconfigClusterData,
+) error {
+	var clusters []*model.Cluster
+	for _, cluster := range listCluster {
+		capacityBackend := cluster.CapacityBackend
+		if capacityBackend == "" {
+			capacityBackend = constant.CapacityBackendNone
+		}
+		metadata := UCEntity.KubeconfigClusterMetaData{
+			Context:         cluster.Context,
+			CapacityBackend: capacityBackend,
+		}
+		metadataByte, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		clusterModel := &model.Cluster{
+			Name:                cluster.Name,
+			ServerEndpoint:      cluster.ServerEndpoint,
+			Certificate:         cluster.Certificate,
+			LatestHPAAPIVersion: cluster.LatestHPAAPIVersion,
+		}
+		clusterModel.DatacenterID.SetUUID(datacenterID)
+		clusterModel.Metadata.SetRawMessage(metadataByte)
+		clusters = append(clusters, clusterModel)
+	}
+
+	err := c.clusterRepo.InsertClusterBatch(tx, clusters)
+	if err != nil {
+		return err
+	}
+
+	for idx, cluster := range clusters {
+		listCluster[idx].ID = cluster.ID.GetUUID()
+	}
+
+	return nil
+}
+
+func (c *kubeconfigCluster) GetKubeconfigClusterMetaData(clusterData *UCEntity.ClusterData) (
+	*UCEntity.KubeconfigClusterMetaData,
+	error,
+) {
+	if clusterData.Datacenter.Datacenter != model.Kubeconfig {
+		return nil, errors.New(errorConstant.DatacenterMismatch)
+	}
+	metadata := &UCEntity.KubeconfigClusterMetaData{}
+	if len(clusterData.Metadata) == 0 {
+		return metadata, nil
+	}
+	err := json.Unmarshal(clusterData.Metadata, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (c *kubeconfigCluster) GetKubernetesClusterClient(
+	credentials *UCEntity.KubeconfigCredentials,
+	clusterData *UCEntity.ClusterData,
+) (*kubernetes.Clientset, error) {
+	if credentials.Kubeconfig == "" {
+		return k8sClient.GetClient(
+			&k8sClient.Credentials{
+				Certificate:    clusterData.Certificate,
+				Name:           clusterData.Name,
+				ServerEndpoint: clusterData.ServerEndpoint,
+				Token:          credentials.Token,
+			},
+		)
+	}
+
+	metadata, err := c.GetKubeconfigClusterMetaData(clusterData)
+	if err != nil {
+		return nil, err
+	}
+	return k8sClient.GetClientFromKubeconfig([]byte(credentials.Kubeconfig), metadata.Context)
+}
+
+func (c *kubeconfigCluster) GetNodePoolName(
+	nodeLabels map[string]string,
+	nodePoolLabel string,
+) string {
+	nodePoolName, ok := nodeLabels[nodePoolLabel]
+	if !ok || nodePoolName == "" {
+		return constant.KubeconfigDefaultNodePool
+	}
+	return nodePoolName
+}
+
+func (c *kubeconfigCluster) GetAllNodePoolsInCluster(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodePoolLabel string,
+) ([]string, map[string]int32, error) {
+	data, err := c.k8sNodeRepo.GetNodeList(ctx, k8sClient, v1Option.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	var nodePoolNames []string
+	nodeCounts := map[string]int32{}
+	for _, node := range data.Items {
+		nodePoolName := c.GetNodePoolName(node.Labels, nodePoolLabel)
+		if _, ok := nodeCounts[nodePoolName]; !ok {
+			nodePoolNames = append(nodePoolNames, nodePoolName)
+		}
+		nodeCounts[nodePoolName] += 1
+	}
+	sort.Strings(nodePoolNames)
+	return nodePoolNames, nodeCounts, nil
+}
+
+func (c *kubeconfigCluster) GetNodesFromNodePool(
+	ctx context.Context,
+	k8sClient kubernetes.Interface,
+	nodePoolLabel, nodePoolName string,
+) (*UCEntity.K8sNodeListData, error) {
+	// Nodes without the node pool label are grouped into the default node pool
+	labelSelector := fmt.Sprintf("%s=%s", nodePoolLabel, nodePoolName)
+	if nodePoolName == constant.KubeconfigDefaultNodePool {
+		labelSelector = fmt.Sprintf("!%s", nodePoolLabel)
+	}
+	data, err := c.k8sNodeRepo.GetNodeList(
+		ctx, k8sClient, v1Option.ListOptions{
+			LabelSelector: labelSelector,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Items) == 0 {
+		return nil, errors.New(errorConstant.NoExistingNode)
+	}
+	return &UCEntity.K8sNodeListData{NodeListObject: data}, nil
+}
+
+func (c *kubeconfigCluster) ValidateCapacityBackend(capacityBackend constant.CapacityBackend) error {
+	if capacityBackend == constant.CapacityBackendNone {
+		return nil
+	}
+	if _, ok := c.capacityBackends[capacityBackend]; !ok {
+		return fmt.Errorf(errorConstant.CapacityBackendUnknown, capacityBackend)
+	}
+	return nil
+}
+
+// GetCapacityBackend returns nil when node pools of the cluster can only be recommended
+func (c *kubeconfigCluster) GetCapacityBackend(
+	clusterData *UCEntity.ClusterData,
+) (CapacityBackend, error) {
+	metadata, err := c.GetKubeconfigClusterMetaData(clusterData)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.CapacityBackend == "" || metadata.CapacityBackend == constant.CapacityBackendNone {
+		return nil, nil
+	}
+	capacityBackend, ok := c.capacityBackends[metadata.CapacityBackend]
+	if !ok {
+		return nil, fmt.Errorf(errorConstant.CapacityBackendUnknown, metadata.CapacityBackend)
+	}
+	return capacityBackend, nil
+}
+
+func (c *kubeconfigCluster) UpdateCapacityBackend(
+	tx *gorm.DB,
+	clusterData *UCEntity.ClusterData,
+	capacityBackend constant.CapacityBackend,
+) error {
+	err := c.ValidateCapacityBackend(capacityBackend)
+	if err != nil {
+		return err
+	}
+	metadata, err := c.GetKubeconfigClusterMetaData(clusterData)
+	if err != nil {
+		return err
+	}
+	metadata.CapacityBackend = capacityBackend
+	metadataByte, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	clusterModel := &model.Cluster{}
+	clusterModel.ID.SetUUID(clusterData.ID)
+	clusterModel.Metadata.SetRawMessage(metadataByte)
+	return c.clusterRepo.UpdateClusterMetadata(tx, clusterModel)
+}
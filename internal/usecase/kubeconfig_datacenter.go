@@ -0,0 +1,176 @@
+package useCase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+	"k8s.io/client-go/tools/clientcmd"
+	"time"
+)
+
+type KubeconfigDatacenter interface {
+	SaveDatacenterDetailedData(tx *gorm.DB, data *UCEntity.DatacenterDetailedData) (
+		uuid.UUID,
+		error,
+	)
+	SaveDatacenter(
+		tx *gorm.DB,
+		data UCEntity.DatacenterData,
+		credentials *UCEntity.KubeconfigCredentials,
+	) (uuid.UUID, error)
+	ParseCredentials(data UCEntity.DatacenterData) (
+		*UCEntity.KubeconfigCredentials,
+		error,
+	)
+	SaveTemporaryDatacenter(
+		ctx context.Context,
+		data UCEntity.DatacenterData,
+		credentials *UCEntity.KubeconfigCredentials,
+	) (uuid.UUID, error)
+	GetTemporaryDatacenterData(ctx context.Context, id uuid.UUID) (
+		*UCEntity.DatacenterDetailedData,
+		error,
+	)
+	GetDatacenterData(tx *gorm.DB, id uuid.UUID) (*UCEntity.DatacenterDetailedData, error)
+}
+
+type kubeconfigDatacenter struct {
+	datacenterRepo repository.Datacenter
+	validatorInst  *validator.Validate
+}
+
+func newKubeconfigDatacenter(
+	datacenterRepo repository.Datacenter,
+	validatorInst *validator.Validate,
+) KubeconfigDatacenter {
+	return &kubeconfigDatacenter{
+		datacenterRepo: datacenterRepo,
+		validatorInst:  validatorInst,
+	}
+}
+
+func (d *kubeconfigDatacenter) ParseCredentials(data UCEntity.DatacenterData) (
+	*UCEntity.KubeconfigCredentials,
+	error,
+) {
+	credentials := &UCEntity.KubeconfigCredentials{}
+	err := json.Unmarshal(data.Credentials, credentials)
+	if err != nil {
+		return nil, err
+	}
+	err = d.validatorInst.Struct(credentials)
+	if err != nil {
+		return nil, errors.New(errorConstant.KubeconfigCredentialsInvalid)
+	}
+	if credentials.Kubeconfig != "" {
+		config, err := clientcmd.Load([]byte(credentials.Kubeconfig))
+		if err != nil || len(config.Contexts) == 0 {
+			return nil, errors.New(errorConstant.KubeconfigInvalid)
+		}
+	}
+	if credentials.NodePoolLabel == "" {
+		credentials.NodePoolLabel = constant.KubeconfigDefaultNodePoolLabel
+	}
+	return credentials, nil
+}
+
+func (d *kubeconfigDatacenter) SaveTemporaryDatacenter(
+	ctx context.Context,
+	data UCEntity.DatacenterData,
+	credentials *UCEntity.KubeconfigCredentials,
+) (uuid.UUID, error) {
+	metaData := &UCEntity.KubeconfigDatacenterMetaData{
+		NodePoolLabel: credentials.NodePoolLabel,
+	}
+	metaDataByte, err := json.Marshal(metaData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	datacenterModel := &model.Datacenter{
+		Name:       data.Name,
+		Datacenter: model.Kubeconfig,
+	}
+	datacenterModel.Credentials.SetRawMessage(data.Credentials)
+	datacenterModel.Metadata.SetRawMessage(metaDataByte)
+	err = d.datacenterRepo.InsertTemporaryDatacenter(ctx, datacenterModel, time.Hour)
+	return datacenterModel.ID.GetUUID(), err
+}
+
+func (d *kubeconfigDatacenter) GetTemporaryDatacenterData(
+	ctx context.Context,
+	id uuid.UUID,
+) (*UCEntity.DatacenterDetailedData, error) {
+	data, err := d.datacenterRepo.GetTemporaryDatacenterByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.DatacenterDetailedData{
+		ID:          data.ID.GetUUID(),
+		Name:        data.Name,
+		Credentials: data.Credentials.GetRawMessage(),
+		Metadata:    data.Metadata.GetRawMessage(),
+		Datacenter:  data.Datacenter,
+	}, nil
+}
+
+func (d *kubeconfigDatacenter) GetDatacenterData(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*UCEntity.DatacenterDetailedData, error) {
+	data, err := d.datacenterRepo.GetDatacenterByID(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &UCEntity.DatacenterDetailedData{
+		ID:          data.ID.GetUUID(),
+		Name:        data.Name,
+		Credentials: data.Credentials.GetRawMessage(),
+		Metadata:    data.Metadata.GetRawMessage(),
+		Datacenter:  data.Datacenter,
+	}, nil
+}
+
+func (d *kubeconfigDatacenter) SaveDatacenterDetailedData(
+	tx *gorm.DB,
+	data *UCEntity.DatacenterDetailedData,
+) (uuid.UUID, error) {
+	datacenterData := &model.Datacenter{
+		Name:       data.Name,
+		Datacenter: data.Datacenter,
+	}
+	datacenterData.ID.SetUUID(data.ID)
+	datacenterData.Credentials.SetRawMessage(data.Credentials)
+	datacenterData.Metadata.SetRawMessage(data.Metadata)
+	err := d.datacenterRepo.InsertDatacenter(tx, datacenterData)
+	return datacenterData.ID.GetUUID(), err
+}
+
+func (d *kubeconfigDatacenter) SaveDatacenter(
+	tx *gorm.DB,
+	data UCEntity.DatacenterData,
+	credentials *UCEntity.KubeconfigCredentials,
+) (uuid.UUID, error) {
+	metaData := &UCEntity.KubeconfigDatacenterMetaData{
+		NodePoolLabel: credentials.NodePoolLabel,
+	}
+	metaDataByte, err := json.Marshal(metaData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	datacenterModel := model.Datacenter{
+		Name:       data.Name,
+		Datacenter: model.Kubeconfig,
+	}
+	datacenterModel.Credentials.SetRawMessage(data.Credentials)
+	datacenterModel.Metadata.SetRawMessage(metaDataByte)
+	err = d.datacenterRepo.InsertDatacenter(tx, &datacenterModel)
+	return uuid.UUID(datacenterModel.ID), err
+}
@@ -0,0 +1,128 @@
+package useCase
+
+import (
+	"context"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"gorm.io/gorm"
+	"k8s.io/client-go/kubernetes"
+)
+
+type KubeconfigEvent interface {
+	CalculateKubeconfigEventPlan(
+		ctx context.Context,
+		tx *gorm.DB,
+		kubernetesClient kubernetes.Interface,
+		nodePoolLabel string,
+		clusterData *UCEntity.ClusterData,
+		event *UCEntity.Event,
+	) (*UCEntity.KubeconfigEventPlan, error)
+}
+
+type kubeconfigEvent struct {
+	eventPlanner
+	kubeconfigClusterUC KubeconfigCluster
+}
+
+func newKubeconfigEvent(
+	clusterUC Cluster,
+	kubeconfigClusterUC KubeconfigCluster,
+	scheduledHPAConfigUC ScheduledHPAConfig,
+	scheduledWorkloadConfigUC ScheduledWorkloadConfig,
+) KubeconfigEvent {
+	return &kubeconfigEvent{
+		eventPlanner: eventPlanner{
+			clusterUC:                 clusterUC,
+			scheduledHPAConfigUC:      scheduledHPAConfigUC,
+			scheduledWorkloadConfigUC: scheduledWorkloadConfigUC,
+		},
+		kubeconfigClusterUC: kubeconfigClusterUC,
+	}
+}
+
+func (k *kubeconfigEvent) CalculateKubeconfigEventPlan(
+	ctx context.Context,
+	tx *gorm.DB,
+	kubernetesClient kubernetes.Interface,
+	nodePoolLabel string,
+	clusterData *UCEntity.ClusterData,
+	e *UCEntity.Event,
+) (*UCEntity.KubeconfigEventPlan, error) {
+	eventPlan, unselectedK8sHPAs, err := k.selectEventHPAs(ctx, tx, kubernetesClient, clusterData, e)
+	if err != nil {
+		return nil, err
+	}
+
+	capacityBackend, err := k.kubeconfigClusterUC.GetCapacityBackend(clusterData)
+	if err != nil {
+		return nil, err
+	}
+	if capacityBackend != nil {
+		nodePoolLabel = capacityBackend.GetNodePoolLabel()
+	}
+
+	plan := &UCEntity.KubeconfigEventPlan{
+		EventPlan:          *eventPlan,
+		NodePoolLabel:      nodePoolLabel,
+		RecommendationOnly: capacityBackend == nil,
+	}
+	if len(plan.SelectedHPAs) == 0 && len(plan.SelectedWorkloads) == 0 {
+		return plan, nil
+	}
+
+	// Node pools are derived from node labels, without a capacity backend their current size
+	// is the only known maximum
+	nodePoolNames, nodeCounts, err := k.kubeconfigClusterUC.GetAllNodePoolsInCluster(
+		ctx,
+		kubernetesClient,
+		nodePoolLabel,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodePools []*nodePoolSource
+	for _, nodePoolName := range nodePoolNames {
+		capacity := &UCEntity.NodePoolCapacityData{MaxNode: nodeCounts[nodePoolName]}
+		if capacityBackend != nil {
+			capacity, err = capacityBackend.GetNodePoolCapacity(
+				ctx,
+				kubernetesClient,
+				nodePoolName,
+				nodeCounts[nodePoolName],
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodePoolPlan := &UCEntity.KubeconfigNodePoolPlan{
+			NodePoolPlan: UCEntity.NodePoolPlan{
+				NodePoolName:   nodePoolName,
+				CurrentMaxNode: capacity.MaxNode,
+			},
+			Capacity: *capacity,
+		}
+		plan.NodePools = append(plan.NodePools, nodePoolPlan)
+
+		nodePoolName := nodePoolName
+		nodePools = append(
+			nodePools, &nodePoolSource{
+				plan: &nodePoolPlan.NodePoolPlan,
+				getNodes: func(ctx context.Context) (*UCEntity.K8sNodeListData, error) {
+					return k.kubeconfigClusterUC.GetNodesFromNodePool(
+						ctx,
+						kubernetesClient,
+						nodePoolLabel,
+						nodePoolName,
+					)
+				},
+			},
+		)
+	}
+
+	err = k.calculateNodePools(ctx, kubernetesClient, e, &plan.EventPlan, unselectedK8sHPAs, nodePools)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
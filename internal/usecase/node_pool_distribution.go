@@ -0,0 +1,196 @@
+package useCase
+
+import (
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	v1Core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"math"
+	"sort"
+)
+
+// podPlacement is a scheduled pod and the node pool of its node
+type podPlacement struct {
+	labels   labels.Set
+	nodePool string
+}
+
+// nodePoolDemandDistributor splits the pods of a workload matching several node pools between them,
+// so every pod is only counted once
+type nodePoolDemandDistributor struct {
+	strategy           model.NodePoolDistribution
+	primaryNodePool    string
+	nodePoolsResources map[string]*UCEntity.NodePoolResourceData
+	podPlacements      map[string][]podPlacement
+}
+
+func newNodePoolDemandDistributor(
+	e *UCEntity.Event,
+	nodePoolsResources map[string]*UCEntity.NodePoolResourceData,
+	nodePoolByNodeName map[string]string,
+	pods *v1Core.PodList,
+) *nodePoolDemandDistributor {
+	d := &nodePoolDemandDistributor{
+		strategy:           e.NodePoolDistribution,
+		primaryNodePool:    e.PrimaryNodePool,
+		nodePoolsResources: nodePoolsResources,
+		podPlacements:      map[string][]podPlacement{},
+	}
+	if d.strategy == "" {
+		d.strategy = model.NodePoolDistributionProportional
+	}
+	if pods == nil {
+		return d
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == v1Core.PodSucceeded || pod.Status.Phase == v1Core.PodFailed {
+			continue
+		}
+		nodePool, ok := nodePoolByNodeName[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		d.podPlacements[pod.Namespace] = append(
+			d.podPlacements[pod.Namespace], podPlacement{
+				labels:   pod.Labels,
+				nodePool: nodePool,
+			},
+		)
+	}
+	return d
+}
+
+// distribute returns the pods allocated to each node pool.
+// The primary pool distribution falls back to the current pod placement when the primary pool doesn't match,
+// every strategy splits evenly when no node pool has weight.
+func (d *nodePoolDemandDistributor) distribute(
+	podTemplate v1Core.PodTemplateSpec,
+	namespace string,
+	nodePools []string,
+	pods int64,
+) (map[string]int64, error) {
+	sortedNodePools := append([]string{}, nodePools...)
+	sort.Strings(sortedNodePools)
+
+	var weights map[string]float64
+	switch d.strategy {
+	case model.NodePoolDistributionPrimaryPool:
+		for _, nodePoolName := range sortedNodePools {
+			if nodePoolName == d.primaryNodePool {
+				return map[string]int64{nodePoolName: pods}, nil
+			}
+		}
+		weights = d.getPlacementWeights(podTemplate, namespace)
+	case model.NodePoolDistributionPreferredAffinity:
+		var nodeAffinity *v1Core.NodeAffinity
+		if podTemplate.Spec.Affinity != nil {
+			nodeAffinity = podTemplate.Spec.Affinity.NodeAffinity
+		}
+		weights = map[string]float64{}
+		for _, nodePoolName := range sortedNodePools {
+			weight, err := util.GetPreferredNodeAffinityWeight(
+				d.nodePoolsResources[nodePoolName].NodeLabels,
+				nodeAffinity,
+			)
+			if err != nil {
+				return nil, err
+			}
+			weights[nodePoolName] = float64(weight)
+		}
+	default:
+		weights = d.getPlacementWeights(podTemplate, namespace)
+	}
+
+	return splitPodsByWeight(pods, sortedNodePools, weights), nil
+}
+
+// getPlacementWeights counts the running pods of the workload on each node pool,
+// pods are matched by carrying every label of the pod template
+func (d *nodePoolDemandDistributor) getPlacementWeights(
+	podTemplate v1Core.PodTemplateSpec,
+	namespace string,
+) map[string]float64 {
+	weights := map[string]float64{}
+	if len(podTemplate.Labels) == 0 {
+		return weights
+	}
+	selector := labels.SelectorFromSet(podTemplate.Labels)
+	for _, placement := range d.podPlacements[namespace] {
+		if selector.Matches(placement.labels) {
+			weights[placement.nodePool] += 1
+		}
+	}
+	return weights
+}
+
+// splitPodsByWeight splits the pods with the largest remainder method
+func splitPodsByWeight(pods int64, nodePools []string, weights map[string]float64) map[string]int64 {
+	result := map[string]int64{}
+	if len(nodePools) == 0 {
+		return result
+	}
+
+	totalWeight := float64(0)
+	for _, nodePoolName := range nodePools {
+		totalWeight += weights[nodePoolName]
+	}
+	getWeight := func(nodePoolName string) float64 {
+		if totalWeight == 0 {
+			return 1
+		}
+		return weights[nodePoolName]
+	}
+	if totalWeight == 0 {
+		totalWeight = float64(len(nodePools))
+	}
+
+	assigned := int64(0)
+	remainders := make([]float64, len(nodePools))
+	order := make([]int, len(nodePools))
+	for idx, nodePoolName := range nodePools {
+		share := float64(pods) * getWeight(nodePoolName) / totalWeight
+		result[nodePoolName] = int64(math.Floor(share))
+		remainders[idx] = share - math.Floor(share)
+		order[idx] = idx
+		assigned += result[nodePoolName]
+	}
+	sort.SliceStable(
+		order, func(i, j int) bool {
+			return remainders[order[i]] > remainders[order[j]]
+		},
+	)
+	for idx := 0; assigned < pods; idx++ {
+		result[nodePools[order[idx%len(order)]]] += 1
+		assigned += 1
+	}
+	return result
+}
+
+// addNodePoolsDemand adds the allocated pods of a workload to the requested resources of the node pools
+func addNodePoolsDemand(
+	nodePoolsRequestedResources map[string]*UCEntity.NodePoolRequestedResourceData,
+	podsPerNodePool map[string]int64,
+	cpuPerPod float64,
+	memoryPerPod float64,
+	zoneSpread bool,
+) {
+	for nodePoolName, pods := range podsPerNodePool {
+		if pods <= 0 {
+			continue
+		}
+		requestedResourceData := nodePoolsRequestedResources[nodePoolName]
+		requestedResourceData.MaxPods += pods
+		requestedResourceData.MaxCPU += cpuPerPod * float64(pods)
+		requestedResourceData.MaxMemory += memoryPerPod * float64(pods)
+		requestedResourceData.PodGroups = append(
+			requestedResourceData.PodGroups,
+			UCEntity.PodResourceGroup{
+				CPU:        cpuPerPod,
+				Memory:     memoryPerPod,
+				Count:      pods,
+				ZoneSpread: zoneSpread,
+			},
+		)
+	}
+}
@@ -0,0 +1,253 @@
+package useCase
+
+import (
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	v1Core "k8s.io/api/core/v1"
+	v1Option "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"reflect"
+	"testing"
+)
+
+func newDistributionTestPod(namespace, nodeName string, podLabels map[string]string, phase v1Core.PodPhase) v1Core.Pod {
+	return v1Core.Pod{
+		ObjectMeta: v1Option.ObjectMeta{Namespace: namespace, Labels: podLabels},
+		Spec:       v1Core.PodSpec{NodeName: nodeName},
+		Status:     v1Core.PodStatus{Phase: phase},
+	}
+}
+
+func TestNodePoolDemandDistributorDistribute(t *testing.T) {
+	nodePoolsResources := map[string]*UCEntity.NodePoolResourceData{
+		"pool-a": {NodeLabels: labels.Set{"pool": "a"}},
+		"pool-b": {NodeLabels: labels.Set{"pool": "b"}},
+	}
+	nodePoolByNodeName := map[string]string{
+		"node-a1": "pool-a",
+		"node-a2": "pool-a",
+		"node-b1": "pool-b",
+	}
+	webLabels := map[string]string{"app": "web"}
+	pods := &v1Core.PodList{
+		Items: []v1Core.Pod{
+			newDistributionTestPod("default", "node-a1", webLabels, v1Core.PodRunning),
+			newDistributionTestPod("default", "node-a1", webLabels, v1Core.PodRunning),
+			newDistributionTestPod("default", "node-a2", map[string]string{"app": "web", "pod": "1"}, v1Core.PodRunning),
+			newDistributionTestPod("default", "node-b1", webLabels, v1Core.PodRunning),
+			newDistributionTestPod("default", "node-b1", webLabels, v1Core.PodSucceeded),
+			newDistributionTestPod("default", "node-b1", webLabels, v1Core.PodFailed),
+			newDistributionTestPod("default", "node-b1", map[string]string{"app": "api"}, v1Core.PodRunning),
+			newDistributionTestPod("default", "node-unknown", webLabels, v1Core.PodRunning),
+			newDistributionTestPod("other", "node-b1", webLabels, v1Core.PodRunning),
+		},
+	}
+	webTemplate := v1Core.PodTemplateSpec{ObjectMeta: v1Option.ObjectMeta{Labels: webLabels}}
+	preferPoolA := v1Core.PodTemplateSpec{
+		ObjectMeta: v1Option.ObjectMeta{Labels: webLabels},
+		Spec: v1Core.PodSpec{
+			Affinity: &v1Core.Affinity{
+				NodeAffinity: &v1Core.NodeAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []v1Core.PreferredSchedulingTerm{
+						{
+							Weight: 80,
+							Preference: v1Core.NodeSelectorTerm{
+								MatchExpressions: []v1Core.NodeSelectorRequirement{
+									{Key: "pool", Operator: v1Core.NodeSelectorOpIn, Values: []string{"a"}},
+								},
+							},
+						},
+						{
+							Weight: 20,
+							Preference: v1Core.NodeSelectorTerm{
+								MatchExpressions: []v1Core.NodeSelectorRequirement{
+									{Key: "pool", Operator: v1Core.NodeSelectorOpIn, Values: []string{"b"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	bothPools := []string{"pool-b", "pool-a"}
+
+	testCases := []struct {
+		name            string
+		strategy        model.NodePoolDistribution
+		primaryNodePool string
+		podTemplate     v1Core.PodTemplateSpec
+		namespace       string
+		nodePools       []string
+		pods            int64
+		expected        map[string]int64
+	}{
+		{
+			name:        "proportional to the running pods",
+			strategy:    model.NodePoolDistributionProportional,
+			podTemplate: webTemplate,
+			namespace:   "default",
+			nodePools:   bothPools,
+			pods:        10,
+			expected:    map[string]int64{"pool-a": 8, "pool-b": 2},
+		},
+		{
+			name:        "proportional by default",
+			podTemplate: webTemplate,
+			namespace:   "default",
+			nodePools:   bothPools,
+			pods:        10,
+			expected:    map[string]int64{"pool-a": 8, "pool-b": 2},
+		},
+		{
+			name:        "proportional without running pods splits evenly",
+			strategy:    model.NodePoolDistributionProportional,
+			podTemplate: v1Core.PodTemplateSpec{ObjectMeta: v1Option.ObjectMeta{Labels: map[string]string{"app": "worker"}}},
+			namespace:   "default",
+			nodePools:   bothPools,
+			pods:        3,
+			expected:    map[string]int64{"pool-a": 2, "pool-b": 1},
+		},
+		{
+			name:      "proportional without pod template labels splits evenly",
+			strategy:  model.NodePoolDistributionProportional,
+			namespace: "default",
+			nodePools: bothPools,
+			pods:      4,
+			expected:  map[string]int64{"pool-a": 2, "pool-b": 2},
+		},
+		{
+			name:        "proportional only counts pods of the namespace",
+			strategy:    model.NodePoolDistributionProportional,
+			podTemplate: webTemplate,
+			namespace:   "other",
+			nodePools:   bothPools,
+			pods:        4,
+			expected:    map[string]int64{"pool-a": 0, "pool-b": 4},
+		},
+		{
+			name:        "preferred affinity weights",
+			strategy:    model.NodePoolDistributionPreferredAffinity,
+			podTemplate: preferPoolA,
+			namespace:   "default",
+			nodePools:   bothPools,
+			pods:        10,
+			expected:    map[string]int64{"pool-a": 8, "pool-b": 2},
+		},
+		{
+			name:        "preferred affinity without affinity splits evenly",
+			strategy:    model.NodePoolDistributionPreferredAffinity,
+			podTemplate: webTemplate,
+			namespace:   "default",
+			nodePools:   bothPools,
+			pods:        10,
+			expected:    map[string]int64{"pool-a": 5, "pool-b": 5},
+		},
+		{
+			name:            "primary pool takes every pod",
+			strategy:        model.NodePoolDistributionPrimaryPool,
+			primaryNodePool: "pool-b",
+			podTemplate:     webTemplate,
+			namespace:       "default",
+			nodePools:       bothPools,
+			pods:            10,
+			expected:        map[string]int64{"pool-b": 10},
+		},
+		{
+			name:            "unmatched primary pool falls back to the running pods",
+			strategy:        model.NodePoolDistributionPrimaryPool,
+			primaryNodePool: "pool-c",
+			podTemplate:     webTemplate,
+			namespace:       "default",
+			nodePools:       bothPools,
+			pods:            10,
+			expected:        map[string]int64{"pool-a": 8, "pool-b": 2},
+		},
+		{
+			name:        "single node pool",
+			strategy:    model.NodePoolDistributionProportional,
+			podTemplate: webTemplate,
+			namespace:   "default",
+			nodePools:   []string{"pool-b"},
+			pods:        7,
+			expected:    map[string]int64{"pool-b": 7},
+		},
+		{
+			name:        "no node pools",
+			strategy:    model.NodePoolDistributionProportional,
+			podTemplate: webTemplate,
+			namespace:   "default",
+			pods:        7,
+			expected:    map[string]int64{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				distributor := newNodePoolDemandDistributor(
+					&UCEntity.Event{
+						NodePoolDistribution: testCase.strategy,
+						PrimaryNodePool:      testCase.primaryNodePool,
+					},
+					nodePoolsResources,
+					nodePoolByNodeName,
+					pods,
+				)
+				res, err := distributor.distribute(
+					testCase.podTemplate,
+					testCase.namespace,
+					testCase.nodePools,
+					testCase.pods,
+				)
+				if err != nil {
+					t.Fatalf("unexpected error : %s", err.Error())
+				}
+				if !reflect.DeepEqual(res, testCase.expected) {
+					t.Fatalf("expected %v, got %v", testCase.expected, res)
+				}
+			},
+		)
+	}
+}
+
+func TestSplitPodsByWeight(t *testing.T) {
+	testCases := []struct {
+		name      string
+		pods      int64
+		nodePools []string
+		weights   map[string]float64
+		expected  map[string]int64
+	}{
+		{
+			name:      "largest remainder takes the leftover pod",
+			pods:      10,
+			nodePools: []string{"pool-a", "pool-b", "pool-c"},
+			weights:   map[string]float64{"pool-a": 1, "pool-b": 1, "pool-c": 2},
+			expected:  map[string]int64{"pool-a": 3, "pool-b": 2, "pool-c": 5},
+		},
+		{
+			name:      "node pools without weight get no pods",
+			pods:      5,
+			nodePools: []string{"pool-a", "pool-b"},
+			weights:   map[string]float64{"pool-a": 3},
+			expected:  map[string]int64{"pool-a": 5, "pool-b": 0},
+		},
+		{
+			name:      "no pods",
+			pods:      0,
+			nodePools: []string{"pool-a", "pool-b"},
+			weights:   map[string]float64{"pool-a": 3, "pool-b": 1},
+			expected:  map[string]int64{"pool-a": 0, "pool-b": 0},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name, func(t *testing.T) {
+				res := splitPodsByWeight(testCase.pods, testCase.nodePools, testCase.weights)
+				if !reflect.DeepEqual(res, testCase.expected) {
+					t.Fatalf("expected %v, got %v", testCase.expected, res)
+				}
+			},
+		)
+	}
+}
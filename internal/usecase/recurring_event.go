@@ -0,0 +1,470 @@
+package useCase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant"
+	errorConstant "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/constant/errors"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/gorm/datatype"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/pkg/util"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type RecurringEvent interface {
+	RegisterRecurringEvent(tx *gorm.DB, data *UCEntity.RecurringEvent) (uuid.UUID, error)
+	GetRecurringEventByID(tx *gorm.DB, id uuid.UUID) (*UCEntity.RecurringEvent, error)
+	ListRecurringEventByClusterID(tx *gorm.DB, clusterID uuid.UUID) ([]*UCEntity.RecurringEvent, error)
+	DeleteRecurringEvent(tx *gorm.DB, id uuid.UUID) error
+	ListUpcomingOccurrences(
+		tx *gorm.DB,
+		id uuid.UUID,
+		now time.Time,
+		count int,
+	) ([]UCEntity.RecurringEventOccurrence, error)
+	UpdateOccurrence(
+		tx *gorm.DB,
+		id uuid.UUID,
+		occurrence UCEntity.RecurringEventOccurrence,
+	) error
+	MaterializeRecurringEvents(db *gorm.DB, now time.Time) error
+}
+
+type recurringEvent struct {
+	recurringEventRepo        repository.RecurringEvent
+	eventUC                   Event
+	scheduledHPAConfigUC      ScheduledHPAConfig
+	scheduledWorkloadConfigUC ScheduledWorkloadConfig
+}
+
+func newRecurringEvent(
+	recurringEventRepo repository.RecurringEvent,
+	eventUC Event,
+	scheduledHPAConfigUC ScheduledHPAConfig,
+	scheduledWorkloadConfigUC ScheduledWorkloadConfig,
+) RecurringEvent {
+	return &recurringEvent{
+		recurringEventRepo:        recurringEventRepo,
+		eventUC:                   eventUC,
+		scheduledHPAConfigUC:      scheduledHPAConfigUC,
+		scheduledWorkloadConfigUC: scheduledWorkloadConfigUC,
+	}
+}
+
+func newRecurringEventData(data *model.RecurringEvent) (*UCEntity.RecurringEvent, error) {
+	recurringEventData := &UCEntity.RecurringEvent{
+		CreatedAt:                  data.CreatedAt,
+		UpdatedAt:                  data.UpdatedAt,
+		ID:                         data.ID.GetUUID(),
+		Name:                       data.Name,
+		CronExpression:             data.CronExpression,
+		Timezone:                   data.Timezone,
+		DurationMinutes:            data.DurationMinutes,
+		ExecuteConfigOffsetMinutes: data.ExecuteConfigOffsetMinutes,
+		WatchingOffsetMinutes:      data.WatchingOffsetMinutes,
+		CalculateNodePool:          data.CalculateNodePool,
+		ForceHPAApply:              data.ForceHPAApply,
+		NodePoolDistribution:       data.NodePoolDistribution,
+		PrimaryNodePool:            data.PrimaryNodePool,
+		MergeConflicts:             data.MergeConflicts,
+		ClusterID:                  data.ClusterID.GetUUID(),
+	}
+	err := unmarshalModifiedConfigs(
+		data.ModifiedHPAConfigs,
+		data.ModifiedWorkloadConfigs,
+		&recurringEventData.ModifiedHPAConfigs,
+		&recurringEventData.ModifiedWorkloadConfigs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return recurringEventData, nil
+}
+
+func unmarshalModifiedConfigs(
+	hpaConfigs, workloadConfigs gormDatatype.JSON,
+	hpaConfigsData *[]UCEntity.EventModifiedHPAConfigData,
+	workloadConfigsData *[]UCEntity.EventModifiedWorkloadConfigData,
+) error {
+	if raw := hpaConfigs.GetRawMessage(); len(raw) > 0 {
+		if err := json.Unmarshal(raw, hpaConfigsData); err != nil {
+			return err
+		}
+	}
+	if raw := workloadConfigs.GetRawMessage(); len(raw) > 0 {
+		if err := json.Unmarshal(raw, workloadConfigsData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func marshalModifiedConfigs(
+	hpaConfigs []UCEntity.EventModifiedHPAConfigData,
+	workloadConfigs []UCEntity.EventModifiedWorkloadConfigData,
+	hpaConfigsData, workloadConfigsData *gormDatatype.JSON,
+) error {
+	hpaConfigsRaw, err := json.Marshal(hpaConfigs)
+	if err != nil {
+		return err
+	}
+	workloadConfigsRaw, err := json.Marshal(workloadConfigs)
+	if err != nil {
+		return err
+	}
+	hpaConfigsData.SetRawMessage(hpaConfigsRaw)
+	workloadConfigsData.SetRawMessage(workloadConfigsRaw)
+	return nil
+}
+
+func (r *recurringEvent) getSchedule(
+	data *UCEntity.RecurringEvent,
+) (*util.CronSchedule, *time.Location, error) {
+	schedule, err := util.ParseCronExpression(data.CronExpression)
+	if err != nil {
+		return nil, nil, err
+	}
+	location, err := time.LoadLocation(data.Timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errorConstant.TimezoneInvalid, data.Timezone)
+	}
+	return schedule, location, nil
+}
+
+func (r *recurringEvent) newOccurrence(
+	data *UCEntity.RecurringEvent,
+	startTime time.Time,
+) UCEntity.RecurringEventOccurrence {
+	return UCEntity.RecurringEventOccurrence{
+		StartTime: startTime,
+		EndTime:   startTime.Add(time.Duration(data.DurationMinutes) * time.Minute),
+		ExecuteConfigAt: startTime.Add(
+			-time.Duration(data.ExecuteConfigOffsetMinutes) * time.Minute,
+		),
+		WatchingAt: startTime.Add(-time.Duration(data.WatchingOffsetMinutes) * time.Minute),
+	}
+}
+
+func (r *recurringEvent) RegisterRecurringEvent(
+	tx *gorm.DB,
+	data *UCEntity.RecurringEvent,
+) (uuid.UUID, error) {
+	if _, _, err := r.getSchedule(data); err != nil {
+		return uuid.UUID{}, err
+	}
+	if data.WatchingOffsetMinutes > data.ExecuteConfigOffsetMinutes {
+		return uuid.UUID{}, errors.New(errorConstant.RecurringEventOffsetInvalid)
+	}
+
+	modelData := &model.RecurringEvent{
+		Name:                       data.Name,
+		CronExpression:             data.CronExpression,
+		Timezone:                   data.Timezone,
+		DurationMinutes:            data.DurationMinutes,
+		ExecuteConfigOffsetMinutes: data.ExecuteConfigOffsetMinutes,
+		WatchingOffsetMinutes:      data.WatchingOffsetMinutes,
+		CalculateNodePool:          data.CalculateNodePool,
+		ForceHPAApply:              data.ForceHPAApply,
+		NodePoolDistribution:       data.NodePoolDistribution,
+		PrimaryNodePool:            data.PrimaryNodePool,
+		MergeConflicts:             data.MergeConflicts,
+	}
+	modelData.ClusterID.SetUUID(data.ClusterID)
+	err := marshalModifiedConfigs(
+		data.ModifiedHPAConfigs,
+		data.ModifiedWorkloadConfigs,
+		&modelData.ModifiedHPAConfigs,
+		&modelData.ModifiedWorkloadConfigs,
+	)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = r.recurringEventRepo.InsertRecurringEvent(tx, modelData)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return modelData.ID.GetUUID(), nil
+}
+
+func (r *recurringEvent) GetRecurringEventByID(
+	tx *gorm.DB,
+	id uuid.UUID,
+) (*UCEntity.RecurringEvent, error) {
+	data, err := r.recurringEventRepo.GetRecurringEventByID(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newRecurringEventData(data)
+}
+
+func (r *recurringEvent) ListRecurringEventByClusterID(
+	tx *gorm.DB,
+	clusterID uuid.UUID,
+) ([]*UCEntity.RecurringEvent, error) {
+	data, err := r.recurringEventRepo.ListRecurringEventByClusterID(tx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	var output []*UCEntity.RecurringEvent
+	for _, datum := range data {
+		recurringEventData, err := newRecurringEventData(datum)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, recurringEventData)
+	}
+	return output, nil
+}
+
+func (r *recurringEvent) DeleteRecurringEvent(tx *gorm.DB, id uuid.UUID) error {
+	return r.recurringEventRepo.DeleteRecurringEvent(tx, id)
+}
+
+func (r *recurringEvent) ListUpcomingOccurrences(
+	tx *gorm.DB,
+	id uuid.UUID,
+	now time.Time,
+	count int,
+) ([]UCEntity.RecurringEventOccurrence, error) {
+	data, err := r.GetRecurringEventByID(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	schedule, location, err := r.getSchedule(data)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := r.recurringEventRepo.ListOccurrenceAfter(tx, id, now)
+	if err != nil {
+		return nil, err
+	}
+	occurrenceMap := map[int64]*model.RecurringEventOccurrence{}
+	for _, occurrence := range occurrences {
+		occurrenceMap[occurrence.StartTime.Unix()] = occurrence
+	}
+
+	var output []UCEntity.RecurringEventOccurrence
+	for startTime := schedule.Next(now.In(location)); !startTime.IsZero() && len(output) < count; startTime = schedule.Next(startTime) {
+		occurrenceData := r.newOccurrence(data, startTime)
+		if occurrence, ok := occurrenceMap[startTime.Unix()]; ok {
+			occurrenceData.Skipped = occurrence.Skipped
+			err := unmarshalModifiedConfigs(
+				occurrence.ModifiedHPAConfigs,
+				occurrence.ModifiedWorkloadConfigs,
+				&occurrenceData.ModifiedHPAConfigs,
+				&occurrenceData.ModifiedWorkloadConfigs,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if occurrence.EventID != nil {
+				eventID := occurrence.EventID.GetUUID()
+				occurrenceData.EventID = &eventID
+			}
+		}
+		output = append(output, occurrenceData)
+	}
+	return output, nil
+}
+
+func (r *recurringEvent) UpdateOccurrence(
+	tx *gorm.DB,
+	id uuid.UUID,
+	occurrenceData UCEntity.RecurringEventOccurrence,
+) error {
+	data, err := r.GetRecurringEventByID(tx, id)
+	if err != nil {
+		return err
+	}
+	schedule, location, err := r.getSchedule(data)
+	if err != nil {
+		return err
+	}
+
+	startTime := occurrenceData.StartTime.In(location)
+	if !schedule.Next(startTime.Add(-time.Minute)).Equal(startTime) {
+		return fmt.Errorf(errorConstant.RecurringEventOccurrenceInvalid, startTime)
+	}
+
+	occurrence, err := r.recurringEventRepo.GetOccurrenceForUpdate(tx, id, startTime)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		occurrence = &model.RecurringEventOccurrence{StartTime: startTime.UTC()}
+		occurrence.RecurringEventID.SetUUID(id)
+	}
+	if occurrence.EventID != nil {
+		return fmt.Errorf(
+			errorConstant.RecurringEventOccurrenceMaterialized,
+			startTime,
+			occurrence.EventID.GetUUID(),
+		)
+	}
+
+	occurrence.Skipped = occurrenceData.Skipped
+	occurrence.ModifiedHPAConfigs = nil
+	occurrence.ModifiedWorkloadConfigs = nil
+	if occurrenceData.ModifiedHPAConfigs != nil || occurrenceData.ModifiedWorkloadConfigs != nil {
+		err = marshalModifiedConfigs(
+			occurrenceData.ModifiedHPAConfigs,
+			occurrenceData.ModifiedWorkloadConfigs,
+			&occurrence.ModifiedHPAConfigs,
+			&occurrence.ModifiedWorkloadConfigs,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if occurrence.ID.GetUUID() == uuid.Nil {
+		_, err = r.recurringEventRepo.InsertOccurrenceIfNotExist(tx, occurrence)
+		return err
+	}
+	return r.recurringEventRepo.SaveOccurrence(tx, occurrence)
+}
+
+// MaterializeRecurringEvents creates the events of occurrences configured within the materialize window
+func (r *recurringEvent) MaterializeRecurringEvents(db *gorm.DB, now time.Time) error {
+	recurringEvents, err := r.recurringEventRepo.ListAllRecurringEvent(db)
+	if err != nil {
+		return err
+	}
+
+	for _, recurringEventModel := range recurringEvents {
+		data, err := newRecurringEventData(recurringEventModel)
+		if err != nil {
+			return err
+		}
+		schedule, location, err := r.getSchedule(data)
+		if err != nil {
+			log.Errorf("[RecurringEvent] Recurring event : %s, Error : %s", data.Name, err.Error())
+			continue
+		}
+
+		for startTime := schedule.Next(now.In(location)); !startTime.IsZero(); startTime = schedule.Next(startTime) {
+			occurrence := r.newOccurrence(data, startTime)
+			if occurrence.ExecuteConfigAt.After(now.Add(constant.RecurringEventMaterializeAhead)) {
+				break
+			}
+			err := r.materializeOccurrence(db, data, occurrence)
+			if err != nil {
+				log.Errorf(
+					"[RecurringEvent] Recurring event : %s, Occurrence %s, Error : %s",
+					data.Name,
+					startTime,
+					err.Error(),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *recurringEvent) materializeOccurrence(
+	db *gorm.DB,
+	data *UCEntity.RecurringEvent,
+	occurrenceData UCEntity.RecurringEventOccurrence,
+) error {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	occurrence, err := r.recurringEventRepo.GetOccurrenceForUpdate(tx, data.ID, occurrenceData.StartTime)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		occurrence = &model.RecurringEventOccurrence{StartTime: occurrenceData.StartTime.UTC()}
+		occurrence.RecurringEventID.SetUUID(data.ID)
+		inserted, err := r.recurringEventRepo.InsertOccurrenceIfNotExist(tx, occurrence)
+		if err != nil || !inserted {
+			return err
+		}
+	}
+	if occurrence.Skipped || occurrence.EventID != nil {
+		return nil
+	}
+
+	hpaConfigs := data.ModifiedHPAConfigs
+	workloadConfigs := data.ModifiedWorkloadConfigs
+	if len(occurrence.ModifiedHPAConfigs) > 0 || len(occurrence.ModifiedWorkloadConfigs) > 0 {
+		hpaConfigs, workloadConfigs = nil, nil
+		err = unmarshalModifiedConfigs(
+			occurrence.ModifiedHPAConfigs,
+			occurrence.ModifiedWorkloadConfigs,
+			&hpaConfigs,
+			&workloadConfigs,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	eventData := &UCEntity.Event{
+		Name: fmt.Sprintf(
+			"%s %s",
+			data.Name,
+			occurrenceData.StartTime.Format(constant.RecurringEventNameTimeFormat),
+		),
+		ExecuteConfigAt:      occurrenceData.ExecuteConfigAt,
+		WatchingAt:           occurrenceData.WatchingAt,
+		StartTime:            occurrenceData.StartTime,
+		EndTime:              occurrenceData.EndTime,
+		CalculateNodePool:    data.CalculateNodePool,
+		ForceHPAApply:        data.ForceHPAApply,
+		NodePoolDistribution: data.NodePoolDistribution,
+		PrimaryNodePool:      data.PrimaryNodePool,
+	}
+	eventData.Cluster.ID = data.ClusterID
+
+	// Same conflict check as the registered events, conflicting occurrences are retried on the next run
+	conflicts, err := r.eventUC.FindConflictingEvents(tx, eventData, hpaConfigs)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		if !data.MergeConflicts {
+			var conflictNames []string
+			for _, conflict := range conflicts {
+				conflictNames = append(conflictNames, conflict.Name)
+			}
+			return fmt.Errorf(errorConstant.RecurringEventOccurrenceConflict, strings.Join(conflictNames, ", "))
+		}
+		hpaConfigs, err = r.eventUC.MergeConflictingHPAConfigs(hpaConfigs, conflicts)
+		if err != nil {
+			return err
+		}
+	}
+
+	eventID, err := r.eventUC.RegisterEvents(tx, eventData)
+	if err != nil {
+		return err
+	}
+	_, err = r.scheduledHPAConfigUC.RegisterModifiedHPAConfigs(tx, hpaConfigs, eventID)
+	if err != nil {
+		return err
+	}
+	_, err = r.scheduledWorkloadConfigUC.RegisterModifiedWorkloadConfigs(tx, workloadConfigs, eventID)
+	if err != nil {
+		return err
+	}
+
+	occurrence.EventID = &gormDatatype.UUID{}
+	occurrence.EventID.SetUUID(eventID)
+	err = r.recurringEventRepo.SaveOccurrence(tx, occurrence)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("[RecurringEvent] Recurring event : %s, Created event %s", data.Name, eventData.Name)
+	return tx.Commit().Error
+}
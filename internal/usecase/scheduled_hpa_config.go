@@ -1,6 +1,7 @@
 package useCase
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
@@ -29,14 +30,86 @@ type ScheduledHPAConfig interface {
 		status model.HPAUpdateStatus,
 		msg string,
 	) error
+	UpdateScheduledHPAConfigOriginalSpec(
+		tx *gorm.DB,
+		id uuid.UUID,
+		minReplicas *int32,
+		maxReplicas int32,
+		behavior, metrics json.RawMessage,
+	) error
+	UpdateScheduledHPAConfigRestoreStatusMessage(
+		tx *gorm.DB,
+		id uuid.UUID,
+		status model.HPAUpdateStatus,
+		msg string,
+	) error
+	UpdateScheduledHPAStepStatusMessage(
+		tx *gorm.DB,
+		id uuid.UUID,
+		status model.HPAUpdateStatus,
+		msg string,
+	) error
+	SkipPendingScheduledHPASteps(tx *gorm.DB, eventID uuid.UUID, msg string) error
 }
 
 type scheduledHPAConfig struct {
 	scheduledHPAConfigRepo repository.ScheduledHPAConfig
+	scheduledHPAStepRepo   repository.ScheduledHPAStep
 }
 
-func newScheduledHPAConfig(scheduledHPAConfigRepo repository.ScheduledHPAConfig) ScheduledHPAConfig {
-	return &scheduledHPAConfig{scheduledHPAConfigRepo: scheduledHPAConfigRepo}
+func newScheduledHPAConfig(
+	scheduledHPAConfigRepo repository.ScheduledHPAConfig,
+	scheduledHPAStepRepo repository.ScheduledHPAStep,
+) ScheduledHPAConfig {
+	return &scheduledHPAConfig{
+		scheduledHPAConfigRepo: scheduledHPAConfigRepo,
+		scheduledHPAStepRepo:   scheduledHPAStepRepo,
+	}
+}
+
+func newEventModifiedHPAConfigData(
+	hpa *model.ScheduledHPAConfig,
+) (*UCEntity.EventModifiedHPAConfigData, error) {
+	data := &UCEntity.EventModifiedHPAConfigData{
+		ID:                  hpa.ID.GetUUID(),
+		Kind:                hpa.Kind,
+		Name:                hpa.Name,
+		Status:              hpa.Status,
+		Message:             hpa.Message,
+		Namespace:           hpa.Namespace,
+		MinReplicas:         hpa.MinPods,
+		MaxReplicas:         hpa.MaxPods,
+		OriginalMinReplicas: hpa.OriginalMinPods,
+		OriginalMaxReplicas: hpa.OriginalMaxPods,
+		Behavior:            hpa.Behavior.GetRawMessage(),
+		OriginalBehavior:    hpa.OriginalBehavior.GetRawMessage(),
+		OriginalMetrics:     hpa.OriginalMetrics.GetRawMessage(),
+		RestoreStatus:       hpa.RestoreStatus,
+		RestoreMessage:      hpa.RestoreMessage,
+	}
+	for _, step := range hpa.Steps {
+		stepData := UCEntity.HPAStepData{
+			ID:          step.ID.GetUUID(),
+			ExecuteAt:   step.ExecuteAt,
+			MinReplicas: step.MinPods,
+			MaxReplicas: step.MaxPods,
+			Status:      step.Status,
+			Message:     step.Message,
+		}
+		switch step.Phase {
+		case model.HPAStepRampUp:
+			data.RampUpSteps = append(data.RampUpSteps, stepData)
+		case model.HPAStepRampDown:
+			data.RampDownSteps = append(data.RampDownSteps, stepData)
+		}
+	}
+	if metricTargets := hpa.MetricTargets.GetRawMessage(); len(metricTargets) > 0 {
+		err := json.Unmarshal(metricTargets, &data.MetricTargets)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
 }
 
 func (s *scheduledHPAConfig) RegisterModifiedHPAConfigs(
@@ -44,14 +117,31 @@ func (s *scheduledHPAConfig) RegisterModifiedHPAConfigs(
 	modifiedHPAs []UCEntity.EventModifiedHPAConfigData,
 	eventID uuid.UUID,
 ) ([]uuid.UUID, error) {
+	if len(modifiedHPAs) == 0 {
+		return nil, nil
+	}
+
 	var data []*model.ScheduledHPAConfig
 	for _, modifiedHPA := range modifiedHPAs {
 		modelData := &model.ScheduledHPAConfig{
+			Kind:      modifiedHPA.Kind,
 			Name:      modifiedHPA.Name,
 			MinPods:   modifiedHPA.MinReplicas,
 			MaxPods:   modifiedHPA.MaxReplicas,
 			Namespace: modifiedHPA.Namespace,
 		}
+		modelData.Behavior.SetRawMessage(modifiedHPA.Behavior)
+		if len(modifiedHPA.MetricTargets) > 0 {
+			metricTargets, err := json.Marshal(modifiedHPA.MetricTargets)
+			if err != nil {
+				return nil, err
+			}
+			modelData.MetricTargets.SetRawMessage(metricTargets)
+		}
+		modelData.Steps = append(
+			newScheduledHPASteps(model.HPAStepRampUp, modifiedHPA.RampUpSteps),
+			newScheduledHPASteps(model.HPAStepRampDown, modifiedHPA.RampDownSteps)...,
+		)
 		modelData.EventID.SetUUID(eventID)
 		data = append(
 			data, modelData,
@@ -86,17 +176,11 @@ func (s *scheduledHPAConfig) ListScheduledHPAConfigByEventID(
 
 	var eventModifiedHPAConfigData []*UCEntity.EventModifiedHPAConfigData
 	for _, hpa := range scheduledHPAConfigs {
-		eventModifiedHPAConfigData = append(
-			eventModifiedHPAConfigData, &UCEntity.EventModifiedHPAConfigData{
-				ID:          hpa.ID.GetUUID(),
-				Name:        hpa.Name,
-				Status:      hpa.Status,
-				Message:     hpa.Message,
-				Namespace:   hpa.Namespace,
-				MinReplicas: hpa.MinPods,
-				MaxReplicas: hpa.MaxPods,
-			},
-		)
+		data, err := newEventModifiedHPAConfigData(hpa)
+		if err != nil {
+			return nil, err
+		}
+		eventModifiedHPAConfigData = append(eventModifiedHPAConfigData, data)
 	}
 
 	return eventModifiedHPAConfigData, nil
@@ -125,3 +209,89 @@ func (s *scheduledHPAConfig) UpdateScheduledHPAConfigStatusMessage(
 
 	return s.scheduledHPAConfigRepo.SaveScheduledHPAConfig(tx, scheduledHPAConfigData)
 }
+
+func (s *scheduledHPAConfig) UpdateScheduledHPAConfigOriginalSpec(
+	tx *gorm.DB,
+	id uuid.UUID,
+	minReplicas *int32,
+	maxReplicas int32,
+	behavior, metrics json.RawMessage,
+) error {
+	scheduledHPAConfigData, err := s.scheduledHPAConfigRepo.GetScheduledHPAConfigByID(tx, id)
+	if err != nil {
+		return err
+	}
+
+	scheduledHPAConfigData.OriginalMinPods = minReplicas
+	scheduledHPAConfigData.OriginalMaxPods = &maxReplicas
+	scheduledHPAConfigData.OriginalBehavior.SetRawMessage(behavior)
+	scheduledHPAConfigData.OriginalMetrics.SetRawMessage(metrics)
+
+	return s.scheduledHPAConfigRepo.SaveScheduledHPAConfig(tx, scheduledHPAConfigData)
+}
+
+func (s *scheduledHPAConfig) UpdateScheduledHPAConfigRestoreStatusMessage(
+	tx *gorm.DB,
+	id uuid.UUID,
+	status model.HPAUpdateStatus,
+	msg string,
+) error {
+	scheduledHPAConfigData, err := s.scheduledHPAConfigRepo.GetScheduledHPAConfigByID(tx, id)
+	if err != nil {
+		return err
+	}
+
+	scheduledHPAConfigData.RestoreStatus = status
+	scheduledHPAConfigData.RestoreMessage = msg
+
+	return s.scheduledHPAConfigRepo.SaveScheduledHPAConfig(tx, scheduledHPAConfigData)
+}
+
+func newScheduledHPASteps(
+	phase model.HPAStepPhase,
+	steps []UCEntity.HPAStepData,
+) []model.ScheduledHPAStep {
+	var data []model.ScheduledHPAStep
+	for _, step := range steps {
+		data = append(
+			data, model.ScheduledHPAStep{
+				Phase:     phase,
+				ExecuteAt: step.ExecuteAt,
+				MinPods:   step.MinReplicas,
+				MaxPods:   step.MaxReplicas,
+			},
+		)
+	}
+	return data
+}
+
+func (s *scheduledHPAConfig) UpdateScheduledHPAStepStatusMessage(
+	tx *gorm.DB,
+	id uuid.UUID,
+	status model.HPAUpdateStatus,
+	msg string,
+) error {
+	scheduledHPAStepData, err := s.scheduledHPAStepRepo.GetScheduledHPAStepByID(tx, id)
+	if err != nil {
+		return err
+	}
+
+	scheduledHPAStepData.Status = status
+	scheduledHPAStepData.Message = msg
+
+	return s.scheduledHPAStepRepo.SaveScheduledHPAStep(tx, scheduledHPAStepData)
+}
+
+func (s *scheduledHPAConfig) SkipPendingScheduledHPASteps(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+	msg string,
+) error {
+	return s.scheduledHPAStepRepo.UpdateScheduledHPAStepStatusByEventID(
+		tx,
+		eventID,
+		model.HPAUpdatePending,
+		model.HPAUpdateSkipped,
+		msg,
+	)
+}
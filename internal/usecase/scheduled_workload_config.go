@@ -0,0 +1,196 @@
+package useCase
+
+import (
+	"github.com/google/uuid"
+	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type ScheduledWorkloadConfig interface {
+	RegisterModifiedWorkloadConfigs(
+		tx *gorm.DB,
+		modifiedWorkloads []UCEntity.EventModifiedWorkloadConfigData,
+		eventID uuid.UUID,
+	) ([]uuid.UUID, error)
+	DeleteEventModifiedWorkloadConfigs(tx *gorm.DB, eventID uuid.UUID) error
+	SoftDeleteEventModifiedWorkloadConfigs(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+	) error
+	ListScheduledWorkloadConfigByEventID(
+		tx *gorm.DB,
+		eventID uuid.UUID,
+	) ([]*UCEntity.EventModifiedWorkloadConfigData, error)
+	UpdateScheduledWorkloadConfigStatusMessage(
+		tx *gorm.DB,
+		id uuid.UUID,
+		status model.WorkloadUpdateStatus,
+		msg string,
+	) error
+	UpdateScheduledWorkloadConfigOriginalReplicas(
+		tx *gorm.DB,
+		id uuid.UUID,
+		replicas int32,
+	) error
+	UpdateScheduledWorkloadConfigRestoreStatusMessage(
+		tx *gorm.DB,
+		id uuid.UUID,
+		status model.WorkloadUpdateStatus,
+		msg string,
+	) error
+}
+
+type scheduledWorkloadConfig struct {
+	scheduledWorkloadConfigRepo repository.ScheduledWorkloadConfig
+}
+
+func newScheduledWorkloadConfig(
+	scheduledWorkloadConfigRepo repository.ScheduledWorkloadConfig,
+) ScheduledWorkloadConfig {
+	return &scheduledWorkloadConfig{scheduledWorkloadConfigRepo: scheduledWorkloadConfigRepo}
+}
+
+func newEventModifiedWorkloadConfigData(
+	workload *model.ScheduledWorkloadConfig,
+) *UCEntity.EventModifiedWorkloadConfigData {
+	return &UCEntity.EventModifiedWorkloadConfigData{
+		ID:               workload.ID.GetUUID(),
+		Kind:             workload.Kind,
+		Name:             workload.Name,
+		Namespace:        workload.Namespace,
+		Replicas:         workload.Replicas,
+		OriginalReplicas: workload.OriginalReplicas,
+		Status:           workload.Status,
+		Message:          workload.Message,
+		RestoreStatus:    workload.RestoreStatus,
+		RestoreMessage:   workload.RestoreMessage,
+	}
+}
+
+func (s *scheduledWorkloadConfig) RegisterModifiedWorkloadConfigs(
+	tx *gorm.DB,
+	modifiedWorkloads []UCEntity.EventModifiedWorkloadConfigData,
+	eventID uuid.UUID,
+) ([]uuid.UUID, error) {
+	if len(modifiedWorkloads) == 0 {
+		return nil, nil
+	}
+
+	var data []*model.ScheduledWorkloadConfig
+	for _, modifiedWorkload := range modifiedWorkloads {
+		modelData := &model.ScheduledWorkloadConfig{
+			Kind:      modifiedWorkload.Kind,
+			Name:      modifiedWorkload.Name,
+			Namespace: modifiedWorkload.Namespace,
+			Replicas:  modifiedWorkload.Replicas,
+		}
+		modelData.EventID.SetUUID(eventID)
+		data = append(data, modelData)
+	}
+	err := s.scheduledWorkloadConfigRepo.InsertBatchScheduledWorkloadConfig(tx, data)
+	if err != nil {
+		return nil, err
+	}
+	var uuids []uuid.UUID
+	for _, datum := range data {
+		uuids = append(uuids, datum.ID.GetUUID())
+	}
+	return uuids, nil
+}
+
+func (s *scheduledWorkloadConfig) DeleteEventModifiedWorkloadConfigs(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) error {
+	return s.scheduledWorkloadConfigRepo.DeletePermanentAllWorkloadConfigByEventID(tx, eventID)
+}
+
+func (s *scheduledWorkloadConfig) SoftDeleteEventModifiedWorkloadConfigs(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) error {
+	return s.scheduledWorkloadConfigRepo.DeleteAllWorkloadConfigByEventID(tx, eventID)
+}
+
+func (s *scheduledWorkloadConfig) ListScheduledWorkloadConfigByEventID(
+	tx *gorm.DB,
+	eventID uuid.UUID,
+) ([]*UCEntity.EventModifiedWorkloadConfigData, error) {
+	scheduledWorkloadConfigs, err := s.scheduledWorkloadConfigRepo.ListScheduledWorkloadConfigByEventID(
+		tx,
+		eventID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventModifiedWorkloadConfigData []*UCEntity.EventModifiedWorkloadConfigData
+	for _, workload := range scheduledWorkloadConfigs {
+		eventModifiedWorkloadConfigData = append(
+			eventModifiedWorkloadConfigData,
+			newEventModifiedWorkloadConfigData(workload),
+		)
+	}
+
+	return eventModifiedWorkloadConfigData, nil
+}
+
+func (s *scheduledWorkloadConfig) UpdateScheduledWorkloadConfigStatusMessage(
+	tx *gorm.DB,
+	id uuid.UUID,
+	status model.WorkloadUpdateStatus,
+	msg string,
+) error {
+	scheduledWorkloadConfigData, err := s.scheduledWorkloadConfigRepo.GetScheduledWorkloadConfigByID(
+		tx,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	scheduledWorkloadConfigData.Status = status
+	scheduledWorkloadConfigData.Message = msg
+
+	return s.scheduledWorkloadConfigRepo.SaveScheduledWorkloadConfig(tx, scheduledWorkloadConfigData)
+}
+
+func (s *scheduledWorkloadConfig) UpdateScheduledWorkloadConfigOriginalReplicas(
+	tx *gorm.DB,
+	id uuid.UUID,
+	replicas int32,
+) error {
+	scheduledWorkloadConfigData, err := s.scheduledWorkloadConfigRepo.GetScheduledWorkloadConfigByID(
+		tx,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	scheduledWorkloadConfigData.OriginalReplicas = &replicas
+
+	return s.scheduledWorkloadConfigRepo.SaveScheduledWorkloadConfig(tx, scheduledWorkloadConfigData)
+}
+
+func (s *scheduledWorkloadConfig) UpdateScheduledWorkloadConfigRestoreStatusMessage(
+	tx *gorm.DB,
+	id uuid.UUID,
+	status model.WorkloadUpdateStatus,
+	msg string,
+) error {
+	scheduledWorkloadConfigData, err := s.scheduledWorkloadConfigRepo.GetScheduledWorkloadConfigByID(
+		tx,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	scheduledWorkloadConfigData.RestoreStatus = status
+	scheduledWorkloadConfigData.RestoreMessage = msg
+
+	return s.scheduledWorkloadConfigRepo.SaveScheduledWorkloadConfig(tx, scheduledWorkloadConfigData)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	UCEntity "github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/entity/usecase"
 	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository"
+	"github.com/hsjsjsj009/kubeEP/kubeEP-BE/internal/repository/model"
 	"gorm.io/gorm"
 )
 
@@ -20,23 +21,37 @@ type Statistic interface {
 		tx *gorm.DB,
 		scheduledHPAConfigID uuid.UUID,
 	) ([]*UCEntity.HPAStatusData, error)
+	GetAllWorkloadStatusByScheduledWorkloadConfigID(
+		tx *gorm.DB,
+		scheduledWorkloadConfigID uuid.UUID,
+	) ([]*UCEntity.WorkloadStatusData, error)
+	UpdateUpdatedNodePoolRestoreStatusMessage(
+		tx *gorm.DB,
+		id uuid.UUID,
+		status model.NodePoolUpdateStatus,
+		msg string,
+	) error
+	UpdateUpdatedNodePoolMaxNode(tx *gorm.DB, id uuid.UUID, maxNode int32) error
 }
 
 type statistic struct {
 	updatedNodePoolRepo repository.UpdatedNodePool
 	hpaStatusRepo       repository.HPAStatus
 	nodePoolStatusRepo  repository.NodePoolStatus
+	workloadStatusRepo  repository.WorkloadStatus
 }
 
 func newStatistic(
 	updatedNodePoolRepo repository.UpdatedNodePool,
 	hpaStatusRepo repository.HPAStatus,
 	nodePoolStatusRepo repository.NodePoolStatus,
+	workloadStatusRepo repository.WorkloadStatus,
 ) Statistic {
 	return &statistic{
 		updatedNodePoolRepo: updatedNodePoolRepo,
 		hpaStatusRepo:       hpaStatusRepo,
 		nodePoolStatusRepo:  nodePoolStatusRepo,
+		workloadStatusRepo:  workloadStatusRepo,
 	}
 }
 
@@ -52,9 +67,19 @@ func (u *statistic) GetAllUpdatedNodePoolByEvent(
 	for _, d := range data {
 		output = append(
 			output, &UCEntity.UpdatedNodePoolData{
-				ID:           d.ID.GetUUID(),
-				NodePoolName: d.NodePoolName,
-				MaxNode:      d.MaxNode,
+				ID:                         d.ID.GetUUID(),
+				NodePoolName:               d.NodePoolName,
+				MaxNode:                    d.MaxNode,
+				OriginalMinNode:            d.OriginalMinNode,
+				OriginalMaxNode:            d.OriginalMaxNode,
+				OriginalAutoscalingEnabled: d.OriginalAutoscalingEnabled,
+				OriginalAutoprovisioned:    d.OriginalAutoprovisioned,
+				OriginalTotalNodeLimit:     d.OriginalTotalNodeLimit,
+				OriginalLocationPolicy:     d.OriginalLocationPolicy,
+				AutoscalingModified:        d.AutoscalingModified,
+				DistributionStrategy:       d.DistributionStrategy,
+				RestoreStatus:              d.RestoreStatus,
+				RestoreMessage:             d.RestoreMessage,
 			},
 		)
 	}
@@ -106,3 +131,58 @@ func (u *statistic) GetAllHPAStatusByScheduledHPAConfigID(
 	}
 	return output, nil
 }
+
+func (u *statistic) GetAllWorkloadStatusByScheduledWorkloadConfigID(
+	tx *gorm.DB,
+	scheduledWorkloadConfigID uuid.UUID,
+) ([]*UCEntity.WorkloadStatusData, error) {
+	var output []*UCEntity.WorkloadStatusData
+	data, err := u.workloadStatusRepo.GetAllWorkloadStatusByScheduledWorkloadConfigID(
+		tx,
+		scheduledWorkloadConfigID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range data {
+		output = append(
+			output, &UCEntity.WorkloadStatusData{
+				CreatedAt:           d.CreatedAt,
+				Replicas:            d.Replicas,
+				ReadyReplicas:       d.ReadyReplicas,
+				AvailableReplicas:   d.AvailableReplicas,
+				UnavailableReplicas: d.UnavailableReplicas,
+			},
+		)
+	}
+	return output, nil
+}
+
+func (u *statistic) UpdateUpdatedNodePoolRestoreStatusMessage(
+	tx *gorm.DB,
+	id uuid.UUID,
+	status model.NodePoolUpdateStatus,
+	msg string,
+) error {
+	updatedNodePoolData, err := u.updatedNodePoolRepo.GetUpdatedNodePoolByID(tx, id)
+	if err != nil {
+		return err
+	}
+
+	updatedNodePoolData.RestoreStatus = status
+	updatedNodePoolData.RestoreMessage = msg
+
+	return u.updatedNodePoolRepo.SaveUpdatedNodePool(tx, updatedNodePoolData)
+}
+
+func (u *statistic) UpdateUpdatedNodePoolMaxNode(tx *gorm.DB, id uuid.UUID, maxNode int32) error {
+	updatedNodePoolData, err := u.updatedNodePoolRepo.GetUpdatedNodePoolByID(tx, id)
+	if err != nil {
+		return err
+	}
+
+	updatedNodePoolData.MaxNode = maxNode
+	updatedNodePoolData.AutoscalingModified = true
+
+	return u.updatedNodePoolRepo.SaveUpdatedNodePool(tx, updatedNodePoolData)
+}